@@ -22,6 +22,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"libdb.so/acm-christmas/internal/csvutil"
 	"libdb.so/acm-christmas/internal/xdraw"
+	"libdb.so/acm-christmas/lib/ledpos"
 	"libdb.so/acm-christmas/lib/vision"
 
 	_ "golang.org/x/image/bmp"
@@ -37,6 +38,7 @@ var (
 	outDir    = ""
 	csvName   = "led-points.csv"
 	outputPNG = false
+	allowMiss = false
 	maxJobs   = runtime.NumCPU()
 )
 
@@ -61,6 +63,7 @@ func main() {
 	pflag.StringVar(&csvName, "csv-name", csvName, "Points CSV output file name")
 	pflag.StringVarP(&outDir, "out-dir", "o", outDir, "Output directory, empty to use temp dir")
 	pflag.BoolVar(&outputPNG, "output-png", outputPNG, "Output PNG files")
+	pflag.BoolVar(&allowMiss, "allow-missing", allowMiss, "Write images without a spot as LEDs with no area instead of failing")
 	pflag.Parse()
 
 	if maskFile != "" {
@@ -180,7 +183,14 @@ func run(ctx context.Context) error {
 	boundingBox := findBoundingBox(result)
 	// Translate all points to the top left corner of the bounding box.
 	for i := range result {
+		if result[i].Spot.Area == 0 {
+			continue
+		}
 		result[i].Spot.Center = result[i].Spot.Center.Sub(boundingBox.Min)
+		result[i].Spot.Centroid = result[i].Spot.Centroid.Sub(ledpos.Point{
+			X: float64(boundingBox.Min.X),
+			Y: float64(boundingBox.Min.Y),
+		})
 	}
 
 	if err := os.MkdirAll(outDir, 0755); err != nil {
@@ -205,7 +215,9 @@ func run(ctx context.Context) error {
 func findBoundingBox(results []processingResult) image.Rectangle {
 	pts := make([]image.Point, 0, len(results))
 	for _, result := range results {
-		pts = append(pts, result.Spot.Center)
+		if result.Spot.Area > 0 {
+			pts = append(pts, result.Spot.Center)
+		}
 	}
 	return xdraw.BoundingBox(pts)
 }
@@ -214,17 +226,19 @@ func createCSVOutput(results []processingResult) error {
 	csvPath := filepath.Join(outDir, csvName)
 	log.Println("writing CSV file to", csvPath)
 
+	// X and Y are the centroids of the spots, which are between pixels for
+	// most spots. Whole numbers are still written without a decimal point.
 	type record struct {
-		X    int
-		Y    int
+		X    float64
+		Y    float64
 		Area int
 	}
 
 	records := make([]record, 0, len(results))
 	for _, r := range results {
 		records = append(records, record{
-			X:    r.Spot.Center.X,
-			Y:    r.Spot.Center.Y,
+			X:    r.Spot.Centroid.X,
+			Y:    r.Spot.Centroid.Y,
 			Area: r.Spot.Area,
 		})
 	}
@@ -239,6 +253,10 @@ func createPNGOutput(results []processingResult, boundingBox image.Rectangle) er
 	log.Println("writing PNG images to", outDir)
 
 	for i, r := range results {
+		if r.Spot.Filled == nil {
+			continue
+		}
+
 		pngPath := filepath.Join(outDir, padDigits(i, len(results))+".png")
 
 		pngFile, err := os.Create(pngPath)
@@ -294,6 +312,10 @@ func (p *processor) process(ctx context.Context, inputImage string) (processingR
 	p.spots.Reset(img)
 
 	biggest, err := p.spots.FindBiggestSpot(spotColor.AsColor())
+	if errors.Is(err, vision.ErrNoSpots) && allowMiss {
+		log.Printf("%s: no spot found, writing it as missing", inputImage)
+		return processingResult{File: inputImage}, nil
+	}
 	if err != nil {
 		return result, fmt.Errorf("failed to find biggest spot: %w", err)
 	}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	_ "embed"
+
+	"github.com/spf13/pflag"
+	"libdb.so/acm-christmas/internal/csvutil"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+//go:embed README
+var readme string
+
+var (
+	output      = "led-points.csv"
+	maxResidual = 5.0
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	pflag.Usage = func() {
+		log.Println(readme)
+		log.Printf("Usage:")
+		log.Printf("  %s [options] <led-points.csv>...", os.Args[0])
+		log.Printf("")
+		log.Printf("Options:")
+		pflag.PrintDefaults()
+	}
+
+	pflag.StringVarP(&output, "output", "o", output, "Fused points CSV output file")
+	pflag.Float64Var(&maxResidual, "max-residual", maxResidual, "Warn about LEDs whose captures are further apart than this, in pixels")
+	pflag.Parse()
+
+	if pflag.NArg() == 0 {
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(pflag.Args()); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(files []string) error {
+	captures := make([][]ledpos.Spot, len(files))
+	for i, file := range files {
+		spots, err := ledpos.ReadSpotsCSVFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read LED points from %q: %w", file, err)
+		}
+		captures[i] = spots
+	}
+
+	fusion, err := ledpos.Fuse(captures)
+	if err != nil {
+		return fmt.Errorf("failed to fuse captures: %w", err)
+	}
+
+	for i, t := range fusion.Transforms[1:] {
+		log.Printf("%s: scaled by %.3f, rotated by %.2f°, moved by %v",
+			files[i+1], t.Scale(), t.Rotation()*180/math.Pi, t.T)
+	}
+
+	type record struct {
+		X          float64
+		Y          float64
+		Confidence float64
+		Residual   float64
+	}
+
+	records := make([]record, len(fusion.Points))
+	for i, p := range fusion.Points {
+		records[i] = record{
+			X:          p.X,
+			Y:          p.Y,
+			Confidence: p.Confidence,
+			Residual:   p.Residual,
+		}
+
+		switch {
+		case p.Views == 0:
+			log.Printf("LED %d: not found in any capture, guessed from its neighbours", i)
+		case p.Residual > maxResidual:
+			log.Printf("LED %d: captures are %.1f pixels apart", i, p.Residual)
+		}
+	}
+
+	if err := csvutil.MarshalFile(output, records); err != nil {
+		return fmt.Errorf("failed to write fused points: %w", err)
+	}
+
+	log.Println("wrote", len(records), "fused points to", output)
+	return nil
+}
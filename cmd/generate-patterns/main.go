@@ -1,20 +1,33 @@
 package main
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"image"
 	"log"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/spf13/pflag"
-	"libdb.so/acm-christmas/internal/csvutil"
+	"libdb.so/acm-christmas/lib/effects"
+	"libdb.so/acm-christmas/lib/ledanim"
+	"libdb.so/acm-christmas/lib/ledpos"
 )
 
 var (
-	ledPoints = "led-points.csv"
-	format    = "json"
+	ledPoints  = "led-points.csv"
+	ledPoints3 = ""
+	format     = "json"
+	duration   = 5 * time.Second
+	interval   = 50 * time.Millisecond
+	loop       = false
+
+	maxSteps      uint64
+	scriptTimeout time.Duration
 )
 
 func main() {
@@ -24,19 +37,27 @@ func main() {
 		log.Printf("generate-patterns generates patterns for the LED strips.")
 		log.Printf("")
 		log.Printf("Usage:")
-		log.Printf("  %s [options] <pattern>", os.Args[0])
+		log.Printf("  %s [options] <pattern | script.star>", os.Args[0])
 		log.Printf("")
 		log.Printf("Patterns:")
 		for _, p := range listPatterns() {
 			log.Printf("  %s", p)
 		}
 		log.Printf("")
+		log.Printf("A Starlark effect script is rendered like the effect patterns.")
+		log.Printf("")
 		log.Printf("Options:")
 		pflag.PrintDefaults()
 	}
 
 	pflag.StringVarP(&ledPoints, "led-points", "i", ledPoints, "path to the CSV file containing the LED points")
-	pflag.StringVarP(&format, "format", "f", format, "output format (json, go)")
+	pflag.StringVar(&ledPoints3, "led-points-3d", ledPoints3, "path to the CSV file containing the 3D LED points from reconstruct-3d, for effects")
+	pflag.StringVarP(&format, "format", "f", format, "output format (json, go for scan-up; json, anim, csv for effects)")
+	pflag.DurationVarP(&duration, "duration", "d", duration, "duration of effects")
+	pflag.DurationVar(&interval, "interval", interval, "time between frames of effects")
+	pflag.BoolVar(&loop, "loop", loop, "make effects jump back to the first frame at the end")
+	pflag.Uint64Var(&maxSteps, "max-steps", maxSteps, "maximum number of steps that a script may take per frame (0: default)")
+	pflag.DurationVar(&scriptTimeout, "script-timeout", scriptTimeout, "maximum time that a script may take per frame (0: default)")
 	pflag.Parse()
 
 	if err := do(); err != nil {
@@ -48,6 +69,13 @@ var patterns = map[string]func() error{
 	"scan-up": scanUp,
 }
 
+func init() {
+	for _, name := range effects.Presets() {
+		name := name
+		patterns[name] = func() error { return effect(name) }
+	}
+}
+
 func listPatterns() []string {
 	var out []string
 	for k := range patterns {
@@ -64,6 +92,9 @@ func do() error {
 	}
 
 	fn, ok := patterns[pflag.Arg(0)]
+	if !ok && filepath.Ext(pflag.Arg(0)) == ".star" {
+		fn, ok = func() error { return script(pflag.Arg(0)) }, true
+	}
 	if !ok {
 		return fmt.Errorf("unknown pattern: %q", pflag.Arg(0))
 	}
@@ -74,13 +105,13 @@ func do() error {
 }
 
 func scanUp() error {
-	pts, err := csvutil.UnmarshalFile[image.Point](ledPoints)
+	pts, err := ledpos.ReadCSVFile(ledPoints)
 	if err != nil {
 		return fmt.Errorf("failed to read LED points: %w", err)
 	}
 
 	type ledPt struct {
-		image.Point
+		ledpos.Point
 		Index int
 	}
 
@@ -109,3 +140,91 @@ func scanUp() error {
 		return fmt.Errorf("unknown format: %q", format)
 	}
 }
+
+func effect(name string) error {
+	effect, err := effects.Preset(name)
+	if err != nil {
+		return err
+	}
+	return writeEffect(effect)
+}
+
+func script(path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	script, err := effects.NewScript(filepath.Base(path), src, effects.ScriptOpts{
+		MaxSteps: maxSteps,
+		Timeout:  scriptTimeout,
+		Print:    func(msg string) { log.Println(msg) },
+	})
+	if err != nil {
+		return err
+	}
+	return writeEffect(script)
+}
+
+func writeEffect(effect effects.Effect) error {
+	exact, err := ledpos.ReadCSVFile(ledPoints)
+	if err != nil {
+		return fmt.Errorf("failed to read LED points: %w", err)
+	}
+	pts := ledpos.ImagePoints(exact)
+
+	layout := effects.NewLayout(pts)
+	if ledPoints3 != "" {
+		pts3, err := ledpos.ReadCSVFile3(ledPoints3)
+		if err != nil {
+			return fmt.Errorf("failed to read 3D LED points: %w", err)
+		}
+		if len(pts3) != len(pts) {
+			return fmt.Errorf("got %d 3D LED points for %d LEDs", len(pts3), len(pts))
+		}
+		layout = effects.NewLayout3(pts3)
+	}
+
+	frames, err := effects.Animate(effect, layout, effects.AnimateOpts{
+		Duration: duration,
+		Interval: interval,
+		Loop:     loop,
+	})
+	if err != nil {
+		return err
+	}
+
+	anim := &ledanim.File{
+		LEDCount:     len(pts),
+		PositionHash: ledanim.HashPositions(pts),
+		Frames:       frames,
+	}
+
+	switch format {
+	case "json":
+		return ledanim.Write(os.Stdout, anim, ledanim.FormatJSON)
+	case "anim":
+		w := bufio.NewWriter(os.Stdout)
+		if err := ledanim.Write(w, anim, ledanim.FormatBinary); err != nil {
+			return err
+		}
+		return w.Flush()
+	case "csv":
+		// One frame per row: the duration followed by the color of each LED.
+		w := csv.NewWriter(os.Stdout)
+		for _, frame := range frames {
+			record := make([]string, 0, 1+len(frame.Image))
+			record = append(record, strconv.Itoa(int(frame.DurationMs)))
+			for _, c := range frame.Image {
+				record = append(record, fmt.Sprintf("%02x%02x%02x", c.R, c.G, c.B))
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+}
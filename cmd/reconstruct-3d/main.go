@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	_ "embed"
+
+	"github.com/spf13/pflag"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+//go:embed README
+var readme string
+
+var (
+	output = "led-points-3d.csv"
+	angles = []float64{}
+	axes   = []float64{}
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	pflag.Usage = func() {
+		log.Println(readme)
+		log.Printf("Usage:")
+		log.Printf("  %s [options] <led-points.csv> <led-points.csv>...", os.Args[0])
+		log.Printf("")
+		log.Printf("Options:")
+		pflag.PrintDefaults()
+	}
+
+	pflag.StringVarP(&output, "output", "o", output, "3D points CSV output file")
+	pflag.Float64SliceVarP(&angles, "angles", "a", angles, "angle of each capture in degrees, moving right around the tree (default: quarter turns apart)")
+	pflag.Float64SliceVar(&axes, "axes", axes, "X position of the trunk in each capture (default: the middle of the LEDs)")
+	pflag.Parse()
+
+	if pflag.NArg() < 2 {
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(pflag.Args()); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(files []string) error {
+	if len(angles) > 0 && len(angles) != len(files) {
+		return fmt.Errorf("got %d angles for %d captures", len(angles), len(files))
+	}
+	if len(axes) > 0 && len(axes) != len(files) {
+		return fmt.Errorf("got %d axes for %d captures", len(axes), len(files))
+	}
+
+	views := make([]ledpos.View, len(files))
+	for i, file := range files {
+		pts, err := ledpos.ReadCSVFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read LED points: %w", err)
+		}
+
+		angle := float64(i) * 90
+		if len(angles) > 0 {
+			angle = angles[i]
+		}
+
+		views[i] = ledpos.View{
+			Points: pts,
+			Angle:  angle * math.Pi / 180,
+		}
+		if len(axes) > 0 {
+			views[i].Axis = axes[i]
+		}
+	}
+
+	pts, err := ledpos.Reconstruct(views)
+	if err != nil {
+		return fmt.Errorf("failed to reconstruct LED positions: %w", err)
+	}
+
+	if err := ledpos.WriteCSVFile3(output, pts); err != nil {
+		return fmt.Errorf("failed to write 3D points: %w", err)
+	}
+
+	log.Println("wrote", len(pts), "3D points to", output)
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Jon-Bright/ledctl/pixarray"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/audio"
+	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+var (
+	ledPoints  = "led-points.csv"
+	sampleFmt  = "s16le"
+	sampleRate = 44100
+	channels   = 2
+	bands      = 8
+	fftSize    = 1024
+)
+
+func main() {
+	log.SetFlags(0)
+
+	pflag.Usage = func() {
+		log.Printf("rpi-audio lights up the tree to music. Bass lights up the bottom")
+		log.Printf("of the tree and treble lights up the top.")
+		log.Printf("")
+		log.Printf("Usage:")
+		log.Printf("  %s [options] <file.wav | ->", os.Args[0])
+		log.Printf("")
+		log.Printf("If the input is -, raw PCM audio is read from stdin, e.g.:")
+		log.Printf("  arecord -f S16_LE -r 44100 -c 2 -t raw | %s -", os.Args[0])
+		log.Printf("")
+		log.Printf("Options:")
+		pflag.PrintDefaults()
+	}
+
+	pflag.StringVarP(&ledPoints, "led-points", "i", ledPoints, "path to the CSV file containing the LED points")
+	pflag.StringVar(&sampleFmt, "format", sampleFmt, "sample format of raw PCM input (u8, s16le, s24le, s32le, f32le)")
+	pflag.IntVar(&sampleRate, "rate", sampleRate, "sample rate of raw PCM input")
+	pflag.IntVar(&channels, "channels", channels, "number of channels of raw PCM input")
+	pflag.IntVar(&bands, "bands", bands, "number of frequency bands")
+	pflag.IntVar(&fftSize, "fft-size", fftSize, "number of samples per FFT, a power of two")
+	pflag.Parse()
+
+	if pflag.NArg() != 1 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx, pflag.Arg(0)); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(ctx context.Context, input string) error {
+	exact, err := ledpos.ReadCSVFile(ledPoints)
+	if err != nil {
+		return fmt.Errorf("failed to read LED points: %w", err)
+	}
+	pts := ledpos.ImagePoints(exact)
+
+	pcm, live, err := openInput(input)
+	if err != nil {
+		return err
+	}
+
+	gen, err := audio.NewGenerator(pcm, pts, audio.GeneratorOpts{
+		Analyzer: audio.AnalyzerOpts{
+			FFTSize: fftSize,
+			Bands:   bands,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	playerOpts := animation.PlayerOpts[leddraw.LEDStrip]{
+		Interpolate: leddraw.InterpolateLEDStrip,
+		Tween:       true,
+		Copy:        leddraw.CopyLEDStrip,
+	}
+	if live {
+		// Audio arrives in real time, so keep the latency low by dropping
+		// frames instead of falling behind.
+		playerOpts.MaxFrames = 4
+		playerOpts.Overflow = animation.OverflowDropOldest
+	}
+	player := animation.NewPlayerWithOpts(playerOpts)
+
+	strip, err := pixarray.NewWS281x(
+		len(pts),     // LEDs
+		3,            // 3 bytes per pixel
+		pixarray.RGB, // RGB channel order
+		800000,       // 800 KHz
+		10,           // DMA 10
+		[]int{12},    // GPIO 12
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pixarray: %w", err)
+	}
+
+	errg, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+
+	errg.Go(func() error {
+		return player.Run(ctx)
+	})
+
+	errg.Go(func() error {
+		defer cancel()
+
+		// The player copies the frames that it shows, so a strip can be
+		// reused once MaxFrames+1 more frames have been added after it.
+		strips := make([]leddraw.LEDStrip, player.Status().MaxFrames+2)
+		for i := 0; ; i = (i + 1) % len(strips) {
+			frame, err := gen.Next(strips[i])
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return fmt.Errorf("failed to read audio: %w", err)
+			}
+			strips[i] = frame.Image
+
+			if err := player.AddFrame(ctx, frame); err != nil {
+				return err
+			}
+		}
+
+		// Let the player finish the queued frames.
+		for player.Status().Queued > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(max(player.Status().Remaining, 50*time.Millisecond)):
+			}
+		}
+		return nil
+	})
+
+	errg.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case frame := <-player.C:
+				for i, c := range frame.Image {
+					strip.SetPixel(i, pixarray.Pixel{
+						R: int(c.R),
+						G: int(c.G),
+						B: int(c.B),
+					})
+				}
+				if err := strip.Write(); err != nil {
+					return fmt.Errorf("failed to write pixels: %w", err)
+				}
+			}
+		}
+	})
+
+	if err := errg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+func openInput(input string) (pcm *audio.PCMReader, live bool, err error) {
+	if input == "-" {
+		sample, err := audio.ParseSampleFormat(sampleFmt)
+		if err != nil {
+			return nil, false, err
+		}
+		pcm, err := audio.NewPCMReader(os.Stdin, audio.Format{
+			SampleRate: sampleRate,
+			Channels:   channels,
+			Sample:     sample,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid PCM format: %w", err)
+		}
+		return pcm, true, nil
+	}
+
+	f, err := os.Open(input)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open audio: %w", err)
+	}
+
+	pcm, err = audio.NewWAVReader(f)
+	if err != nil {
+		f.Close()
+		return nil, false, fmt.Errorf("failed to read WAV file %q: %w", input, err)
+	}
+	return pcm, false, nil
+}
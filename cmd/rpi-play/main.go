@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Jon-Bright/ledctl/pixarray"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/ledanim"
+	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+var (
+	ledPoints = ""
+	tween     = false
+)
+
+func main() {
+	log.SetFlags(0)
+
+	pflag.Usage = func() {
+		log.Printf("rpi-play plays an animation file on the tree, such as one made by")
+		log.Printf("generate-patterns or tree-canvas.")
+		log.Printf("")
+		log.Printf("Usage:")
+		log.Printf("  %s [options] <file>", os.Args[0])
+		log.Printf("")
+		log.Printf("Options:")
+		pflag.PrintDefaults()
+	}
+
+	pflag.StringVarP(&ledPoints, "led-points", "i", ledPoints, "path to the CSV file containing the LED points, to check that the animation was made for them")
+	pflag.BoolVar(&tween, "tween", tween, "blend each frame into the next one")
+	pflag.Parse()
+
+	if pflag.NArg() != 1 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx, pflag.Arg(0)); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(ctx context.Context, path string) error {
+	anim, err := ledanim.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read animation: %w", err)
+	}
+	if len(anim.Frames) == 0 {
+		return fmt.Errorf("animation %q has no frames", path)
+	}
+
+	if ledPoints != "" {
+		pts, err := ledpos.ReadCSVFile(ledPoints)
+		if err != nil {
+			return fmt.Errorf("failed to read LED points: %w", err)
+		}
+		if err := anim.CheckPositions(ledpos.ImagePoints(pts)); err != nil {
+			return err
+		}
+	}
+
+	// Hold the whole animation so that its loops can jump back anywhere. The
+	// player holds at least 2 frames, even for a still image.
+	player := animation.NewPlayerWithOpts(animation.PlayerOpts[leddraw.LEDStrip]{
+		MaxFrames:   max(len(anim.Frames), 2),
+		Interpolate: leddraw.InterpolateLEDStrip,
+		Tween:       tween,
+		Copy:        leddraw.CopyLEDStrip,
+	})
+
+	strip, err := pixarray.NewWS281x(
+		anim.LEDCount, // LEDs
+		3,             // 3 bytes per pixel
+		pixarray.RGB,  // RGB channel order
+		800000,        // 800 KHz
+		10,            // DMA 10
+		[]int{12},     // GPIO 12
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pixarray: %w", err)
+	}
+
+	errg, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+
+	errg.Go(func() error {
+		return player.Run(ctx)
+	})
+
+	errg.Go(func() error {
+		defer cancel()
+
+		if err := player.AddFrames(ctx, anim.Frames); err != nil {
+			return err
+		}
+
+		// Play until the animation ends, or forever if it loops.
+		for {
+			status := player.Status()
+			if status.Queued == 0 && !status.Looping {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(max(status.Remaining, 50*time.Millisecond)):
+			}
+		}
+	})
+
+	errg.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case frame := <-player.C:
+				for i, c := range frame.Image {
+					strip.SetPixel(i, pixarray.Pixel{
+						R: int(c.R),
+						G: int(c.G),
+						B: int(c.B),
+					})
+				}
+				if err := strip.Write(); err != nil {
+					return fmt.Errorf("failed to write pixels: %w", err)
+				}
+			}
+		}
+	})
+
+	if err := errg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Jon-Bright/ledctl/pixarray"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/ffutil"
+	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+var (
+	ledPoints = "led-points.csv"
+	ppi       = 72.0
+	fit       = false
+	loop      = false
+	tween     = false
+	filters   = []string{}
+	workers   = 0
+	cacheDir  = ""
+)
+
+func main() {
+	log.SetFlags(0)
+
+	pflag.Usage = func() {
+		log.Printf("rpi-video plays a video file on the tree. The video is decoded by")
+		log.Printf("ffmpeg, so any format that ffmpeg supports works.")
+		log.Printf("")
+		log.Printf("Usage:")
+		log.Printf("  %s [options] <video>", os.Args[0])
+		log.Printf("")
+		log.Printf("Options:")
+		pflag.PrintDefaults()
+	}
+
+	pflag.StringVarP(&ledPoints, "led-points", "i", ledPoints, "path to the CSV file containing the LED points")
+	pflag.Float64Var(&ppi, "ppi", ppi, "pixels per inch")
+	pflag.BoolVar(&fit, "fit", fit, "fill or fit the video (default: fill)")
+	pflag.BoolVar(&loop, "loop", loop, "play the video over and over")
+	pflag.BoolVar(&tween, "tween", tween, "blend each frame into the next one")
+	pflag.IntVar(&workers, "workers", workers, "number of goroutines to render frames with, or -1 for one per CPU")
+	pflag.StringVar(&cacheDir, "cache-dir", cacheDir, "directory to cache the LED canvas in, so that it starts up faster next time")
+	pflag.StringSliceVarP(&filters, "filter", "f", filters, "additional ffmpeg filters")
+	pflag.Parse()
+
+	if pflag.NArg() != 1 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx, pflag.Arg(0)); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(ctx context.Context, path string) error {
+	pts, err := ledpos.ReadCSVFile(ledPoints)
+	if err != nil {
+		return fmt.Errorf("failed to read LED points: %w", err)
+	}
+	numLEDs := len(pts)
+
+	canvas, err := leddraw.NewLEDCanvasAnimated(pts, leddraw.LEDCanvasAnimatedOpts{
+		LEDCanvasOpts: leddraw.LEDCanvasOpts{PPI: ppi, Workers: workers, CacheDir: cacheDir},
+		Player:        animation.PlayerOpts[leddraw.LEDStrip]{Tween: tween},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create LED canvas: %w", err)
+	}
+	defer canvas.Close()
+
+	strip, err := pixarray.NewWS281x(
+		numLEDs,      // LEDs
+		3,            // 3 bytes per pixel
+		pixarray.RGB, // RGB channel order
+		800000,       // 800 KHz
+		10,           // DMA 10
+		[]int{12},    // GPIO 12
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pixarray: %w", err)
+	}
+
+	errg, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+
+	errg.Go(func() error {
+		return canvas.Run(ctx)
+	})
+
+	errg.Go(func() error {
+		defer cancel()
+
+		err := ffutil.PlayVideo(ctx, canvas, path, ffutil.VideoOpts{
+			Fit:     fit,
+			Loop:    loop,
+			Filters: filters,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to play video: %w", err)
+		}
+
+		// Let the player finish the queued frames.
+		for canvas.Status().Queued > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(max(canvas.Status().Remaining, 50*time.Millisecond)):
+			}
+		}
+		return nil
+	})
+
+	errg.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case frame := <-canvas.C:
+				for i, c := range frame.Image {
+					strip.SetPixel(i, pixarray.Pixel{
+						R: int(c.R),
+						G: int(c.G),
+						B: int(c.B),
+					})
+				}
+				if err := strip.Write(); err != nil {
+					return fmt.Errorf("failed to write pixels: %w", err)
+				}
+			}
+		}
+	})
+
+	if err := errg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -1,27 +1,34 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/csv"
 	"fmt"
 	"image"
 	"image/color"
+	"image/color/palette"
 	"image/draw"
+	"image/gif"
 	"image/png"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	_ "image/jpeg"
 	_ "image/png"
 
-	"github.com/disintegration/imaging"
 	"github.com/spf13/pflag"
+	"libdb.so/acm-christmas/internal/animation"
 	"libdb.so/acm-christmas/internal/csvutil"
 	"libdb.so/acm-christmas/internal/xdraw"
+	"libdb.so/acm-christmas/lib/animimage"
+	"libdb.so/acm-christmas/lib/ledanim"
 	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
 
 	_ "golang.org/x/image/bmp"
 )
@@ -30,107 +37,136 @@ import (
 // points.
 
 var (
-	ledPointsFile = "led-points.csv"
-	outputDir     = "."
-	pngImageFile  = ""
-	csvColorFile  = ""
-	goCodeFile    = ""
-	maxPtDistance = 0.0 // auto
-	ppi           = 72.0
-	fit           = false
+	ledPointsFile  = "led-points.csv"
+	outputDir      = "."
+	pngImageFile   = ""
+	csvColorFile   = ""
+	goCodeFile     = ""
+	animFile       = ""
+	previewGIFFile = ""
+	maxPtDistance  = 0.0 // auto
+	ppi            = 72.0
+	fit            = false
+	kernel         = ""
+	cacheDir       = ""
 )
 
+// kernels are the resampling kernels that --kernel accepts.
+var kernels = map[string]leddraw.KernelFunc{
+	"gaussian": leddraw.GaussianKernel,
+	"lanczos":  leddraw.NewLanczosKernel(3),
+	"box":      leddraw.BoxKernel,
+	"bilinear": leddraw.BilinearKernel,
+}
+
 func init() {
 	pflag.StringVarP(&ledPointsFile, "led-points", "i", ledPointsFile, "path to the CSV file containing the LED points")
 	pflag.StringVarP(&outputDir, "output-dir", "o", outputDir, "path to the output directory")
 	pflag.StringVar(&pngImageFile, "png-image", pngImageFile, "path to the output PNG image file")
 	pflag.StringVar(&csvColorFile, "csv-color", csvColorFile, "path to the output CSV color file")
 	pflag.StringVar(&goCodeFile, "go-code", goCodeFile, "path to the output Go code file")
+	pflag.StringVar(&animFile, "anim", animFile, "path to the output animation file (JSON if it ends in .json)")
+	pflag.StringVar(&previewGIFFile, "preview-gif", previewGIFFile, "path to the output GIF that previews the LEDs of every frame")
 	pflag.Float64Var(&maxPtDistance, "max-distance", maxPtDistance, "maximum distance between a point and an LED")
 	pflag.Float64Var(&ppi, "ppi", ppi, "pixels per inch")
 	pflag.BoolVar(&fit, "fit", fit, "fill or fit the source image (default: fill)")
+	pflag.StringVar(&kernel, "kernel", kernel, "resampling kernel: gaussian, lanczos, box or bilinear (default: average the nearest pixels)")
+	pflag.StringVar(&cacheDir, "cache-dir", cacheDir, "directory to cache the LED canvas in, so that it starts up faster next time")
 }
 
 func main() {
 	pflag.Parse()
 
-	ledPoints, err := readCSVPoints(ledPointsFile)
+	exactPoints, err := ledpos.ReadCSVFile(ledPointsFile)
 	if err != nil {
 		log.Fatalln("failed to read LED points:", err)
 	}
+	// The canvas samples the exact positions, but the outputs draw and hash
+	// the LEDs at whole pixels.
+	ledPoints := ledpos.ImagePoints(exactPoints)
 
 	var ledCanvasOpts leddraw.LEDCanvasOpts
 	ledCanvasOpts.PPI = ppi
+	ledCanvasOpts.CacheDir = cacheDir
+	// The intensity and kernel are functions, so the flags that pick them
+	// tell their caches apart.
+	ledCanvasOpts.CacheKey = fmt.Sprintf("max-distance=%v kernel=%s", maxPtDistance, kernel)
 	if maxPtDistance > 0 {
 		ledCanvasOpts.Intensity = leddraw.NewCubicIntensity(maxPtDistance)
 	}
+	if kernel != "" {
+		ledCanvasOpts.Kernel = kernels[kernel]
+		if ledCanvasOpts.Kernel == nil {
+			log.Fatalf("unknown kernel %q", kernel)
+		}
+	}
 
-	ledCanvas, err := leddraw.NewLEDCanvas(ledPoints, ledCanvasOpts)
+	ledCanvas, err := leddraw.NewLEDCanvas(exactPoints, ledCanvasOpts)
 	if err != nil {
 		log.Fatalln("failed to create LED canvas:", err)
 	}
 
-	// TODO: scale while preserving aspect ratio, and center the image.
 	canvasBounds := ledCanvas.CanvasBounds()
 
-	img, err := decodeImageFile(pflag.Arg(0))
+	anim, err := decodeAnimationFile(pflag.Arg(0))
 	if err != nil {
 		log.Fatalln("failed to decode source image:", err)
 	}
 
-	var imagedCanvas *image.NRGBA
-	if fit {
-		imagedCanvas = imaging.Fit(
-			img, canvasBounds.Dx(), canvasBounds.Dy(), imaging.NearestNeighbor)
-	} else {
-		imagedCanvas = imaging.Fill(
-			img, canvasBounds.Dx(), canvasBounds.Dy(), imaging.Center, imaging.NearestNeighbor)
-	}
-	imagedCanvas = imaging.PasteCenter(image.NewNRGBA(canvasBounds), imagedCanvas)
-
 	start := time.Now()
-	if err := ledCanvas.Render((*image.RGBA)(imagedCanvas)); err != nil {
+	frames, err := renderFrames(ledCanvas, anim)
+	if err != nil {
 		log.Fatalln("failed to render image:", err)
 	}
-	log.Println("rendered in", time.Since(start))
+	log.Println("rendered", len(frames), "frames in", time.Since(start))
+
+	// Outputs that hold a single frame use the first one.
+	leds := frames[0].Image
 
 	if pngImageFile != "" {
-		if err := writePNGImage(ledCanvas, ledPoints); err != nil {
+		if err := writePNGImage(ledCanvas, leds, ledPoints); err != nil {
 			log.Fatalln("failed to write PNG image:", err)
 		}
 	}
 
 	if csvColorFile != "" {
-		if err := writeCSVColors(ledCanvas, ledPoints); err != nil {
+		if err := writeCSVColors(leds); err != nil {
 			log.Fatalln("failed to write CSV colors:", err)
 		}
 	}
 
 	if goCodeFile != "" {
-		if err := writeGoCode(ledCanvas, ledPoints); err != nil {
+		if err := writeGoCode(leds); err != nil {
 			log.Fatalln("failed to write Go code:", err)
 		}
 	}
 
-	if pngImageFile == "" && csvColorFile == "" && goCodeFile == "" {
+	if animFile != "" {
+		if err := writeAnimation(frames, ledPoints); err != nil {
+			log.Fatalln("failed to write animation:", err)
+		}
+	}
+
+	if previewGIFFile != "" {
+		if err := writePreviewGIF(ledCanvas, frames[:len(anim.Frames)], anim, ledPoints); err != nil {
+			log.Fatalln("failed to write preview GIF:", err)
+		}
+	}
+
+	if pngImageFile == "" && csvColorFile == "" && goCodeFile == "" && animFile == "" && previewGIFFile == "" {
 		log.Println("Nothing to do.")
 		log.Println()
 		log.Println("Debug Information:")
 		log.Println("  LED bounds:  ", ledCanvas.LEDBounds())
 		log.Println("  Canvas size: ", canvasBounds.Dx(), "x", canvasBounds.Dy())
+		log.Println("  Frames:      ", len(frames), "over", anim.Duration())
 		log.Println()
 	}
 }
 
-func writePNGImage(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error {
+func writePNGImage(ledCanvas *leddraw.LEDCanvas, leds leddraw.LEDStrip, ledPoints []image.Point) error {
 	outImage := image.NewRGBA(ledCanvas.LEDBounds())
-	draw.Draw(outImage, outImage.Bounds(), image.NewUniform(color.Black), image.ZP, draw.Src)
-
-	// TODO: write these points to a CSV file.
-	// TODO: write these points to a []color.RGBA file.
-	for i, led := range ledCanvas.LEDs() {
-		xdraw.DrawCircle(outImage, ledPoints[i], 3, led)
-	}
+	drawLEDs(outImage, leds, ledPoints)
 
 	f, err := createFile(pngImageFile)
 	if err != nil {
@@ -149,13 +185,68 @@ func writePNGImage(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error
 	return nil
 }
 
-func writeCSVColors(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error {
+func writePreviewGIF(ledCanvas *leddraw.LEDCanvas, frames []animation.Frame[leddraw.LEDStrip], anim *animimage.Animation, ledPoints []image.Point) error {
+	outGIF := &gif.GIF{
+		Image: make([]*image.Paletted, len(frames)),
+		Delay: make([]int, len(frames)),
+	}
+
+	// GIF counts repeats after the first play, and -1 plays once.
+	switch anim.Plays {
+	case 0:
+		outGIF.LoopCount = 0
+	case 1:
+		outGIF.LoopCount = -1
+	default:
+		outGIF.LoopCount = anim.Plays - 1
+	}
+
+	outImage := image.NewRGBA(ledCanvas.LEDBounds())
+	for i, frame := range frames {
+		drawLEDs(outImage, frame.Image, ledPoints)
+
+		paletted := image.NewPaletted(outImage.Rect, palette.Plan9)
+		draw.Draw(paletted, paletted.Rect, outImage, outImage.Rect.Min, draw.Src)
+
+		outGIF.Image[i] = paletted
+		outGIF.Delay[i] = int(anim.Frames[i].Delay / (10 * time.Millisecond))
+	}
+
+	f, err := createFile(previewGIFFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer f.Close()
+
+	if err := gif.EncodeAll(f, outGIF); err != nil {
+		return fmt.Errorf("failed to encode GIF: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %v", err)
+	}
+
+	return nil
+}
+
+// drawLEDs draws the LEDs as circles onto a black img.
+func drawLEDs(img *image.RGBA, leds leddraw.LEDStrip, ledPoints []image.Point) {
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.ZP, draw.Src)
+
+	// TODO: write these points to a CSV file.
+	// TODO: write these points to a []color.RGBA file.
+	for i, led := range leds {
+		xdraw.DrawCircle(img, ledPoints[i], 3, led)
+	}
+}
+
+func writeCSVColors(leds leddraw.LEDStrip) error {
 	type color struct {
 		R, G, B uint8
 	}
 
-	colors := make([]color, len(ledCanvas.LEDs()))
-	for i, led := range ledCanvas.LEDs() {
+	colors := make([]color, len(leds))
+	for i, led := range leds {
 		colors[i] = color{led.R, led.G, led.B}
 	}
 
@@ -176,10 +267,10 @@ func writeCSVColors(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error
 	return nil
 }
 
-func writeGoCode(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error {
+func writeGoCode(leds leddraw.LEDStrip) error {
 	var buf bytes.Buffer
 	buf.WriteString("var ledColors = []color.RGBA{")
-	for _, led := range ledCanvas.LEDs() {
+	for _, led := range leds {
 		buf.WriteString(fmt.Sprintf("{0x%02X, 0x%02X, 0x%02X, 0xFF}, ", led.R, led.G, led.B))
 	}
 	buf.WriteString("}\n")
@@ -201,6 +292,35 @@ func writeGoCode(ledCanvas *leddraw.LEDCanvas, ledPoints []image.Point) error {
 	return nil
 }
 
+func writeAnimation(frames []animation.Frame[leddraw.LEDStrip], ledPoints []image.Point) error {
+	anim := &ledanim.File{
+		LEDCount:     len(ledPoints),
+		PositionHash: ledanim.HashPositions(ledPoints),
+		Frames:       frames,
+	}
+
+	f, err := createFile(animFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := ledanim.Write(w, anim, ledanim.FormatFromPath(animFile)); err != nil {
+		return fmt.Errorf("failed to encode animation: %v", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write to output file: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %v", err)
+	}
+
+	return nil
+}
+
 func createFile(name string) (*os.File, error) {
 	if name == "-" {
 		return os.Stdout, nil
@@ -217,21 +337,56 @@ func createFile(name string) (*os.File, error) {
 	return os.Create(filepath.Join(outputDir, name))
 }
 
-func readCSVPoints(csvPath string) ([]image.Point, error) {
-	pts, err := csvutil.UnmarshalFile[image.Point](csvPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal CSV file %q: %v", csvPath, err)
-	}
-	return pts, nil
-}
-
-func decodeImageFile(path string) (image.Image, error) {
+func decodeAnimationFile(path string) (*animimage.Animation, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	img, _, err := image.Decode(f)
-	return img, err
+	return animimage.Decode(bufio.NewReader(f))
+}
+
+// renderFrames renders each frame of the animation onto the LEDs. Frames wait
+// for the delay of the frame before them. Looping animations get extra frames
+// after their own, so that only the jump back waits for the delay of the last
+// frame; the first len(anim.Frames) frames are always the animation's own.
+func renderFrames(ledCanvas *leddraw.LEDCanvas, anim *animimage.Animation) ([]animation.Frame[leddraw.LEDStrip], error) {
+	frames := make([]animation.Frame[leddraw.LEDStrip], len(anim.Frames))
+
+	imageOpts := leddraw.ImageOpts{Scaling: leddraw.ScaleFill}
+	if fit {
+		imageOpts.Scaling = leddraw.ScaleFit
+	}
+
+	for i, frame := range anim.Frames {
+		if err := ledCanvas.RenderImage(frame.Image, imageOpts); err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i, err)
+		}
+
+		frames[i].Image = slices.Clone(ledCanvas.LEDs())
+		if i > 0 {
+			frames[i].DurationMs = animation.DurationToMs(anim.Frames[i-1].Delay)
+		}
+	}
+
+	n := len(frames)
+	if n < 2 || anim.Plays == 1 {
+		return frames, nil
+	}
+
+	// The first frame is shown right away, so the loop instead ends with a
+	// copy of it that waits for the last frame and jumps back to the second.
+	first := frames[0]
+	first.DurationMs = animation.DurationToMs(anim.Frames[n-1].Delay)
+	first.JumpBackAmount = int32(n - 1)
+	frames = append(frames, first)
+	if anim.Plays == 0 {
+		return frames, nil
+	}
+
+	// The looped frames start at the second one, so the last play is added
+	// after them to end on the last frame rather than on the first.
+	frames[n].LoopCount = uint32(anim.Plays - 1)
+	return append(frames, frames[1:n]...), nil
 }
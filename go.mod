@@ -5,7 +5,6 @@ go 1.21
 require (
 	github.com/Jon-Bright/ledctl v0.0.0-20220811175751-98f2a0ba0a4b
 	github.com/alecthomas/assert/v2 v2.3.0
-	github.com/disintegration/imaging v1.6.2
 	github.com/fogleman/poissondisc v0.0.0-20190923201222-9b82984c50c5
 	github.com/gobwas/ws v1.3.1
 	github.com/google/go-cmp v0.5.8
@@ -14,6 +13,7 @@ require (
 	github.com/pierrre/imageutil v1.0.0
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/pflag v1.0.5
+	go.starlark.net v0.0.0-20231121155337-90ade8b19d09
 	golang.org/x/exp v0.0.0-20230711023510-fffb14384f22
 	golang.org/x/image v0.9.0
 	golang.org/x/sync v0.1.0
@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"expvar"
-	"log"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
 	"time"
-
-	"gopkg.in/typ.v4/lists"
 )
 
 // Milliseconds is a duration in milliseconds.
@@ -21,65 +22,296 @@ func DurationToMs(d time.Duration) Milliseconds {
 
 // Frame is a single frame of an animation.
 type Frame[Image any] struct {
-	Image          Image
+	Image Image
+	// JumpBackAmount is the number of frames to jump back by after this frame
+	// has been shown. Zero means no jump: the player continues with the next
+	// frame.
 	JumpBackAmount int32
-	DurationMs     Milliseconds
+	// DurationMs is how long the player waits before showing this frame.
+	DurationMs Milliseconds
+	// DurationBeats, if positive, is how many beats of the player's BeatClock
+	// the player waits before showing this frame. It overrides DurationMs.
+	// Such frames are shown on the beat grid: the first one after a break
+	// waits for the next multiple of DurationBeats, and each one after it is
+	// due exactly DurationBeats after the previous one, so that they never
+	// drift from the beat. They are not affected by the playback speed.
+	DurationBeats float64
+	// LoopCount is the number of times the segment looped by this frame is
+	// played in total, including the first time. Once the segment has been
+	// played LoopCount times, the player continues with the next frame. Zero
+	// loops forever.
+	LoopCount uint32
+	// Marker names this frame so that later frames can jump back to it using
+	// JumpToMarker.
+	Marker string
+	// JumpToMarker, if not empty, makes the player jump back to the latest
+	// earlier frame whose Marker matches after this frame has been shown. It
+	// overrides JumpBackAmount. If no such frame is held by the player, the
+	// frame does not jump.
+	JumpToMarker string
 }
 
-// Duration returns the duration of the frame as a time.Duration.
+// Duration returns the duration of the frame as a time.Duration. It ignores
+// DurationBeats.
 func (f Frame[Image]) Duration() time.Duration {
 	return time.Duration(f.DurationMs) * time.Millisecond
 }
 
+// ConvertFrame returns a copy of frame with its image replaced by img.
+func ConvertFrame[From, To any](frame Frame[From], img To) Frame[To] {
+	return Frame[To]{
+		Image:          img,
+		JumpBackAmount: frame.JumpBackAmount,
+		DurationMs:     frame.DurationMs,
+		DurationBeats:  frame.DurationBeats,
+		LoopCount:      frame.LoopCount,
+		Marker:         frame.Marker,
+		JumpToMarker:   frame.JumpToMarker,
+	}
+}
+
+// OverflowPolicy describes what the player does when a frame is added while
+// it is full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowBlock blocks the sender until there is room for the frame.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest frame that has not been shown yet
+	// to make room for the new frame. This is useful for live streams, where
+	// the sender should never be held up. If no frame can be dropped, for
+	// example because the queued frames are looping, the new frame is dropped
+	// instead.
+	OverflowDropOldest
+)
+
+// EndBehavior describes what the player shows once it runs out of frames.
+type EndBehavior uint8
+
+const (
+	// EndHold keeps showing the last frame.
+	EndHold EndBehavior = iota
+	// EndDark shows PlayerOpts.Dark once the last frame has been shown for
+	// its own duration.
+	EndDark
+)
+
 // ErrFramebufferOverflow is returned when the framebuffer is full.
 var ErrFramebufferOverflow = errors.New("framebuffer overflow")
 
+// ErrFrameNotHeld is returned when seeking to a frame that the player does not
+// hold, either because it has not been added yet or because it has already
+// been dropped.
+var ErrFrameNotHeld = errors.New("frame is not held by the player")
+
 const (
-	metricDroppedFrames = "dropped_frames"
-	metricTotalFrames   = "total_frames"
-	metricFrameJitter   = "frame_jitter"
+	metricDroppedFrames  = "dropped_frames"
+	metricOverflowFrames = "overflow_frames"
+	metricTotalFrames    = "total_frames"
+	metricFrameJitter    = "frame_jitter"
 )
 
 var metrics = expvar.NewMap("animation")
 
 // Player is an animation player. It is safe to use from multiple goroutines.
+//
+// If the player blends or copies frames, the image of a frame received from C
+// is only valid until the next frame is received.
 type Player[Image any] struct {
 	C <-chan Frame[Image]
 
-	ch    chan Frame[Image]
-	addCh chan Frame[Image] // nil clears
+	ch      chan Frame[Image]
+	addCh   chan Frame[Image]
+	clearCh chan struct{}
+	ctrlCh  chan playerControl
+
+	frames frameBuffer[Image]
+	opts   PlayerOpts[Image]
 
-	insert   *lists.Ring[Frame[Image]] // points to empty slot next to last frame
-	playback *lists.Ring[Frame[Image]] // points to current frame
+	status   playerStatus
+	statusMu sync.Mutex
 }
 
-// NewPlayer creates a new animation player that can hold up to maxFrames
-// frames.
+// PlayerStatus is a snapshot of the state of a Player.
+type PlayerStatus struct {
+	// Queued is the number of frames that have been added but not shown yet.
+	Queued int
+	// MaxFrames is the maximum number of frames that the player can hold.
+	MaxFrames int
+	// FrameIndex is the index of the frame that is currently shown. Frames are
+	// indexed in the order that they were added, starting from 0. Frames that
+	// were dropped are counted too. It is -1 if no frame is shown.
+	FrameIndex int64
+	// Remaining is how long it takes until all queued frames have been shown
+	// once. Loops are not accounted for.
+	Remaining time.Duration
+	// Looping is true if the queued frames will loop, in which case playing
+	// them takes longer than Remaining, possibly forever.
+	Looping bool
+	// Paused is true if playback is paused.
+	Paused bool
+	// Speed is the playback rate. 1 is normal speed.
+	Speed float64
+	// BPM is the tempo of the player's BeatClock.
+	BPM float64
+}
+
+// playerStatus is the internal state behind PlayerStatus. Remaining is
+// computed when the status is requested.
+type playerStatus struct {
+	PlayerStatus
+	nextFrameDue time.Time // zero if no frame is scheduled
+	queuedAfter  time.Duration
+	queuedBeats  float64   // part of queuedAfter that is timed in beats
+	pausedAt     time.Time // time stands still from here while paused
+}
+
+type playerControlKind uint8
+
+const (
+	controlPause playerControlKind = iota
+	controlResume
+	controlSeek
+	controlSpeed
+)
+
+// playerControl is a request to change the playback of a running player.
+type playerControl struct {
+	kind  playerControlKind
+	index int64   // controlSeek
+	speed float64 // controlSpeed
+	errCh chan error
+}
+
+// PlayerOpts is a set of options for creating a new Player.
+type PlayerOpts[Image any] struct {
+	// MaxFrames is the maximum number of frames that the player can hold. It
+	// defaults to 100.
+	MaxFrames int
+	// Interpolate is the function used to blend images. If nil, frames are
+	// never blended, and Tween and Crossfade have no effect.
+	Interpolate InterpolateFunc[Image]
+	// Easing is the easing function used when blending frames. It defaults to
+	// EaseLinear.
+	Easing EasingFunc
+	// Tween, if true, blends each frame into the next one over the duration of
+	// the next frame instead of jumping to it.
+	Tween bool
+	// Crossfade is the duration of the crossfade from the currently shown
+	// image to the new frames after ReplaceFrames is called. Zero disables
+	// crossfading.
+	Crossfade time.Duration
+	// OutputInterval is the interval at which blended frames are emitted. It
+	// defaults to DefaultOutputInterval.
+	OutputInterval time.Duration
+	// Overflow is what the player does when a frame is added while it is
+	// full. It defaults to OverflowBlock.
+	Overflow OverflowPolicy
+	// End is what the player does once it runs out of frames. It defaults to
+	// EndHold.
+	End EndBehavior
+	// Dark is the image that is shown when End is EndDark.
+	Dark Image
+	// Copy copies src into dst and returns dst, reusing its buffers. dst is
+	// the zero value the first time. If set, the player copies the image of
+	// each frame that it shows into its own buffers. An added image may then
+	// be reused by the caller once MaxFrames+1 more frames have been added
+	// after it, which allows the caller to render into a fixed set of
+	// images instead of allocating one per frame.
+	Copy func(dst, src Image) Image
+	// Logger, if not nil, receives debug logs about the player. The player
+	// only logs at slog.LevelDebug.
+	Logger *slog.Logger
+	// Clock is the clock that the player is timed by. It defaults to
+	// SystemClock.
+	Clock Clock
+	// Beats is the clock that frames with DurationBeats are timed by. It
+	// defaults to a new BeatClock at DefaultBPM.
+	Beats *BeatClock
+}
+
+// NewPlayer creates a new animation player that can hold up to 100 frames.
 func NewPlayer[Image any]() *Player[Image] {
-	return NewPlayerWithSize[Image](100)
+	return NewPlayerWithOpts(PlayerOpts[Image]{})
 }
 
 // NewPlayerWithSize creates a new animation player that can hold up to
 // maxFrames frames.
 func NewPlayerWithSize[Image any](maxFrames int) *Player[Image] {
-	if maxFrames < 2 {
+	return NewPlayerWithOpts(PlayerOpts[Image]{MaxFrames: maxFrames})
+}
+
+// NewPlayerWithOpts creates a new animation player with the given options.
+func NewPlayerWithOpts[Image any](opts PlayerOpts[Image]) *Player[Image] {
+	if opts.MaxFrames == 0 {
+		opts.MaxFrames = 100
+	}
+	if opts.MaxFrames < 2 {
 		panic("maxFrames must be at least 2")
 	}
+	if opts.OutputInterval == 0 {
+		opts.OutputInterval = DefaultOutputInterval
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+	if opts.Beats == nil {
+		opts.Beats = NewBeatClock(opts.Clock, DefaultBPM)
+	}
 
 	ch := make(chan Frame[Image])
-	frames := lists.NewRing[Frame[Image]](maxFrames)
 
 	return &Player[Image]{
-		C:        ch,
-		ch:       ch,
-		addCh:    make(chan Frame[Image]),
-		insert:   frames,
-		playback: frames.Prev(),
+		C:       ch,
+		ch:      ch,
+		addCh:   make(chan Frame[Image]),
+		clearCh: make(chan struct{}),
+		ctrlCh:  make(chan playerControl),
+		frames:  newFrameBuffer[Image](opts.MaxFrames),
+		opts:    opts,
+		status: playerStatus{
+			PlayerStatus: PlayerStatus{
+				MaxFrames:  opts.MaxFrames,
+				FrameIndex: -1,
+				Speed:      1,
+			},
+		},
 	}
 }
 
-// AddFrames adds a frame to the animation. If the player is full, the function
-// blocks until there is room for the frame.
+// Status returns a snapshot of the state of the player.
+func (p *Player[Image]) Status() PlayerStatus {
+	p.statusMu.Lock()
+	status := p.status
+	p.statusMu.Unlock()
+
+	if !status.nextFrameDue.IsZero() {
+		now := p.opts.Clock.Now()
+		if status.Paused {
+			now = status.pausedAt
+		}
+		status.Remaining = status.nextFrameDue.Sub(now)
+		if status.Remaining < 0 {
+			status.Remaining = 0
+		}
+	}
+
+	status.BPM, _ = p.opts.Beats.Tempo()
+	status.Remaining += status.queuedAfter
+	status.Remaining += time.Duration(status.queuedBeats * float64(p.opts.Beats.BeatDuration()))
+
+	return status.PlayerStatus
+}
+
+// Beats returns the clock that frames timed in beats follow. Changing its
+// tempo changes the timing of the frames right away.
+func (p *Player[Image]) Beats() *BeatClock {
+	return p.opts.Beats
+}
+
+// AddFrame adds a frame to the animation. If the player is full, the function
+// blocks until there is room for the frame, unless the player's overflow policy
+// says otherwise.
 func (p *Player[Image]) AddFrame(ctx context.Context, frame Frame[Image]) error {
 	select {
 	case <-ctx.Done():
@@ -100,6 +332,66 @@ func (p *Player[Image]) AddFrames(ctx context.Context, frames []Frame[Image]) er
 	return nil
 }
 
+// ReplaceFrames drops all frames that have not been played yet and adds the
+// given frames in their place. The currently shown frame stays on until the
+// first new frame is due. If the player is configured to crossfade, the new
+// frames fade in over the shown frame.
+func (p *Player[Image]) ReplaceFrames(ctx context.Context, frames []Frame[Image]) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.clearCh <- struct{}{}:
+	}
+	return p.AddFrames(ctx, frames)
+}
+
+// Pause pauses playback. The shown frame stays on, and frames can still be
+// added. Pausing a paused player does nothing.
+func (p *Player[Image]) Pause(ctx context.Context) error {
+	return p.control(ctx, playerControl{kind: controlPause})
+}
+
+// Resume resumes paused playback. The frame that was due next is shown after
+// what was left of its duration when the player was paused.
+func (p *Player[Image]) Resume(ctx context.Context) error {
+	return p.control(ctx, playerControl{kind: controlResume})
+}
+
+// Seek shows the frame at the given index right away and continues playing
+// from there. Frames are indexed like PlayerStatus.FrameIndex. The frame must
+// still be held by the player, otherwise ErrFrameNotHeld is returned. Loops
+// from the frame onwards start over. A paused player shows the frame but stays
+// paused.
+func (p *Player[Image]) Seek(ctx context.Context, index int64) error {
+	return p.control(ctx, playerControl{kind: controlSeek, index: index})
+}
+
+// SetSpeed sets the playback rate. 1 is normal speed, 2 plays twice as fast
+// and 0.5 half as fast. The speed must be positive; use Pause to stop.
+func (p *Player[Image]) SetSpeed(ctx context.Context, speed float64) error {
+	if !(speed > 0) || math.IsInf(speed, 0) {
+		return fmt.Errorf("invalid speed %v", speed)
+	}
+	return p.control(ctx, playerControl{kind: controlSpeed, speed: speed})
+}
+
+func (p *Player[Image]) control(ctx context.Context, c playerControl) error {
+	c.errCh = make(chan error, 1)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.ctrlCh <- c:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-c.errCh:
+		return err
+	}
+}
+
 // Run starts playing the animation. Run returns when the animation is
 // finished or when the context is canceled.
 func (p *Player[Image]) Run(ctx context.Context) error {
@@ -108,12 +400,198 @@ func (p *Player[Image]) Run(ctx context.Context) error {
 
 	var currentFrame Frame[Image]
 	var nextFrame *Frame[Image]
+	var nextFrameAt time.Time      // when nextFrame was scheduled
+	var nextFrameDur time.Duration // how long after nextFrameAt it is due
+	var hasCurrentFrame bool
+
+	// nextFrameBeat is the beat position that nextFrame is due at if it is
+	// timed in beats. lastBeat is the beat position that the current frame
+	// was due at, which the next frame is timed from if onBeat is true.
+	var nextFrameBeat, lastBeat float64
+	var onBeat bool
+
+	// outFrame is the frame that is sent to the receiver. It is currentFrame
+	// unless the player is blending.
+	var outFrame Frame[Image]
+
+	// While paused, time stands still at pausedAt. Frame durations are
+	// divided by speed.
+	var paused bool
+	var pausedAt time.Time
+	speed := 1.0
+
+	beatCh := p.opts.Beats.Changed()
+
+	clock := func(now time.Time) time.Time {
+		if paused {
+			return pausedAt
+		}
+		return now
+	}
+
+	scaled := func(d time.Duration) time.Duration {
+		return time.Duration(float64(d) / speed)
+	}
 
-	nextFrameTimer := time.NewTimer(0)
+	nextFrameTimer := p.opts.Clock.NewTimer(0)
 	defer nextFrameTimer.Stop()
 
 	if !nextFrameTimer.Stop() {
-		<-nextFrameTimer.C
+		<-nextFrameTimer.C()
+	}
+
+	blend := newBlender(p.opts.Interpolate, p.opts.Easing, p.opts.Copy)
+	tween := blend.enabled() && p.opts.Tween
+	crossfade := blend.enabled() && p.opts.Crossfade > 0
+
+	// fadePending is true if a crossfade should start once the first new
+	// frame is shown. fading is true while the crossfade is in progress.
+	var fadePending, fading bool
+	var fadeStart time.Time
+
+	// outputTicker emits blended frames in between frames. It only runs while
+	// there is something to blend, and it is rearmed on every tick.
+	outputTicker := p.opts.Clock.NewTimer(p.opts.OutputInterval)
+	outputTicker.Stop()
+	defer outputTicker.Stop()
+
+	var outputTickerC <-chan time.Time
+	updateOutputTicker := func() {
+		blending := !paused && hasCurrentFrame && ((tween && nextFrame != nil) || fading)
+		switch {
+		case blending && outputTickerC == nil:
+			outputTicker.Reset(p.opts.OutputInterval)
+			outputTickerC = outputTicker.C()
+		case !blending && outputTickerC != nil:
+			if !outputTicker.Stop() {
+				select {
+				case <-outputTicker.C():
+				default:
+				}
+			}
+			outputTickerC = nil
+		}
+	}
+
+	// render returns the frame to be shown at the given time.
+	render := func(now time.Time) Frame[Image] {
+		frame := currentFrame
+		now = clock(now)
+
+		if fading && now.Sub(fadeStart) >= p.opts.Crossfade {
+			fading = false
+		}
+
+		tweening := tween && nextFrame != nil
+		switch {
+		case tweening && fading:
+			t := progress(now.Sub(nextFrameAt), nextFrameDur)
+			frame.Image = blend.blendTween(currentFrame.Image, nextFrame.Image, t)
+			frame.Image = blend.blendFade(frame.Image, progress(now.Sub(fadeStart), p.opts.Crossfade))
+		case tweening:
+			t := progress(now.Sub(nextFrameAt), nextFrameDur)
+			frame.Image = blend.blendOut(currentFrame.Image, nextFrame.Image, t)
+		case fading:
+			frame.Image = blend.blendFade(currentFrame.Image, progress(now.Sub(fadeStart), p.opts.Crossfade))
+		case blend.copying():
+			// The current image is overwritten by the next frame, so the
+			// receiver gets its own copy.
+			frame.Image = blend.copyOut(currentFrame.Image)
+		}
+
+		return frame
+	}
+
+	// darkFrame is scheduled after the last frame if the player should go
+	// dark at the end.
+	darkFrame := Frame[Image]{Image: p.opts.Dark}
+	var showingDark bool
+
+	updateAddCh := func() {
+		if p.frames.isFull() && p.opts.Overflow == OverflowBlock {
+			addCh = nil
+		} else {
+			addCh = p.addCh
+		}
+	}
+
+	// dropOldest drops the scheduled frame, which is the oldest frame that
+	// has not been shown yet, in favor of the frame after it. The timer is
+	// left running so that the cadence of the animation is kept.
+	dropOldest := func() bool {
+		if nextFrame == nil || nextFrame == &darkFrame {
+			return false
+		}
+		f, ok := p.frames.dropOldest()
+		if ok {
+			nextFrame = f
+		}
+		return ok
+	}
+
+	var shownIndex int64 = -1
+	updateStatus := func() {
+		queued, queuedDuration, queuedBeats, looping := p.frames.queued()
+
+		var nextFrameDue time.Time
+		if nextFrame != nil && nextFrame != &darkFrame {
+			queued++
+			nextFrameDue = nextFrameAt.Add(nextFrameDur)
+		}
+
+		p.statusMu.Lock()
+		p.status.Queued = queued
+		p.status.FrameIndex = shownIndex
+		p.status.Looping = looping
+		p.status.Paused = paused
+		p.status.Speed = speed
+		p.status.nextFrameDue = nextFrameDue
+		p.status.queuedAfter = scaled(queuedDuration)
+		p.status.queuedBeats = queuedBeats
+		p.status.pausedAt = pausedAt
+		p.statusMu.Unlock()
+	}
+
+	drainNextFrameTimer := func() {
+		if !nextFrameTimer.Stop() {
+			select {
+			case <-nextFrameTimer.C():
+			default:
+			}
+		}
+	}
+
+	stopNextFrameTimer := func() {
+		drainNextFrameTimer()
+		nextFrame = nil
+	}
+
+	// startNextFrameTimer starts the timer for the rest of the scheduled
+	// frame's duration. The timer stays off while paused.
+	startNextFrameTimer := func(now time.Time) {
+		drainNextFrameTimer()
+		if !paused && nextFrame != nil {
+			nextFrameTimer.Reset(nextFrameAt.Add(nextFrameDur).Sub(now))
+		}
+	}
+
+	// timeNextFrame works out when nextFrame is due, counting from now.
+	timeNextFrame := func(now time.Time) {
+		nextFrameAt = now
+		if nextFrame.DurationBeats <= 0 {
+			nextFrameDur = scaled(nextFrame.Duration())
+			return
+		}
+
+		beats := p.opts.Beats
+		nowBeat := beats.BeatAt(now)
+		if onBeat && lastBeat+nextFrame.DurationBeats >= nowBeat-beatEpsilon {
+			nextFrameBeat = lastBeat + nextFrame.DurationBeats
+		} else {
+			// Coming out of a break, so get back on the beat grid.
+			nextFrameBeat = nextBeat(nowBeat, nextFrame.DurationBeats)
+		}
+		nextFrameDur = beats.TimeAt(nextFrameBeat).Sub(now)
 	}
 
 	scheduleNextFrame := func() {
@@ -121,18 +599,144 @@ func (p *Player[Image]) Run(ctx context.Context) error {
 			panic("scheduleNextFrame called but nextFrame is still not used")
 		}
 
-		f, ok := p.nextFrame()
-		log.Printf("schedule next frame: %v", f)
+		f, ok := p.frames.next()
+		if ok && p.debugEnabled(ctx) {
+			p.opts.Logger.LogAttrs(ctx, slog.LevelDebug, "scheduled next frame",
+				slog.Uint64("position", p.frames.playback),
+				slog.Duration("duration", f.Duration()))
+		}
+		if !ok && p.opts.End == EndDark && hasCurrentFrame && !showingDark {
+			darkFrame.DurationMs = currentFrame.DurationMs
+			darkFrame.DurationBeats = currentFrame.DurationBeats
+			f, ok = &darkFrame, true
+		}
+
 		if ok {
-			nextFrameTimer.Reset(f.Duration())
 			nextFrame = f
+			timeNextFrame(clock(p.opts.Clock.Now()))
+			if !paused {
+				nextFrameTimer.Reset(nextFrameDur)
+			}
 		} else {
 			nextFrameTimer.Stop()
 			nextFrame = nil
 		}
 
-		// We can take more frames now, so unblock the addCh.
-		addCh = p.addCh
+		// We may be able to take more frames now, so unblock the addCh.
+		updateAddCh()
+	}
+
+	// showNextFrame makes nextFrame the current frame and schedules the one
+	// after it. onTime is false if the frame is shown early, in which case
+	// frames timed in beats have to find the beat again.
+	showNextFrame := func(now time.Time, onTime bool) {
+		if frameCh != nil {
+			// The next frame is due, but the previous frame hasn't been sent
+			// yet. This means that the receiver is too slow.
+			metrics.Add(metricDroppedFrames, 1)
+			if p.debugEnabled(ctx) {
+				p.opts.Logger.LogAttrs(ctx, slog.LevelDebug, "receiver too slow, dropped frame")
+			}
+		}
+
+		showingDark = nextFrame == &darkFrame
+		if showingDark {
+			shownIndex = -1
+		} else {
+			// The shown frame is still at playback until the next frame is
+			// scheduled.
+			shownIndex = int64(p.frames.playback) - 1
+		}
+
+		onBeat = onTime && nextFrame.DurationBeats > 0
+		lastBeat = nextFrameBeat
+
+		currentFrame, nextFrame = *nextFrame, nil
+		hasCurrentFrame = true
+		if blend.copying() {
+			// The caller may reuse the image once it has left the frame
+			// buffer, so keep our own copy.
+			currentFrame.Image = blend.copyCurrent(currentFrame.Image)
+		}
+		frameCh = p.ch
+
+		if fadePending {
+			fadePending = false
+			fading = true
+			fadeStart = clock(now)
+		}
+
+		// Advancing the frame here instead of waiting for the receiver
+		// to pick up the frame. This ensures that the animation is
+		// played at the correct speed even if the receiver is slow.
+		scheduleNextFrame()
+		updateOutputTicker()
+
+		// Render after scheduling, so a tween into the new next frame
+		// starts from exactly the current frame.
+		outFrame = render(now)
+		updateStatus()
+	}
+
+	control := func(c playerControl) error {
+		now := p.opts.Clock.Now()
+
+		switch c.kind {
+		case controlPause:
+			if paused {
+				return nil
+			}
+			paused = true
+			pausedAt = now
+			drainNextFrameTimer()
+
+		case controlResume:
+			if !paused {
+				return nil
+			}
+			// Shift everything that is timed by the time spent paused.
+			shift := now.Sub(pausedAt)
+			nextFrameAt = nextFrameAt.Add(shift)
+			fadeStart = fadeStart.Add(shift)
+			paused = false
+			if nextFrame != nil && nextFrame.DurationBeats > 0 {
+				// Pick up the beat from here.
+				onBeat = false
+				timeNextFrame(now)
+			}
+			startNextFrameTimer(now)
+
+		case controlSeek:
+			if c.index < 0 {
+				return ErrFrameNotHeld
+			}
+			f, ok := p.frames.seek(uint64(c.index) + 1)
+			if !ok {
+				return ErrFrameNotHeld
+			}
+			stopNextFrameTimer()
+			nextFrame = f
+			showNextFrame(now, false)
+			return nil
+
+		case controlSpeed:
+			if nextFrame == nil || nextFrame.DurationBeats > 0 {
+				// Frames timed in beats follow the tempo instead.
+				speed = c.speed
+				break
+			}
+			// Keep the progress into the scheduled frame.
+			now := clock(now)
+			t := progress(now.Sub(nextFrameAt), nextFrameDur)
+			speed = c.speed
+			nextFrameDur = scaled(nextFrame.Duration())
+			nextFrameAt = now.Add(-time.Duration(t * float64(nextFrameDur)))
+			startNextFrameTimer(now)
+		}
+
+		updateOutputTicker()
+		updateStatus()
+		return nil
 	}
 
 	for {
@@ -141,79 +745,95 @@ func (p *Player[Image]) Run(ctx context.Context) error {
 			return ctx.Err()
 
 		case frame := <-addCh:
-			p.addFrame(frame)
-			if p.isFull() {
-				addCh = nil
+			if p.frames.isFull() {
+				// Only reachable if the overflow policy is not to block.
+				metrics.Add(metricOverflowFrames, 1)
+				if !dropOldest() {
+					// There is still no room, so drop the new frame instead.
+					if p.debugEnabled(ctx) {
+						p.opts.Logger.LogAttrs(ctx, slog.LevelDebug, "dropped new frame on overflow")
+					}
+					updateStatus()
+					break
+				}
+			}
+
+			p.frames.add(frame)
+			updateAddCh()
+
+			if nextFrame == &darkFrame {
+				// We were about to go dark, but there are new frames now.
+				stopNextFrameTimer()
 			}
 
 			if nextFrame == nil {
 				// No frame is currently being played, so start playing the
 				// first frame.
 				scheduleNextFrame()
+				updateOutputTicker()
+			}
+
+			updateStatus()
+
+		case <-p.clearCh:
+			if crossfade && hasCurrentFrame {
+				// Take a snapshot of what is currently shown so that the new
+				// frames can fade in over it.
+				blend.snapshotFade(render(p.opts.Clock.Now()).Image)
+				fadePending = true
+				fading = false
 			}
 
-		case <-nextFrameTimer.C:
+			p.frames.clear()
+			if nextFrame != nil {
+				stopNextFrameTimer()
+			}
+
+			updateAddCh()
+			updateOutputTicker()
+			updateStatus()
+
+		case c := <-p.ctrlCh:
+			c.errCh <- control(c)
+
+		case <-beatCh:
+			beatCh = p.opts.Beats.Changed()
+			if nextFrame != nil && nextFrame.DurationBeats > 0 {
+				// The beat that the frame is due at is now at a different
+				// time.
+				nextFrameDur = p.opts.Beats.TimeAt(nextFrameBeat).Sub(nextFrameAt)
+				startNextFrameTimer(clock(p.opts.Clock.Now()))
+				updateStatus()
+			}
+
+		case now := <-nextFrameTimer.C():
 			if nextFrame == nil {
 				panic("unreachable: nextFrameTimer fired but nextFrame is nil")
 			}
+			showNextFrame(now, true)
 
+		case now := <-outputTickerC:
 			if frameCh != nil {
-				// Timer for next frame fired, but the previous frame hasn't
-				// been sent yet. This means that the receiver is too slow.
 				metrics.Add(metricDroppedFrames, 1)
 			}
 
-			currentFrame, nextFrame = *nextFrame, nil
+			outFrame = render(now)
+			outFrame.DurationMs = DurationToMs(p.opts.OutputInterval)
 			frameCh = p.ch
 
-			// Advancing the frame here instead of waiting for the receiver
-			// to pick up the frame. This ensures that the animation is
-			// played at the correct speed even if the receiver is slow.
-			scheduleNextFrame()
+			outputTicker.Reset(p.opts.OutputInterval)
+			updateOutputTicker()
 
-		case frameCh <- currentFrame:
+		case frameCh <- outFrame:
 			frameCh = nil
+			blend.sent()
 			metrics.Add(metricTotalFrames, 1)
 		}
 	}
 }
 
-// addFrame adds a frame to the player. If the player is already full, false is
-// returned and the player halts.
-func (p *Player[Image]) addFrame(f Frame[Image]) {
-	p.insert.Value = f
-	p.insert = p.insert.Next()
-}
-
-func (p *Player[Image]) clearFrames() {
-	p.playback = p.insert.Prev()
-}
-
-// nextFrame returns the next frame in the animation. False is returned if the
-// animation is finished.
-func (p *Player[Image]) nextFrame() (*Frame[Image], bool) {
-	current := p.playback
-
-	if current.Value.JumpBackAmount > 0 {
-		jumpBackAmount := current.Value.JumpBackAmount
-		for i := int32(0); i < jumpBackAmount; i++ {
-			current = current.Prev()
-			if current == p.insert {
-				return nil, false
-			}
-		}
-	} else {
-		current = current.Next()
-		if current == p.insert {
-			return nil, false
-		}
-	}
-
-	p.playback = current
-	return &current.Value, true
-}
-
-// isFull returns true if the player cannot take in any more frames.
-func (p *Player[Image]) isFull() bool {
-	return p.insert == p.playback
+// debugEnabled returns true if the player should log at debug level. Checking
+// this first keeps the hot loop from building log attributes for nothing.
+func (p *Player[Image]) debugEnabled(ctx context.Context) bool {
+	return p.opts.Logger != nil && p.opts.Logger.Enabled(ctx, slog.LevelDebug)
 }
@@ -2,6 +2,8 @@ package animation
 
 import (
 	"context"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -21,80 +23,80 @@ func TestPlayer(t *testing.T) {
 	t.Run("short", func(t *testing.T) {
 		p, _ := startPlayer(t, 10)
 		mustAddFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 		expectFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 	})
 
 	t.Run("loop", func(t *testing.T) {
 		p, _ := startPlayer(t, 10)
 		mustAddFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 3, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, JumpBackAmount: 3, DurationMs: 250},
 		})
 		expectFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 3, 250},
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 3, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, JumpBackAmount: 3, DurationMs: 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, JumpBackAmount: 3, DurationMs: 250},
 		})
 	})
 
 	t.Run("race", func(t *testing.T) {
 		p, _ := startPlayer(t, 10)
 		go mustAddFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 		expectFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 	})
 
 	t.Run("interleave", func(t *testing.T) {
 		p, _ := startPlayer(t, 10)
-		mustAddFrames(t, p, []Frame[testFrame]{{testFrame{"frame 1"}, 0, 100}})
-		expectFrames(t, p, []Frame[testFrame]{{testFrame{"frame 1"}, 0, 100}})
-		mustAddFrames(t, p, []Frame[testFrame]{{testFrame{"frame 2"}, 0, 150}})
-		expectFrames(t, p, []Frame[testFrame]{{testFrame{"frame 2"}, 0, 150}})
-		mustAddFrames(t, p, []Frame[testFrame]{{testFrame{"frame 3"}, 0, 200}})
-		expectFrames(t, p, []Frame[testFrame]{{testFrame{"frame 3"}, 0, 200}})
-		mustAddFrames(t, p, []Frame[testFrame]{{testFrame{"frame 4"}, 0, 250}})
-		expectFrames(t, p, []Frame[testFrame]{{testFrame{"frame 4"}, 0, 250}})
+		mustAddFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 1"}, DurationMs: 100}})
+		expectFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 1"}, DurationMs: 100}})
+		mustAddFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 2"}, DurationMs: 150}})
+		expectFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 2"}, DurationMs: 150}})
+		mustAddFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 3"}, DurationMs: 200}})
+		expectFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 3"}, DurationMs: 200}})
+		mustAddFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 4"}, DurationMs: 250}})
+		expectFrames(t, p, []Frame[testFrame]{{Image: testFrame{"frame 4"}, DurationMs: 250}})
 	})
 
 	t.Run("overflow", func(t *testing.T) {
 		p, _ := startPlayer(t, 2)
 		go mustAddFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 		expectFrames(t, p, []Frame[testFrame]{
-			{testFrame{"frame 1"}, 0, 100},
-			{testFrame{"frame 2"}, 0, 150},
-			{testFrame{"frame 3"}, 0, 200},
-			{testFrame{"frame 4"}, 0, 250},
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationMs: 150},
+			{Image: testFrame{"frame 3"}, DurationMs: 200},
+			{Image: testFrame{"frame 4"}, DurationMs: 250},
 		})
 	})
 
@@ -108,16 +110,220 @@ func TestPlayer(t *testing.T) {
 	})
 }
 
+func TestPlayerLoops(t *testing.T) {
+	var (
+		frameA = Frame[testFrame]{Image: testFrame{"A"}, DurationMs: 30}
+		frameB = Frame[testFrame]{Image: testFrame{"B"}, DurationMs: 30}
+		frameC = Frame[testFrame]{Image: testFrame{"C"}, DurationMs: 30}
+		frameD = Frame[testFrame]{Image: testFrame{"D"}, DurationMs: 30}
+		frameE = Frame[testFrame]{Image: testFrame{"E"}, DurationMs: 30}
+	)
+
+	with := func(f Frame[testFrame], modify func(*Frame[testFrame])) Frame[testFrame] {
+		modify(&f)
+		return f
+	}
+
+	tests := []struct {
+		name      string
+		maxFrames int
+		frames    []Frame[testFrame]
+		expect    []Frame[testFrame]
+	}{
+		{
+			name: "count",
+			frames: []Frame[testFrame]{
+				frameA,
+				frameB,
+				with(frameC, func(f *Frame[testFrame]) { f.JumpBackAmount = 2; f.LoopCount = 3 }),
+				frameD,
+			},
+			expect: []Frame[testFrame]{
+				frameA, frameB, with(frameC, func(f *Frame[testFrame]) { f.JumpBackAmount = 2; f.LoopCount = 3 }),
+				frameA, frameB, with(frameC, func(f *Frame[testFrame]) { f.JumpBackAmount = 2; f.LoopCount = 3 }),
+				frameA, frameB, with(frameC, func(f *Frame[testFrame]) { f.JumpBackAmount = 2; f.LoopCount = 3 }),
+				frameD,
+			},
+		},
+		{
+			name: "count_once",
+			frames: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 1 }),
+				frameC,
+			},
+			expect: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 1 }),
+				frameC,
+			},
+		},
+		{
+			name: "marker",
+			frames: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "start" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "start"; f.LoopCount = 2 }),
+				frameD,
+			},
+			expect: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "start" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "start"; f.LoopCount = 2 }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "start" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "start"; f.LoopCount = 2 }),
+				frameD,
+			},
+		},
+		{
+			name: "nested",
+			frames: []Frame[testFrame]{
+				with(frameA, func(f *Frame[testFrame]) { f.Marker = "outer" }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "inner" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "inner"; f.LoopCount = 2 }),
+				with(frameD, func(f *Frame[testFrame]) { f.JumpToMarker = "outer"; f.LoopCount = 2 }),
+				frameE,
+			},
+			expect: []Frame[testFrame]{
+				with(frameA, func(f *Frame[testFrame]) { f.Marker = "outer" }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "inner" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "inner"; f.LoopCount = 2 }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "inner" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "inner"; f.LoopCount = 2 }),
+				with(frameD, func(f *Frame[testFrame]) { f.JumpToMarker = "outer"; f.LoopCount = 2 }),
+				with(frameA, func(f *Frame[testFrame]) { f.Marker = "outer" }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "inner" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "inner"; f.LoopCount = 2 }),
+				with(frameB, func(f *Frame[testFrame]) { f.Marker = "inner" }),
+				with(frameC, func(f *Frame[testFrame]) { f.JumpToMarker = "inner"; f.LoopCount = 2 }),
+				with(frameD, func(f *Frame[testFrame]) { f.JumpToMarker = "outer"; f.LoopCount = 2 }),
+				frameE,
+			},
+		},
+		{
+			name: "unknown_marker",
+			frames: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpToMarker = "nope" }),
+				frameC,
+			},
+			expect: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpToMarker = "nope" }),
+				frameC,
+			},
+		},
+		{
+			name: "jump_too_far",
+			frames: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 5 }),
+				frameC,
+			},
+			expect: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 5 }),
+				frameC,
+			},
+		},
+		{
+			// The looped frames must not be overwritten by the frames added
+			// after them, even though the buffer is small.
+			name:      "retain_looped_frames",
+			maxFrames: 3,
+			frames: []Frame[testFrame]{
+				frameA,
+				with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 3 }),
+				frameC,
+				frameD,
+			},
+			expect: []Frame[testFrame]{
+				frameA, with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 3 }),
+				frameA, with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 3 }),
+				frameA, with(frameB, func(f *Frame[testFrame]) { f.JumpBackAmount = 1; f.LoopCount = 3 }),
+				frameC,
+				frameD,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			maxFrames := test.maxFrames
+			if maxFrames == 0 {
+				maxFrames = 10
+			}
+
+			p, _ := startPlayer(t, maxFrames)
+			go mustAddFrames(t, p, test.frames)
+			expectFrameOrder(t, p, test.expect)
+			expectNoFrame(t, p)
+		})
+	}
+}
+
+func TestPlayerEnd(t *testing.T) {
+	frames := []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationMs: 50},
+		{Image: testFrame{"frame 2"}, DurationMs: 80},
+	}
+
+	t.Run("hold", func(t *testing.T) {
+		p, _ := startPlayerWithOpts(t, PlayerOpts[testFrame]{End: EndHold})
+		mustAddFrames(t, p, frames)
+		expectFrames(t, p, frames)
+		expectNoFrame(t, p)
+	})
+
+	t.Run("dark", func(t *testing.T) {
+		p, _ := startPlayerWithOpts(t, PlayerOpts[testFrame]{
+			End:  EndDark,
+			Dark: testFrame{"dark"},
+		})
+		mustAddFrames(t, p, frames)
+		expectFrames(t, p, append(frames, Frame[testFrame]{
+			Image:      testFrame{"dark"},
+			DurationMs: 80,
+		}))
+		expectNoFrame(t, p)
+
+		// New frames play normally after going dark.
+		mustAddFrames(t, p, frames[:1])
+		expectFrames(t, p, []Frame[testFrame]{
+			frames[0],
+			{Image: testFrame{"dark"}, DurationMs: 50},
+		})
+	})
+
+	t.Run("dark_cancelled", func(t *testing.T) {
+		p, _ := startPlayerWithOpts(t, PlayerOpts[testFrame]{
+			End:  EndDark,
+			Dark: testFrame{"dark"},
+		})
+		mustAddFrames(t, p, frames[:1])
+		expectFrames(t, p, frames[:1])
+
+		// Adding a frame before the player goes dark cancels it.
+		mustAddFrames(t, p, frames[1:])
+		expectFrames(t, p, frames[1:])
+	})
+}
+
 type testPlayer[Image any] struct {
 	*Player[Image]
 	ctx context.Context
 }
 
 func startPlayer(t *testing.T, maxFrames int) (player *testPlayer[testFrame], done func(error)) {
+	return startPlayerWithOpts(t, PlayerOpts[testFrame]{MaxFrames: maxFrames})
+}
+
+func startPlayerWithOpts(t *testing.T, opts PlayerOpts[testFrame]) (player *testPlayer[testFrame], done func(error)) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	t.Cleanup(cancel)
 
-	p := NewPlayerWithSize[testFrame](maxFrames)
+	p := NewPlayerWithOpts(opts)
 
 	errCh := make(chan error, 1)
 	go func() { errCh <- p.Run(ctx) }()
@@ -173,9 +379,458 @@ func expectFrames(t *testing.T, p *testPlayer[testFrame], frames []Frame[testFra
 	}
 }
 
+// expectFrameOrder is like expectFrames, but it does not check the timing of
+// the frames.
+func expectFrameOrder(t *testing.T, p *testPlayer[testFrame], frames []Frame[testFrame]) {
+	t.Helper()
+
+	for _, expect := range frames {
+		select {
+		case <-p.ctx.Done():
+			t.Error("timed out")
+			return
+		case frame := <-p.C:
+			assert.Equal(t, expect, frame)
+		}
+	}
+}
+
+func expectNoFrame(t *testing.T, p *testPlayer[testFrame]) {
+	t.Helper()
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+	case frame := <-p.C:
+		t.Errorf("got unexpected frame: %v", frame)
+	}
+}
+
 func mustAddFrames(t *testing.T, p *testPlayer[testFrame], frames []Frame[testFrame]) {
 	t.Helper()
 	if err := p.AddFrames(p.ctx, frames); err != nil {
 		t.Error(err)
 	}
 }
+
+func lerpFloat(dst, a, b float64, t float64) float64 {
+	return a + (b-a)*t
+}
+
+func TestPlayerInterpolation(t *testing.T) {
+	t.Run("tween", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+
+		p := NewPlayerWithOpts(PlayerOpts[float64]{
+			MaxFrames:      10,
+			Interpolate:    lerpFloat,
+			Tween:          true,
+			OutputInterval: 20 * time.Millisecond,
+		})
+		go p.Run(ctx)
+
+		assert.NoError(t, p.AddFrames(ctx, []Frame[float64]{
+			{Image: 0, DurationMs: 50},
+			{Image: 100, DurationMs: 200},
+		}))
+
+		values := receiveUntil(t, ctx, p, 100)
+		assert.Equal(t, 0.0, values[0])
+		assertIncreasing(t, values)
+
+		// 200ms at 20ms per frame is about 10 frames, but allow for slow
+		// machines.
+		if len(values) < 5 {
+			t.Errorf("expected at least 5 blended frames, got %v", values)
+		}
+	})
+
+	t.Run("crossfade", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+
+		p := NewPlayerWithOpts(PlayerOpts[float64]{
+			MaxFrames:      10,
+			Interpolate:    lerpFloat,
+			Crossfade:      200 * time.Millisecond,
+			OutputInterval: 20 * time.Millisecond,
+		})
+		go p.Run(ctx)
+
+		assert.NoError(t, p.AddFrames(ctx, []Frame[float64]{{Image: 0, DurationMs: 50}}))
+		assert.Equal(t, []float64{0}, receiveUntil(t, ctx, p, 0))
+
+		assert.NoError(t, p.ReplaceFrames(ctx, []Frame[float64]{{Image: 100, DurationMs: 50}}))
+
+		values := receiveUntil(t, ctx, p, 100)
+		assert.Equal(t, 0.0, values[0])
+		assertIncreasing(t, values)
+
+		if len(values) < 5 {
+			t.Errorf("expected at least 5 crossfaded frames, got %v", values)
+		}
+
+		// The crossfade is done, so nothing else should be sent.
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case frame := <-p.C:
+			t.Errorf("got unexpected frame: %v", frame)
+		}
+	})
+
+	t.Run("no_interpolate", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+
+		// Tween is ignored without an Interpolate function.
+		p := NewPlayerWithOpts(PlayerOpts[float64]{
+			MaxFrames: 10,
+			Tween:     true,
+		})
+		go p.Run(ctx)
+
+		assert.NoError(t, p.AddFrames(ctx, []Frame[float64]{
+			{Image: 0, DurationMs: 50},
+			{Image: 100, DurationMs: 100},
+		}))
+		assert.Equal(t, []float64{0, 100}, receiveUntil(t, ctx, p, 100))
+	})
+}
+
+// receiveUntil receives frames from p until a frame with the given image is
+// received. It returns the images of all received frames.
+func receiveUntil(t *testing.T, ctx context.Context, p *Player[float64], last float64) []float64 {
+	t.Helper()
+
+	var values []float64
+	for {
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out, got", values)
+		case frame := <-p.C:
+			values = append(values, frame.Image)
+			if frame.Image == last {
+				return values
+			}
+		}
+	}
+}
+
+func assertIncreasing(t *testing.T, values []float64) {
+	t.Helper()
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			t.Errorf("values are not increasing at %d: %v", i, values)
+			return
+		}
+	}
+}
+
+func TestPlayerOverflow(t *testing.T) {
+	frames := []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationMs: 50},
+		{Image: testFrame{"frame 2"}, DurationMs: 50},
+		{Image: testFrame{"frame 3"}, DurationMs: 50},
+		{Image: testFrame{"frame 4"}, DurationMs: 50},
+		{Image: testFrame{"frame 5"}, DurationMs: 50},
+		{Image: testFrame{"frame 6"}, DurationMs: 50},
+	}
+
+	t.Run("drop_oldest", func(t *testing.T) {
+		p, _ := startPlayerWithOpts(t, PlayerOpts[testFrame]{
+			MaxFrames: 3,
+			Overflow:  OverflowDropOldest,
+		})
+
+		// Adding must never block, even though the player only has room for
+		// a few frames.
+		ctx, cancel := context.WithTimeout(p.ctx, 25*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, p.AddFrames(ctx, frames))
+
+		expectFrames(t, p, frames[3:])
+		expectNoFrame(t, p)
+	})
+
+	t.Run("drop_new_when_looping", func(t *testing.T) {
+		p, _ := startPlayerWithOpts(t, PlayerOpts[testFrame]{
+			MaxFrames: 3,
+			Overflow:  OverflowDropOldest,
+		})
+
+		loop := []Frame[testFrame]{
+			{Image: testFrame{"frame 1"}, DurationMs: 50},
+			{Image: testFrame{"frame 2"}, DurationMs: 50, JumpBackAmount: 1},
+		}
+		mustAddFrames(t, p, loop)
+		mustAddFrames(t, p, frames[2:])
+
+		// The loop holds on to its frames, so the new frames are dropped.
+		expectFrameOrder(t, p, []Frame[testFrame]{loop[0], loop[1], loop[0], loop[1]})
+	})
+}
+
+func TestPlayerStatus(t *testing.T) {
+	p, _ := startPlayer(t, 10)
+
+	assert.Equal(t, PlayerStatus{
+		MaxFrames:  10,
+		FrameIndex: -1,
+		Speed:      1,
+		BPM:        DefaultBPM,
+	}, p.Status())
+
+	mustAddFrames(t, p, []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationMs: 100},
+		{Image: testFrame{"frame 2"}, DurationMs: 150},
+		{Image: testFrame{"frame 3"}, DurationMs: 200, JumpBackAmount: 1, LoopCount: 2},
+	})
+
+	status := p.Status()
+	assert.Equal(t, 3, status.Queued)
+	assert.Equal(t, int64(-1), status.FrameIndex)
+	assert.True(t, status.Looping)
+	assertDurationNear(t, 450*time.Millisecond, status.Remaining)
+
+	expectFrameOrder(t, p, []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationMs: 100},
+	})
+
+	status = p.Status()
+	assert.Equal(t, 2, status.Queued)
+	assert.Equal(t, int64(0), status.FrameIndex)
+	assertDurationNear(t, 350*time.Millisecond, status.Remaining)
+
+	expectFrameOrder(t, p, []Frame[testFrame]{
+		{Image: testFrame{"frame 2"}, DurationMs: 150},
+		{Image: testFrame{"frame 3"}, DurationMs: 200, JumpBackAmount: 1, LoopCount: 2},
+		{Image: testFrame{"frame 2"}, DurationMs: 150},
+	})
+
+	status = p.Status()
+	assert.Equal(t, 1, status.Queued)
+	assert.Equal(t, int64(1), status.FrameIndex)
+	assert.False(t, status.Looping)
+	assertDurationNear(t, 200*time.Millisecond, status.Remaining)
+}
+
+func assertDurationNear(t *testing.T, expected, actual time.Duration) {
+	t.Helper()
+	if intmath.Abs(expected-actual) > frameTimeMargin {
+		t.Errorf("expected duration near %v, got %v", expected, actual)
+	}
+}
+
+func copyBytes(dst, src []byte) []byte {
+	return append(dst[:0], src...)
+}
+
+func TestPlayerCopy(t *testing.T) {
+	const maxFrames = 4
+	const numFrames = 200
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	p := NewPlayerWithOpts(PlayerOpts[[]byte]{
+		MaxFrames: maxFrames,
+		Copy:      copyBytes,
+	})
+	go p.Run(ctx)
+
+	go func() {
+		// The images may be reused once maxFrames+1 more frames have been
+		// added after them. The race detector catches us if that is wrong.
+		var images [maxFrames + 2][]byte
+		for i := range images {
+			images[i] = make([]byte, 16)
+		}
+
+		for i := 1; i <= numFrames; i++ {
+			img := images[i%len(images)]
+			for j := range img {
+				img[j] = byte(i)
+			}
+			if err := p.AddFrame(ctx, Frame[[]byte]{Image: img, DurationMs: 1}); err != nil {
+				return
+			}
+		}
+	}()
+
+	var last byte
+	for last != numFrames {
+		var frame Frame[[]byte]
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out")
+		case frame = <-p.C:
+		}
+
+		for _, b := range frame.Image {
+			if b != frame.Image[0] {
+				t.Fatalf("torn image: %v", frame.Image)
+			}
+		}
+		if frame.Image[0] < last {
+			t.Fatalf("frame %d received after frame %d", frame.Image[0], last)
+		}
+		last = frame.Image[0]
+	}
+}
+
+func TestPlayerAllocs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	p := NewPlayerWithOpts(PlayerOpts[[]byte]{
+		MaxFrames: 2,
+		Copy:      copyBytes,
+		// Debug logs are disabled, so the logger must not cost anything.
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	go p.Run(ctx)
+
+	frame := Frame[[]byte]{Image: make([]byte, 64)}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := p.AddFrame(ctx, frame); err != nil {
+			t.Fatal(err)
+		}
+		<-p.C
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkPlayer(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+
+	p := NewPlayerWithOpts(PlayerOpts[[]byte]{
+		MaxFrames: 2,
+		Copy:      copyBytes,
+	})
+	go p.Run(ctx)
+
+	frame := Frame[[]byte]{Image: make([]byte, 64)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := p.AddFrame(ctx, frame); err != nil {
+			b.Fatal(err)
+		}
+		<-p.C
+	}
+}
+
+func TestPlayerControl(t *testing.T) {
+	frames := []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationMs: 50},
+		{Image: testFrame{"frame 2"}, DurationMs: 50},
+		{Image: testFrame{"frame 3"}, DurationMs: 50},
+		{Image: testFrame{"frame 4"}, DurationMs: 50},
+	}
+
+	t.Run("pause_resume", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		mustAddFrames(t, p, frames)
+		expectFrameOrder(t, p, frames[:1])
+
+		assert.NoError(t, p.Pause(p.ctx))
+		assert.True(t, p.Status().Paused)
+		expectNoFrame(t, p)
+
+		// Remaining does not run down while paused.
+		remaining := p.Status().Remaining
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, remaining, p.Status().Remaining)
+
+		assert.NoError(t, p.Resume(p.ctx))
+		assert.False(t, p.Status().Paused)
+		expectFrameOrder(t, p, frames[1:])
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		mustAddFrames(t, p, frames)
+		expectFrameOrder(t, p, frames[:2])
+
+		// Seeking back shows the frame right away.
+		start := time.Now()
+		assert.NoError(t, p.Seek(p.ctx, 0))
+		expectFrameOrder(t, p, frames[:1])
+		if d := time.Since(start); d > 40*time.Millisecond {
+			t.Errorf("seeked frame took %v to show", d)
+		}
+		assert.Equal(t, int64(0), p.Status().FrameIndex)
+
+		assert.NoError(t, p.Seek(p.ctx, 2))
+		expectFrameOrder(t, p, frames[2:])
+	})
+
+	t.Run("seek_restarts_loop", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		loop := []Frame[testFrame]{
+			{Image: testFrame{"frame 1"}, DurationMs: 50},
+			{Image: testFrame{"frame 2"}, DurationMs: 50, JumpBackAmount: 1, LoopCount: 2},
+			{Image: testFrame{"frame 3"}, DurationMs: 50},
+		}
+		mustAddFrames(t, p, loop)
+		expectFrameOrder(t, p, []Frame[testFrame]{loop[0], loop[1], loop[0]})
+
+		assert.NoError(t, p.Seek(p.ctx, 0))
+		expectFrameOrder(t, p, []Frame[testFrame]{loop[0], loop[1], loop[0], loop[1], loop[2]})
+	})
+
+	t.Run("seek_not_held", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		mustAddFrames(t, p, frames)
+
+		assert.IsError(t, p.Seek(p.ctx, 4), ErrFrameNotHeld)
+		assert.IsError(t, p.Seek(p.ctx, -1), ErrFrameNotHeld)
+
+		// Frames dropped by ReplaceFrames are gone.
+		expectFrameOrder(t, p, frames[:1])
+		assert.NoError(t, p.ReplaceFrames(p.ctx, frames[2:]))
+		assert.IsError(t, p.Seek(p.ctx, 1), ErrFrameNotHeld)
+		assert.NoError(t, p.Seek(p.ctx, 4))
+		expectFrameOrder(t, p, frames[2:])
+	})
+
+	t.Run("seek_paused", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		mustAddFrames(t, p, frames)
+		expectFrameOrder(t, p, frames[:2])
+
+		assert.NoError(t, p.Pause(p.ctx))
+		assert.NoError(t, p.Seek(p.ctx, 0))
+		expectFrameOrder(t, p, frames[:1])
+		expectNoFrame(t, p)
+
+		assert.NoError(t, p.Resume(p.ctx))
+		expectFrameOrder(t, p, frames[1:])
+	})
+
+	t.Run("speed", func(t *testing.T) {
+		p, _ := startPlayer(t, 10)
+		assert.Error(t, p.SetSpeed(p.ctx, 0))
+		assert.Error(t, p.SetSpeed(p.ctx, -1))
+
+		assert.NoError(t, p.SetSpeed(p.ctx, 4))
+		assert.Equal(t, 4.0, p.Status().Speed)
+
+		slow := []Frame[testFrame]{
+			{Image: testFrame{"frame 1"}, DurationMs: 200},
+			{Image: testFrame{"frame 2"}, DurationMs: 200},
+		}
+		mustAddFrames(t, p, slow)
+		expectFrameOrder(t, p, slow[:1])
+
+		start := time.Now()
+		expectFrameOrder(t, p, slow[1:])
+		if d := time.Since(start); d > 100*time.Millisecond {
+			t.Errorf("frame at 4x speed took %v instead of 50ms", d)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package animation
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultBPM is the tempo of a BeatClock that is not given one.
+const DefaultBPM = 120
+
+const (
+	// MinBPM is the slowest tempo of a BeatClock.
+	MinBPM = 1
+	// MaxBPM is the fastest tempo of a BeatClock. Beats much shorter than
+	// this are too short to time frames by.
+	MaxBPM = 1000
+)
+
+const (
+	// tapTimeout is how long after the last tap a new tap starts over
+	// instead of adding to the tempo.
+	tapTimeout = 2 * time.Second
+	// maxTaps is the number of taps that the tempo is averaged over.
+	maxTaps = 8
+)
+
+// BeatClock maps time to musical beats. It lets frames be timed in beats, so
+// that an animation follows the music. The tempo can be set directly or
+// tapped in. It is safe to use from multiple goroutines.
+//
+// Beats are counted from an origin, so a beat position is a float where the
+// integer part is the beat and the fractional part is the phase within it.
+type BeatClock struct {
+	clock Clock
+
+	mu      sync.Mutex
+	bpm     float64
+	origin  time.Time // the time of beat 0
+	taps    []time.Time
+	changed chan struct{}
+}
+
+// NewBeatClock creates a new BeatClock with the given tempo in beats per
+// minute, which must be between MinBPM and MaxBPM. Beat 0 is now. If clock is
+// nil, SystemClock is used. If bpm is 0, DefaultBPM is used.
+func NewBeatClock(clock Clock, bpm float64) *BeatClock {
+	if clock == nil {
+		clock = SystemClock
+	}
+	if bpm == 0 {
+		bpm = DefaultBPM
+	}
+	if err := checkBPM(bpm); err != nil {
+		panic(err)
+	}
+	return &BeatClock{
+		clock:   clock,
+		bpm:     bpm,
+		origin:  clock.Now(),
+		changed: make(chan struct{}),
+	}
+}
+
+// Tempo returns the tempo in beats per minute and the current phase, which is
+// how far into the current beat we are, between 0 and 1.
+func (c *BeatClock) Tempo() (bpm, phase float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	beat := c.beatAt(c.clock.Now())
+	return c.bpm, beat - math.Floor(beat)
+}
+
+// SetTempo sets the tempo in beats per minute, which must be between MinBPM
+// and MaxBPM. The current beat position is kept, so the change takes effect
+// from now on.
+func (c *BeatClock) SetTempo(bpm float64) error {
+	if err := checkBPM(bpm); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	c.setTempo(now, c.beatAt(now), bpm)
+	c.notify()
+	return nil
+}
+
+// SetPhase sets the phase of the current beat, which is between 0 and 1. The
+// beat position moves by at most half a beat, either way.
+func (c *BeatClock) SetPhase(phase float64) error {
+	if !(phase >= 0 && phase < 1) {
+		return fmt.Errorf("invalid phase %v", phase)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	beat := c.beatAt(now)
+	c.setTempo(now, nearestBeat(beat, phase), c.bpm)
+	c.notify()
+	return nil
+}
+
+// Tap registers a tap on the beat. Each tap lands on a whole beat. Taps that
+// follow each other within a couple of seconds set the tempo to their average
+// interval, kept between MinBPM and MaxBPM; a tap after a pause only sets the
+// phase.
+func (c *BeatClock) Tap() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if len(c.taps) > 0 && now.Sub(c.taps[len(c.taps)-1]) > tapTimeout {
+		c.taps = c.taps[:0]
+	}
+	if len(c.taps) == maxTaps {
+		c.taps = append(c.taps[:0], c.taps[1:]...)
+	}
+	c.taps = append(c.taps, now)
+
+	bpm := c.bpm
+	if n := len(c.taps); n > 1 {
+		interval := c.taps[n-1].Sub(c.taps[0]) / time.Duration(n-1)
+		if interval > 0 {
+			bpm = float64(time.Minute) / float64(interval)
+		}
+		bpm = min(max(bpm, MinBPM), MaxBPM)
+	}
+
+	c.setTempo(now, nearestBeat(c.beatAt(now), 0), bpm)
+	c.notify()
+}
+
+// BeatAt returns the beat position at the given time.
+func (c *BeatClock) BeatAt(t time.Time) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.beatAt(t)
+}
+
+// TimeAt returns the time of the given beat position.
+func (c *BeatClock) TimeAt(beat float64) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.timeAt(beat)
+}
+
+// BeatDuration returns the duration of a single beat.
+func (c *BeatClock) BeatDuration() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.beatDuration()
+}
+
+// Changed returns a channel that is closed the next time the tempo or phase
+// changes. Call it again for the change after that.
+func (c *BeatClock) Changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.changed
+}
+
+func checkBPM(bpm float64) error {
+	if !(bpm >= MinBPM && bpm <= MaxBPM) {
+		return fmt.Errorf("tempo %v BPM is not between %d and %d", bpm, MinBPM, MaxBPM)
+	}
+	return nil
+}
+
+func (c *BeatClock) beatDuration() time.Duration {
+	return time.Duration(float64(time.Minute) / c.bpm)
+}
+
+func (c *BeatClock) beatAt(t time.Time) float64 {
+	return float64(t.Sub(c.origin)) / float64(c.beatDuration())
+}
+
+func (c *BeatClock) timeAt(beat float64) time.Time {
+	return c.origin.Add(time.Duration(beat * float64(c.beatDuration())))
+}
+
+// setTempo sets the tempo so that the given time is at the given beat.
+func (c *BeatClock) setTempo(t time.Time, beat, bpm float64) {
+	c.bpm = bpm
+	c.origin = t.Add(-time.Duration(beat * float64(c.beatDuration())))
+}
+
+func (c *BeatClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// nearestBeat returns the beat position closest to beat that has the given
+// phase.
+func nearestBeat(beat, phase float64) float64 {
+	return math.Round(beat-phase) + phase
+}
+
+// beatEpsilon is how far past a beat position, in beats, still counts as
+// being on it. Timers fire a little late, which must not cost a whole beat.
+const beatEpsilon = 1e-6
+
+// nextBeat returns the first beat position at or after beat that is a
+// multiple of step. It is used to start frames that last step beats on the
+// beat grid.
+func nextBeat(beat, step float64) float64 {
+	return math.Ceil(beat/step-beatEpsilon) * step
+}
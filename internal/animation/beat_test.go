@@ -0,0 +1,314 @@
+package animation
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// fakeClock is a Clock whose time only moves when told to. Timers only fire
+// when time is moved, even if they are due right away.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock  *fakeClock
+	ch     chan time.Time
+	when   time.Time
+	active bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	t.Reset(d)
+	return t
+}
+
+// Advance moves time forward by d, firing the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if t.active && !t.when.After(c.now) {
+			t.fire()
+		}
+	}
+}
+
+// AdvanceToNext waits for a timer to be armed, then moves time forward to
+// when it is due and fires it. It returns how far time moved.
+func (c *fakeClock) AdvanceToNext(t *testing.T) time.Duration {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		var next *fakeTimer
+		for _, timer := range c.timers {
+			if timer.active && (next == nil || timer.when.Before(next.when)) {
+				next = timer
+			}
+		}
+		if next != nil {
+			d := next.when.Sub(c.now)
+			c.now = next.when
+			next.fire()
+			c.mu.Unlock()
+			return d
+		}
+		c.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("no timer was armed")
+	return 0
+}
+
+// fire must be called with the clock locked.
+func (t *fakeTimer) fire() {
+	t.active = false
+	select {
+	case t.ch <- t.clock.now:
+	default:
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := t.active
+	t.active = true
+	t.when = t.clock.now.Add(d)
+	return wasActive
+}
+
+func TestBeatClock(t *testing.T) {
+	t.Run("tempo", func(t *testing.T) {
+		clock := newFakeClock()
+		beats := NewBeatClock(clock, 120)
+		start := clock.Now()
+
+		assert.Equal(t, 500*time.Millisecond, beats.BeatDuration())
+		assertBeat(t, 0, beats.BeatAt(start))
+		assertBeat(t, 2.5, beats.BeatAt(start.Add(1250*time.Millisecond)))
+		assert.Equal(t, start.Add(time.Second), beats.TimeAt(2))
+
+		clock.Advance(750 * time.Millisecond)
+		bpm, phase := beats.Tempo()
+		assert.Equal(t, 120.0, bpm)
+		assertBeat(t, 0.5, phase)
+
+		// Changing the tempo keeps the current beat position.
+		changed := beats.Changed()
+		assert.NoError(t, beats.SetTempo(60))
+		assertClosed(t, changed)
+		assertBeat(t, 1.5, beats.BeatAt(clock.Now()))
+		assertBeat(t, 2.5, beats.BeatAt(clock.Now().Add(time.Second)))
+
+		assert.Error(t, beats.SetTempo(0))
+		assert.Error(t, beats.SetTempo(math.Inf(1)))
+		assert.Error(t, beats.SetTempo(math.NaN()))
+		assert.Error(t, beats.SetTempo(1e-10))
+		assert.Error(t, beats.SetTempo(1e12))
+		assert.NoError(t, beats.SetTempo(MinBPM))
+		assert.NoError(t, beats.SetTempo(MaxBPM))
+		assert.Equal(t, 60*time.Millisecond, beats.BeatDuration())
+
+		assert.Panics(t, func() { NewBeatClock(clock, 1e-10) })
+		assert.Panics(t, func() { NewBeatClock(clock, 1e12) })
+	})
+
+	t.Run("phase", func(t *testing.T) {
+		clock := newFakeClock()
+		beats := NewBeatClock(clock, 120)
+
+		clock.Advance(600 * time.Millisecond) // beat 1.2
+		assert.NoError(t, beats.SetPhase(0.9))
+		assertBeat(t, 0.9, beats.BeatAt(clock.Now()))
+
+		assert.NoError(t, beats.SetPhase(0.1))
+		assertBeat(t, 1.1, beats.BeatAt(clock.Now()))
+
+		assert.Error(t, beats.SetPhase(1))
+	})
+
+	t.Run("tap", func(t *testing.T) {
+		clock := newFakeClock()
+		beats := NewBeatClock(clock, 120)
+
+		clock.Advance(100 * time.Millisecond)
+		for i := 0; i < 4; i++ {
+			beats.Tap()
+			bpm, phase := beats.Tempo()
+			assertBeat(t, 0, phase)
+			if i > 0 {
+				assertBeat(t, 150, bpm)
+			}
+			clock.Advance(400 * time.Millisecond)
+		}
+
+		// A tap after a long break only moves the phase.
+		clock.Advance(5 * time.Second)
+		beats.Tap()
+		bpm, phase := beats.Tempo()
+		assertBeat(t, 150, bpm)
+		assertBeat(t, 0, phase)
+
+		// Taps that are too close together are kept to MaxBPM.
+		clock.Advance(time.Millisecond)
+		beats.Tap()
+		bpm, _ = beats.Tempo()
+		assertBeat(t, MaxBPM, bpm)
+	})
+}
+
+func TestPlayerBeats(t *testing.T) {
+	frames := []Frame[testFrame]{
+		{Image: testFrame{"frame 1"}, DurationBeats: 1},
+		{Image: testFrame{"frame 2"}, DurationBeats: 1},
+		{Image: testFrame{"frame 3"}, DurationBeats: 0.5},
+		{Image: testFrame{"frame 4"}, DurationBeats: 1},
+	}
+
+	t.Run("grid", func(t *testing.T) {
+		p, clock := startBeatPlayer(t)
+
+		// Start a bit into the first beat, then wait for the next one.
+		clock.Advance(200 * time.Millisecond)
+		mustAddFrames(t, p, frames)
+
+		expectFrameAfter(t, p, clock, frames[0], 300*time.Millisecond)
+		expectFrameAfter(t, p, clock, frames[1], 500*time.Millisecond)
+		expectFrameAfter(t, p, clock, frames[2], 250*time.Millisecond)
+		expectFrameAfter(t, p, clock, frames[3], 500*time.Millisecond)
+	})
+
+	t.Run("tempo_change", func(t *testing.T) {
+		p, clock := startBeatPlayer(t)
+		mustAddFrames(t, p, frames[:2])
+		expectFrameAfter(t, p, clock, frames[0], 0)
+
+		// Halfway into the next frame, halve the tempo. The other half of
+		// the beat now takes twice as long.
+		clock.Advance(250 * time.Millisecond)
+		assert.NoError(t, p.Beats().SetTempo(60))
+		waitForRemaining(t, p, 500*time.Millisecond)
+
+		expectFrameAfter(t, p, clock, frames[1], 500*time.Millisecond)
+		assert.Equal(t, 60.0, p.Status().BPM)
+	})
+
+	t.Run("tap_tempo", func(t *testing.T) {
+		p, clock := startBeatPlayer(t)
+
+		for i := 0; i < 3; i++ {
+			p.Beats().Tap()
+			clock.Advance(300 * time.Millisecond)
+		}
+		p.Beats().Tap()
+		assertBeat(t, 200, p.Status().BPM)
+
+		mustAddFrames(t, p, frames[:2])
+		expectFrameAfter(t, p, clock, frames[0], 0)
+		expectFrameAfter(t, p, clock, frames[1], 300*time.Millisecond)
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		p, clock := startBeatPlayer(t)
+
+		mixed := []Frame[testFrame]{
+			{Image: testFrame{"frame 1"}, DurationMs: 100},
+			{Image: testFrame{"frame 2"}, DurationBeats: 1},
+			{Image: testFrame{"frame 3"}, DurationMs: 100},
+		}
+		mustAddFrames(t, p, mixed)
+
+		expectFrameAfter(t, p, clock, mixed[0], 100*time.Millisecond)
+		expectFrameAfter(t, p, clock, mixed[1], 400*time.Millisecond)
+		expectFrameAfter(t, p, clock, mixed[2], 100*time.Millisecond)
+	})
+}
+
+func startBeatPlayer(t *testing.T) (*testPlayer[testFrame], *fakeClock) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	clock := newFakeClock()
+	p := NewPlayerWithOpts(PlayerOpts[testFrame]{Clock: clock})
+	go p.Run(ctx)
+
+	return &testPlayer[testFrame]{p, ctx}, clock
+}
+
+// expectFrameAfter advances the clock to the next timer and expects that it
+// shows the given frame after d.
+func expectFrameAfter(t *testing.T, p *testPlayer[testFrame], clock *fakeClock, frame Frame[testFrame], d time.Duration) {
+	t.Helper()
+
+	assert.Equal(t, d, clock.AdvanceToNext(t))
+	expectFrameOrder(t, p, []Frame[testFrame]{frame})
+}
+
+// waitForRemaining waits for the player to catch up with a change that makes
+// the remaining time of the player the given duration.
+func waitForRemaining(t *testing.T, p *testPlayer[testFrame], remaining time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Status().Remaining != remaining {
+		if time.Now().After(deadline) {
+			t.Fatalf("remaining is %v, not %v", p.Status().Remaining, remaining)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func assertBeat(t *testing.T, expected, actual float64) {
+	t.Helper()
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func assertClosed(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ch:
+	default:
+		t.Error("channel is not closed")
+	}
+}
@@ -0,0 +1,39 @@
+package animation
+
+import "time"
+
+// Clock tells the time and makes timers. The player uses it for all of its
+// timing, which allows tests to control time.
+type Clock interface {
+	Now() time.Time
+	// NewTimer creates a timer that fires once after d, like time.NewTimer.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer made by a Clock. It behaves like time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// SystemClock is the Clock that uses the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time        { return t.t.C }
+func (t systemTimer) Stop() bool                 { return t.t.Stop() }
+func (t systemTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
@@ -0,0 +1,215 @@
+package animation
+
+import "time"
+
+// frameBuffer is a ring buffer of frames. Frames are addressed by their
+// absolute position, which only ever grows; the slot of a position is the
+// position modulo the buffer size.
+//
+// Frames that have already been played are kept around for as long as a loop
+// may jump back to them.
+type frameBuffer[Image any] struct {
+	slots []frameSlot[Image]
+
+	// insert is the position of the next frame to be added.
+	insert uint64
+	// playback is the position of the frame that was last taken out of the
+	// buffer to be shown. Its slot is never overwritten.
+	playback uint64
+	// tail is the position of the oldest frame that must be kept.
+	tail uint64
+	// start is the position of the first frame added after the last clear.
+	// Frames cannot jump back past it.
+	start uint64
+}
+
+type frameSlot[Image any] struct {
+	Frame[Image]
+	// jumpBack is the resolved number of frames to jump back by. It is 0 if
+	// the frame does not jump.
+	jumpBack uint64
+	// jumps is the number of times the jump has been taken in the current
+	// run of the loop.
+	jumps uint32
+}
+
+func newFrameBuffer[Image any](size int) frameBuffer[Image] {
+	// Position 0 is a blank frame that is never shown. It acts as the frame
+	// before the first one.
+	return frameBuffer[Image]{
+		slots:  make([]frameSlot[Image], size),
+		insert: 1,
+		start:  1,
+	}
+}
+
+func (b *frameBuffer[Image]) slot(pos uint64) *frameSlot[Image] {
+	return &b.slots[pos%uint64(len(b.slots))]
+}
+
+// add adds a frame to the buffer. The caller must ensure that the buffer is
+// not full.
+func (b *frameBuffer[Image]) add(f Frame[Image]) {
+	pos := b.insert
+	b.insert++
+
+	s := b.slot(pos)
+	*s = frameSlot[Image]{Frame: f}
+
+	switch {
+	case f.JumpToMarker != "":
+		s.jumpBack = b.findMarker(pos, f.JumpToMarker)
+	case f.JumpBackAmount > 0:
+		s.jumpBack = uint64(f.JumpBackAmount)
+	}
+
+	// The target of the jump must still be in the buffer. Frames older than
+	// the last len(slots)-1 frames may have been overwritten.
+	if s.jumpBack >= uint64(len(b.slots)) || s.jumpBack > pos-b.start {
+		s.jumpBack = 0
+	}
+
+	b.updateTail()
+}
+
+// findMarker returns how far back from pos the latest frame with the given
+// marker is. It returns 0 if there is no such frame.
+func (b *frameBuffer[Image]) findMarker(pos uint64, marker string) uint64 {
+	oldest := b.start
+	if n := uint64(len(b.slots)); pos >= n && pos-n+1 > oldest {
+		oldest = pos - n + 1
+	}
+	for p := pos - 1; p >= oldest; p-- {
+		if b.slot(p).Marker == marker {
+			return pos - p
+		}
+	}
+	return 0
+}
+
+// clear drops all frames that have not been taken out of the buffer yet.
+func (b *frameBuffer[Image]) clear() {
+	b.playback = b.insert - 1
+	b.tail = b.playback
+	b.start = b.insert
+
+	// The last frame must not loop back into the dropped frames.
+	s := b.slot(b.playback)
+	s.jumpBack = 0
+	s.jumps = 0
+}
+
+// next takes the next frame out of the buffer. False is returned if there
+// are no more frames.
+func (b *frameBuffer[Image]) next() (*Frame[Image], bool) {
+	s := b.slot(b.playback)
+
+	var next uint64
+	if s.jumpBack > 0 && (s.LoopCount == 0 || s.jumps+1 < s.LoopCount) {
+		s.jumps++
+		next = b.playback - s.jumpBack
+	} else {
+		// The loop, if any, is done. Reset it so that it plays again if an
+		// outer loop jumps back over it.
+		s.jumps = 0
+		next = b.playback + 1
+		if next == b.insert {
+			return nil, false
+		}
+	}
+
+	b.playback = next
+	b.updateTail()
+
+	return &b.slot(next).Frame, true
+}
+
+// dropOldest drops the frame at playback, which has been taken out of the
+// buffer but not shown yet, and takes out the frame after it instead. Nothing
+// is dropped if that would not make room in the buffer because a loop still
+// holds on to the frame.
+func (b *frameBuffer[Image]) dropOldest() (*Frame[Image], bool) {
+	if b.playback+1 >= b.insert {
+		return nil, false
+	}
+	for p := b.playback; p < b.insert; p++ {
+		s := b.slot(p)
+		if s.jumpBack > 0 && p-s.jumpBack <= b.playback {
+			return nil, false
+		}
+	}
+
+	b.playback++
+	b.updateTail()
+
+	return &b.slot(b.playback).Frame, true
+}
+
+// seek takes the frame at pos out of the buffer as if playback had just
+// reached it. Loops from pos onwards start over. False is returned if the frame
+// is not held by the buffer, or if a loop after it would jump back to a frame
+// that is no longer held.
+func (b *frameBuffer[Image]) seek(pos uint64) (*Frame[Image], bool) {
+	if pos < b.start || pos >= b.insert {
+		return nil, false
+	}
+
+	tail := pos
+	for p := pos; p < b.insert; p++ {
+		s := b.slot(p)
+		if s.jumpBack > 0 && p-s.jumpBack < tail {
+			tail = p - s.jumpBack
+		}
+	}
+	if b.insert-tail > uint64(len(b.slots)) {
+		return nil, false
+	}
+
+	for p := pos; p < b.insert; p++ {
+		b.slot(p).jumps = 0
+	}
+
+	b.playback = pos
+	b.tail = tail
+
+	return &b.slot(pos).Frame, true
+}
+
+// updateTail moves the tail to the oldest frame that can still be played:
+// either the current frame or the target of a loop that has not been left yet.
+func (b *frameBuffer[Image]) updateTail() {
+	tail := b.playback
+	for p := b.playback; p < b.insert; p++ {
+		s := b.slot(p)
+		if s.jumpBack > 0 && p-s.jumpBack < tail {
+			tail = p - s.jumpBack
+		}
+	}
+	b.tail = tail
+}
+
+// isFull returns true if the buffer cannot take in any more frames.
+func (b *frameBuffer[Image]) isFull() bool {
+	return b.insert-b.tail >= uint64(len(b.slots))
+}
+
+// queued returns the number and total duration of the frames after playback.
+// The durations of frames timed in beats are summed up in beats instead.
+// looping is true if any frame from playback onwards will still jump back.
+func (b *frameBuffer[Image]) queued() (n int, d time.Duration, beats float64, looping bool) {
+	for p := b.playback; p < b.insert; p++ {
+		s := b.slot(p)
+		if p > b.playback {
+			n++
+			if s.DurationBeats > 0 {
+				beats += s.DurationBeats
+			} else {
+				d += s.Duration()
+			}
+		}
+		if s.jumpBack > 0 && (s.LoopCount == 0 || s.jumps+1 < s.LoopCount) {
+			looping = true
+		}
+	}
+	return
+}
@@ -0,0 +1,131 @@
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// EasingFunc maps the linear progress t of a transition, which is between 0
+// and 1, to the eased progress of that transition. An EasingFunc must return 0
+// for t = 0 and 1 for t = 1.
+type EasingFunc func(t float64) float64
+
+// EaseLinear is an EasingFunc that does not ease at all.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInOutCubic is an EasingFunc that starts and ends slowly.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// EaseInOutSine is an EasingFunc that starts and ends slowly. It is gentler
+// than EaseInOutCubic.
+func EaseInOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// InterpolateFunc blends image a into image b. t is between 0 and 1, where 0
+// must produce a and 1 must produce b.
+//
+// The result should be written into dst, which is a value that was previously
+// returned by the same InterpolateFunc, or the zero value if there is none.
+// This allows the function to reuse its buffers. The returned value is used as
+// dst in a later call.
+type InterpolateFunc[Image any] func(dst, a, b Image, t float64) Image
+
+// DefaultOutputInterval is the default interval at which the player emits
+// blended frames.
+const DefaultOutputInterval = time.Second / 60
+
+// blender holds the buffers used for blending and copying frames in the
+// player. Output images are double-buffered: the image that the receiver
+// currently holds is never written to.
+type blender[Image any] struct {
+	interpolate InterpolateFunc[Image]
+	easing      EasingFunc
+	copy        func(dst, src Image) Image
+
+	out     [2]Image
+	outIx   int
+	tween   Image
+	fade    Image // snapshot of the image being faded out
+	current Image // copy of the currently shown image
+}
+
+func newBlender[Image any](interpolate InterpolateFunc[Image], easing EasingFunc, copy func(dst, src Image) Image) blender[Image] {
+	if easing == nil {
+		easing = EaseLinear
+	}
+	return blender[Image]{
+		interpolate: interpolate,
+		easing:      easing,
+		copy:        copy,
+	}
+}
+
+// enabled returns true if the blender can blend images.
+func (b *blender[Image]) enabled() bool {
+	return b.interpolate != nil
+}
+
+// blendTween blends the two frames into the tween buffer.
+func (b *blender[Image]) blendTween(x, y Image, t float64) Image {
+	b.tween = b.interpolate(b.tween, x, y, b.easing(t))
+	return b.tween
+}
+
+// snapshotFade copies img into the fade buffer.
+func (b *blender[Image]) snapshotFade(img Image) {
+	b.fade = b.interpolate(b.fade, img, img, 0)
+}
+
+// blendFade blends the fade snapshot into img.
+func (b *blender[Image]) blendFade(img Image, t float64) Image {
+	return b.blendOut(b.fade, img, t)
+}
+
+// blendOut blends the two images into the output buffer that is not currently
+// held by the receiver.
+func (b *blender[Image]) blendOut(x, y Image, t float64) Image {
+	b.out[b.outIx] = b.interpolate(b.out[b.outIx], x, y, b.easing(t))
+	return b.out[b.outIx]
+}
+
+// copying returns true if the player owns copies of the images it shows.
+func (b *blender[Image]) copying() bool {
+	return b.copy != nil
+}
+
+// copyCurrent copies img into the current buffer.
+func (b *blender[Image]) copyCurrent(img Image) Image {
+	b.current = b.copy(b.current, img)
+	return b.current
+}
+
+// copyOut copies img into the output buffer that is not currently held by the
+// receiver.
+func (b *blender[Image]) copyOut(img Image) Image {
+	b.out[b.outIx] = b.copy(b.out[b.outIx], img)
+	return b.out[b.outIx]
+}
+
+// sent marks the current output buffer as held by the receiver.
+func (b *blender[Image]) sent() {
+	b.outIx ^= 1
+}
+
+// progress returns how far elapsed is into total, clamped to [0, 1].
+func progress(elapsed, total time.Duration) float64 {
+	if total <= 0 || elapsed >= total {
+		return 1
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(elapsed) / float64(total)
+}
@@ -0,0 +1,102 @@
+package xcolor
+
+import "fmt"
+
+// BlendMode describes how a color is blended onto another.
+type BlendMode uint8
+
+const (
+	// BlendNormal paints the top color over the bottom one.
+	BlendNormal BlendMode = iota
+	// BlendAdd adds the colors together, which brightens them.
+	BlendAdd
+	// BlendMultiply multiplies the colors, which darkens them. It is useful
+	// for masks: white keeps the bottom color and black turns it off.
+	BlendMultiply
+	// BlendScreen is the inverse of multiplying the inverted colors, which
+	// brightens them without clipping as harshly as BlendAdd.
+	BlendScreen
+)
+
+var blendModeNames = [...]string{
+	BlendNormal:   "normal",
+	BlendAdd:      "add",
+	BlendMultiply: "multiply",
+	BlendScreen:   "screen",
+}
+
+// ParseBlendMode parses the name of a blend mode, such as "screen".
+func ParseBlendMode(name string) (BlendMode, error) {
+	for m, n := range blendModeNames {
+		if n == name {
+			return BlendMode(m), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown blend mode %q", name)
+}
+
+// String returns the name of the blend mode.
+func (m BlendMode) String() string {
+	if int(m) < len(blendModeNames) {
+		return blendModeNames[m]
+	}
+	return fmt.Sprintf("BlendMode(%d)", m)
+}
+
+// IsValid returns true if m is a known blend mode.
+func (m BlendMode) IsValid() bool {
+	return int(m) < len(blendModeNames)
+}
+
+// Blend blends the color top onto bottom using the blend mode m. opacity is
+// between 0 and 1, where 0 returns bottom and 1 returns the fully blended
+// color.
+func Blend(m BlendMode, bottom, top RGB, opacity float64) RGB {
+	var blended RGB
+	switch m {
+	case BlendNormal:
+		blended = top
+	case BlendAdd:
+		blended = RGB{
+			R: add8(bottom.R, top.R),
+			G: add8(bottom.G, top.G),
+			B: add8(bottom.B, top.B),
+		}
+	case BlendMultiply:
+		blended = RGB{
+			R: mul8(bottom.R, top.R),
+			G: mul8(bottom.G, top.G),
+			B: mul8(bottom.B, top.B),
+		}
+	case BlendScreen:
+		blended = RGB{
+			R: 0xFF - mul8(0xFF-bottom.R, 0xFF-top.R),
+			G: 0xFF - mul8(0xFF-bottom.G, 0xFF-top.G),
+			B: 0xFF - mul8(0xFF-bottom.B, 0xFF-top.B),
+		}
+	default:
+		panic(fmt.Sprintf("invalid blend mode %v", m))
+	}
+
+	switch {
+	case opacity >= 1:
+		return blended
+	case opacity <= 0:
+		return bottom
+	default:
+		return Lerp(bottom, blended, opacity)
+	}
+}
+
+func add8(a, b uint8) uint8 {
+	if s := uint16(a) + uint16(b); s < 0xFF {
+		return uint8(s)
+	}
+	return 0xFF
+}
+
+// mul8 multiplies a and b as if they were between 0 and 1, rounding to the
+// nearest value.
+func mul8(a, b uint8) uint8 {
+	return uint8((uint16(a)*uint16(b) + 0x7F) / 0xFF)
+}
@@ -0,0 +1,59 @@
+package xcolor
+
+import "math"
+
+// HSV converts a color in the HSV color space to RGB. h is the hue in turns,
+// so 0 and 1 are both red. s and v are the saturation and value, between 0
+// and 1.
+func HSV(h, s, v float64) RGB {
+	h = (h - math.Floor(h)) * 6
+	i := int(h)
+	f := h - float64(i)
+
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+
+	var r, g, b float64
+	switch i {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+
+	return RGB{
+		R: unit8(r),
+		G: unit8(g),
+		B: unit8(b),
+	}
+}
+
+// Scale scales the brightness of c by f, which is between 0 and 1.
+func Scale(c RGB, f float64) RGB {
+	return RGB{
+		R: unit8(float64(c.R) / 0xFF * f),
+		G: unit8(float64(c.G) / 0xFF * f),
+		B: unit8(float64(c.B) / 0xFF * f),
+	}
+}
+
+// unit8 converts a value between 0 and 1 to a uint8, clamping it.
+func unit8(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 1:
+		return 0xFF
+	default:
+		return uint8(v*0xFF + 0.5)
+	}
+}
@@ -46,3 +46,17 @@ func (c RGB) RGBA() (r, g, b, a uint32) {
 	a = 0xFFFF
 	return
 }
+
+// Lerp linearly interpolates between the colors a and b. t is between 0 and 1,
+// where 0 returns a and 1 returns b.
+func Lerp(a, b RGB, t float64) RGB {
+	return RGB{
+		R: lerp8(a.R, b.R, t),
+		G: lerp8(a.G, b.G, t),
+		B: lerp8(a.B, b.B, t),
+	}
+}
+
+func lerp8(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
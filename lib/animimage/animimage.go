@@ -0,0 +1,113 @@
+// Package animimage decodes animated images, such as animated GIFs and
+// APNGs, into a list of full frames. Each frame is the whole image as it is
+// shown at that point in the animation, so that frames don't depend on each
+// other.
+package animimage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"time"
+)
+
+// Frame is a frame of an animation.
+type Frame struct {
+	// Image is the whole image as it is shown during this frame.
+	Image *image.RGBA
+	// Delay is how long the frame is shown for.
+	Delay time.Duration
+}
+
+// Animation is a decoded animated image.
+type Animation struct {
+	// Frames are the frames of the animation. Images that aren't animated
+	// have a single frame with no delay.
+	Frames []Frame
+	// Plays is the number of times that the animation is played. Zero plays
+	// it forever.
+	Plays int
+}
+
+// Bounds returns the bounds of the frames.
+func (a *Animation) Bounds() image.Rectangle {
+	if len(a.Frames) == 0 {
+		return image.Rectangle{}
+	}
+	return a.Frames[0].Image.Rect
+}
+
+// Duration returns how long it takes to play the animation once.
+func (a *Animation) Duration() time.Duration {
+	var d time.Duration
+	for _, f := range a.Frames {
+		d += f.Delay
+	}
+	return d
+}
+
+var (
+	gifMagic = []byte("GIF8")
+	pngMagic = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// Decode decodes an animated GIF or APNG. Any other image that is registered
+// with the image package, including PNGs that aren't animated, is decoded as
+// a single frame.
+func Decode(r io.Reader) (*Animation, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(pngMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gifMagic):
+		return DecodeGIF(br)
+	case bytes.HasPrefix(magic, pngMagic):
+		return DecodeAPNG(br)
+	}
+
+	img, _, err := image.Decode(br)
+	if err != nil {
+		return nil, err
+	}
+	return still(img), nil
+}
+
+// still returns an animation of a single image.
+func still(img image.Image) *Animation {
+	return &Animation{
+		Frames: []Frame{{Image: toRGBA(img)}},
+		Plays:  1,
+	}
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rectangle{Max: b.Size()})
+	draw.Draw(rgba, rgba.Rect, img, b.Min, draw.Src)
+	return rgba
+}
+
+// cloneRGBA returns a copy of img.
+func cloneRGBA(img *image.RGBA) *image.RGBA {
+	return &image.RGBA{
+		Pix:    bytes.Clone(img.Pix),
+		Stride: img.Stride,
+		Rect:   img.Rect,
+	}
+}
+
+// clearRect makes the given part of img transparent.
+func clearRect(img *image.RGBA, r image.Rectangle) {
+	draw.Draw(img, r, image.Transparent, image.Point{}, draw.Src)
+}
+
+// errFormat formats an error about a malformed image.
+func errFormat(format string, args ...any) error {
+	return fmt.Errorf("animimage: "+format, args...)
+}
@@ -0,0 +1,113 @@
+package animimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	_ "embed"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// bounce.gif and bounce.apng are the same 4x4 animation of 3 frames:
+//
+//  1. the whole image is blue
+//  2. a red square is drawn at (1,1) and then disposed to the previous frame
+//  3. a red square is drawn at (2,2)
+var (
+	//go:embed bounce.gif
+	bounceGIF []byte
+	//go:embed bounce.apng
+	bounceAPNG []byte
+)
+
+var (
+	blue = color.RGBA{0, 0, 255, 255}
+	red  = color.RGBA{255, 0, 0, 255}
+)
+
+// bounceFrames returns the frames of the bounce animation.
+func bounceFrames() []*image.RGBA {
+	square := func(x, y int) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for py := 0; py < 4; py++ {
+			for px := 0; px < 4; px++ {
+				c := blue
+				if px >= x && px < x+2 && py >= y && py < y+2 {
+					c = red
+				}
+				img.SetRGBA(px, py, c)
+			}
+		}
+		return img
+	}
+	return []*image.RGBA{square(-2, -2), square(1, 1), square(2, 2)}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		delays []time.Duration
+	}{
+		{
+			name: "gif",
+			data: bounceGIF,
+			// The second frame has no delay, which is shown for 100ms.
+			delays: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name:   "apng",
+			data:   bounceAPNG,
+			delays: []time.Duration{100 * time.Millisecond, 0, 250 * time.Millisecond},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			anim, err := Decode(bytes.NewReader(test.data))
+			assert.NoError(t, err)
+			assert.Equal(t, 0, anim.Plays)
+			assert.Equal(t, image.Rect(0, 0, 4, 4), anim.Bounds())
+
+			want := bounceFrames()
+			assert.Equal(t, len(want), len(anim.Frames))
+			for i, frame := range anim.Frames {
+				assert.Equal(t, want[i].Pix, frame.Image.Pix, "frame %d", i)
+				assert.Equal(t, test.delays[i], frame.Delay, "frame %d", i)
+			}
+		})
+	}
+}
+
+func TestDecodeStill(t *testing.T) {
+	img := bounceFrames()[1]
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	anim, err := Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, anim.Plays)
+	assert.Equal(t, 1, len(anim.Frames))
+	assert.Equal(t, img.Pix, anim.Frames[0].Image.Pix)
+	assert.Equal(t, time.Duration(0), anim.Duration())
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	// Corrupt the checksum of the last chunk.
+	data := bytes.Clone(bounceAPNG)
+	data[len(data)-1] ^= 0xFF
+	_, err := Decode(bytes.NewReader(data))
+	assert.Error(t, err)
+
+	_, err = Decode(bytes.NewReader(bounceAPNG[:len(bounceAPNG)/2]))
+	assert.Error(t, err)
+
+	_, err = Decode(bytes.NewReader([]byte("not an image")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,273 @@
+package animimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"time"
+)
+
+// APNG extends PNG with these chunks, which PNG decoders ignore:
+//
+//	acTL: the number of frames and plays
+//	fcTL: the size, offset, delay and compositing of the next frame
+//	fdAT: the image data of a frame, like IDAT with a sequence number
+//
+// The IDAT image is the first frame if an fcTL comes before it. Otherwise, it
+// is a fallback image that isn't part of the animation. Each frame is decoded
+// by wrapping its data in a PNG of its own, with the header and palette of
+// the APNG.
+
+const (
+	apngDisposeNone       = 0
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+
+	apngBlendSource = 0
+	apngBlendOver   = 1
+)
+
+type apngChunk struct {
+	typ  string
+	data []byte
+}
+
+type apngFrame struct {
+	rect     image.Rectangle
+	delay    time.Duration
+	dispose  byte
+	blend    byte
+	data     bytes.Buffer // concatenated IDAT or fdAT data
+	hasImage bool
+}
+
+// DecodeAPNG decodes a PNG, which may be an APNG. A PNG that isn't animated
+// is decoded as a single frame.
+func DecodeAPNG(r io.Reader) (*Animation, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := readPNGChunks(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		header   []byte
+		shared   []apngChunk // chunks that each frame needs, like PLTE
+		animated bool
+		plays    int
+		frames   []*apngFrame
+		current  *apngFrame
+	)
+
+	for _, c := range chunks {
+		switch c.typ {
+		case "IHDR":
+			if len(c.data) != 13 {
+				return nil, errFormat("invalid IHDR")
+			}
+			header = c.data
+		case "acTL":
+			if len(c.data) != 8 {
+				return nil, errFormat("invalid acTL")
+			}
+			animated = true
+			plays = int(binary.BigEndian.Uint32(c.data[4:]))
+		case "fcTL":
+			f, err := parseFCTL(c.data)
+			if err != nil {
+				return nil, err
+			}
+			current = f
+			frames = append(frames, f)
+		case "IDAT":
+			if current != nil {
+				current.data.Write(c.data)
+				current.hasImage = true
+			}
+		case "fdAT":
+			if len(c.data) < 4 {
+				return nil, errFormat("invalid fdAT")
+			}
+			if current == nil {
+				return nil, errFormat("fdAT before fcTL")
+			}
+			current.data.Write(c.data[4:])
+			current.hasImage = true
+		case "IEND":
+		default:
+			// Chunks before the image data, such as PLTE, tRNS and gAMA,
+			// apply to all frames.
+			if len(frames) == 0 && !isAncillaryAfterData(c.typ) {
+				shared = append(shared, c)
+			}
+		}
+	}
+
+	if header == nil {
+		return nil, errFormat("missing IHDR")
+	}
+	if !animated || len(frames) == 0 {
+		img, err := png.Decode(bytes.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		return still(img), nil
+	}
+
+	width := int(binary.BigEndian.Uint32(header[0:]))
+	height := int(binary.BigEndian.Uint32(header[4:]))
+	bounds := image.Rect(0, 0, width, height)
+
+	anim := &Animation{Plays: plays}
+	canvas := image.NewRGBA(bounds)
+
+	for i, f := range frames {
+		if !f.hasImage {
+			return nil, errFormat("frame %d has no image data", i)
+		}
+		if !f.rect.In(bounds) {
+			return nil, errFormat("frame %d at %v is outside of the image %v", i, f.rect, bounds)
+		}
+
+		img, err := decodeAPNGFrame(header, shared, f)
+		if err != nil {
+			return nil, errFormat("frame %d: %v", i, err)
+		}
+
+		dispose := f.dispose
+		if i == 0 && dispose == apngDisposePrevious {
+			dispose = apngDisposeBackground
+		}
+
+		var previous *image.RGBA
+		if dispose == apngDisposePrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		op := draw.Over
+		if f.blend == apngBlendSource {
+			op = draw.Src
+		}
+		draw.Draw(canvas, f.rect, img, img.Bounds().Min, op)
+
+		anim.Frames = append(anim.Frames, Frame{
+			Image: cloneRGBA(canvas),
+			Delay: f.delay,
+		})
+
+		switch dispose {
+		case apngDisposeBackground:
+			clearRect(canvas, f.rect)
+		case apngDisposePrevious:
+			canvas = previous
+		}
+	}
+
+	return anim, nil
+}
+
+func parseFCTL(data []byte) (*apngFrame, error) {
+	if len(data) != 26 {
+		return nil, errFormat("invalid fcTL")
+	}
+
+	be := binary.BigEndian
+	w := int(be.Uint32(data[4:]))
+	h := int(be.Uint32(data[8:]))
+	x := int(be.Uint32(data[12:]))
+	y := int(be.Uint32(data[16:]))
+	num := be.Uint16(data[20:])
+	den := be.Uint16(data[22:])
+	if den == 0 {
+		den = 100
+	}
+
+	return &apngFrame{
+		rect:    image.Rect(x, y, x+w, y+h),
+		delay:   time.Duration(num) * time.Second / time.Duration(den),
+		dispose: data[24],
+		blend:   data[25],
+	}, nil
+}
+
+// isAncillaryAfterData returns true for chunks that may come after the image
+// data and that frames don't need.
+func isAncillaryAfterData(typ string) bool {
+	switch typ {
+	case "tEXt", "zTXt", "iTXt", "tIME":
+		return true
+	}
+	return false
+}
+
+// decodeAPNGFrame decodes the image data of a frame by wrapping it in a PNG.
+func decodeAPNGFrame(header []byte, shared []apngChunk, f *apngFrame) (image.Image, error) {
+	ihdr := bytes.Clone(header)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(f.rect.Dx()))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(f.rect.Dy()))
+
+	var buf bytes.Buffer
+	buf.Write(pngMagic)
+	writePNGChunk(&buf, "IHDR", ihdr)
+	for _, c := range shared {
+		writePNGChunk(&buf, c.typ, c.data)
+	}
+	writePNGChunk(&buf, "IDAT", f.data.Bytes())
+	writePNGChunk(&buf, "IEND", nil)
+
+	return png.Decode(&buf)
+}
+
+func readPNGChunks(src []byte) ([]apngChunk, error) {
+	if !bytes.HasPrefix(src, pngMagic) {
+		return nil, errFormat("not a PNG")
+	}
+	src = src[len(pngMagic):]
+
+	var chunks []apngChunk
+	for len(src) > 0 {
+		if len(src) < 12 {
+			return nil, errFormat("truncated chunk")
+		}
+		n := binary.BigEndian.Uint32(src)
+		if uint64(n) > uint64(len(src)-12) {
+			return nil, errFormat("truncated chunk")
+		}
+		typ := string(src[4:8])
+		data := src[8 : 8+n]
+		crc := binary.BigEndian.Uint32(src[8+n:])
+		if crc32.ChecksumIEEE(src[4:8+n]) != crc {
+			return nil, errFormat("invalid checksum of %s chunk", typ)
+		}
+		chunks = append(chunks, apngChunk{typ, data})
+		src = src[12+n:]
+
+		if typ == "IEND" {
+			break
+		}
+	}
+	return chunks, nil
+}
+
+func writePNGChunk(w *bytes.Buffer, typ string, data []byte) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(len(data)))
+	w.Write(b[:])
+
+	crc := crc32.NewIEEE()
+	w.WriteString(typ)
+	crc.Write([]byte(typ))
+	w.Write(data)
+	crc.Write(data)
+
+	binary.BigEndian.PutUint32(b[:], crc.Sum32())
+	w.Write(b[:])
+}
@@ -0,0 +1,73 @@
+package animimage
+
+import (
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// minGIFDelay is the shortest delay that GIF frames are shown for. Browsers
+// show frames with shorter delays, which are usually 0, for 100ms instead.
+const minGIFDelay = 20 * time.Millisecond
+
+// DecodeGIF decodes a GIF, which may be animated.
+func DecodeGIF(r io.Reader) (*Animation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	anim := &Animation{
+		Frames: make([]Frame, len(g.Image)),
+	}
+	switch {
+	case g.LoopCount < 0:
+		anim.Plays = 1
+	case g.LoopCount == 0:
+		anim.Plays = 0
+	default:
+		anim.Plays = g.LoopCount + 1
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+	var previous *image.RGBA
+
+	for i, frame := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Rect, frame, frame.Rect.Min, draw.Over)
+
+		delay := 100 * time.Millisecond
+		if i < len(g.Delay) {
+			if d := time.Duration(g.Delay[i]) * 10 * time.Millisecond; d >= minGIFDelay {
+				delay = d
+			}
+		}
+		anim.Frames[i] = Frame{
+			Image: cloneRGBA(canvas),
+			Delay: delay,
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			clearRect(canvas, frame.Rect)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	if len(anim.Frames) == 1 {
+		anim.Frames[0].Delay = 0
+		anim.Plays = 1
+	}
+
+	return anim, nil
+}
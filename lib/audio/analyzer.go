@@ -0,0 +1,179 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"time"
+)
+
+// AnalyzerOpts is a set of options for creating a new Analyzer.
+type AnalyzerOpts struct {
+	// SampleRate is the sample rate of the audio. It is required.
+	SampleRate int
+	// FFTSize is the number of samples that each analysis looks at. It must
+	// be a power of two and defaults to 1024.
+	FFTSize int
+	// HopSize is the number of new samples per analysis. It defaults to half
+	// of FFTSize.
+	HopSize int
+	// Bands is the number of frequency bands. It defaults to 8.
+	Bands int
+	// MinFreq and MaxFreq are the edges of the lowest and highest bands in
+	// Hz. The bands are spaced logarithmically in between, which is how we
+	// hear pitch. They default to 40 Hz and 16 kHz, and MaxFreq is capped at
+	// the Nyquist frequency.
+	MinFreq, MaxFreq float64
+	// DynamicRange is the range of loudness, in dB below a full-scale sine,
+	// that is mapped to levels between 0 and 1. It defaults to 60.
+	DynamicRange float64
+	// Release is how long it takes a level to fall to about a third after
+	// the sound stops. Levels rise right away. It defaults to 150ms, which
+	// keeps the lights from flickering.
+	Release time.Duration
+}
+
+// Analyzer splits audio into frequency band levels.
+type Analyzer struct {
+	opts AnalyzerOpts
+	plan fftPlan
+
+	window  []float64 // Hann window
+	winNorm float64   // scales band power to the RMS of the band
+	decay   float64   // level kept per hop when falling
+
+	samples  []float64 // sliding window of the last FFTSize samples
+	spectrum []complex128
+	bands    [][2]int // bin range of each band
+	levels   []float64
+}
+
+// NewAnalyzer creates a new Analyzer.
+func NewAnalyzer(opts AnalyzerOpts) (*Analyzer, error) {
+	if opts.SampleRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rate %d", opts.SampleRate)
+	}
+	if opts.FFTSize == 0 {
+		opts.FFTSize = 1024
+	}
+	if opts.FFTSize < 2 || opts.FFTSize&(opts.FFTSize-1) != 0 {
+		return nil, fmt.Errorf("FFT size %d is not a power of two", opts.FFTSize)
+	}
+	if opts.HopSize == 0 {
+		opts.HopSize = opts.FFTSize / 2
+	}
+	if opts.HopSize < 0 || opts.HopSize > opts.FFTSize {
+		return nil, fmt.Errorf("hop size %d is not within the FFT size", opts.HopSize)
+	}
+	if opts.Bands == 0 {
+		opts.Bands = 8
+	}
+	if opts.Bands < 0 {
+		return nil, fmt.Errorf("invalid band count %d", opts.Bands)
+	}
+	if opts.MinFreq == 0 {
+		opts.MinFreq = 40
+	}
+	if opts.MaxFreq == 0 {
+		opts.MaxFreq = 16000
+	}
+	if nyquist := float64(opts.SampleRate) / 2; opts.MaxFreq > nyquist {
+		opts.MaxFreq = nyquist
+	}
+	if opts.MinFreq <= 0 || opts.MinFreq >= opts.MaxFreq {
+		return nil, fmt.Errorf("invalid frequency range %v-%v Hz", opts.MinFreq, opts.MaxFreq)
+	}
+	if opts.DynamicRange == 0 {
+		opts.DynamicRange = 60
+	}
+	if opts.Release == 0 {
+		opts.Release = 150 * time.Millisecond
+	}
+
+	n := opts.FFTSize
+
+	window := make([]float64, n)
+	var windowPower float64
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+		windowPower += window[i] * window[i]
+	}
+
+	hop := time.Duration(opts.HopSize) * time.Second / time.Duration(opts.SampleRate)
+
+	return &Analyzer{
+		opts:     opts,
+		plan:     newFFTPlan(n),
+		window:   window,
+		winNorm:  2 / (float64(n) * windowPower),
+		decay:    math.Exp(-float64(hop) / float64(opts.Release)),
+		samples:  make([]float64, n),
+		spectrum: make([]complex128, n),
+		bands:    bandBins(opts),
+		levels:   make([]float64, opts.Bands),
+	}, nil
+}
+
+// bandBins returns the range of FFT bins of each band. Every band gets at
+// least one bin, so low bands may share bins if the FFT is too small.
+func bandBins(opts AnalyzerOpts) [][2]int {
+	binWidth := float64(opts.SampleRate) / float64(opts.FFTSize)
+	ratio := opts.MaxFreq / opts.MinFreq
+
+	bands := make([][2]int, opts.Bands)
+	for i := range bands {
+		lo := opts.MinFreq * math.Pow(ratio, float64(i)/float64(opts.Bands))
+		hi := opts.MinFreq * math.Pow(ratio, float64(i+1)/float64(opts.Bands))
+
+		start := int(math.Round(lo / binWidth))
+		end := int(math.Round(hi / binWidth))
+		start = max(start, 1) // skip DC
+		end = min(max(end, start+1), opts.FFTSize/2+1)
+		start = min(start, end-1)
+
+		bands[i] = [2]int{start, end}
+	}
+	return bands
+}
+
+// Opts returns the options of the analyzer, with the defaults filled in.
+func (a *Analyzer) Opts() AnalyzerOpts {
+	return a.opts
+}
+
+// Analyze takes in the next HopSize samples and returns the level of each
+// band, between 0 and 1. The returned slice is reused by the next call.
+func (a *Analyzer) Analyze(hop []float64) []float64 {
+	if len(hop) != a.opts.HopSize {
+		panic("audio: Analyze called with the wrong number of samples")
+	}
+
+	// Slide the window along.
+	copy(a.samples, a.samples[len(hop):])
+	copy(a.samples[len(a.samples)-len(hop):], hop)
+
+	for i, s := range a.samples {
+		a.spectrum[i] = complex(s*a.window[i], 0)
+	}
+	a.plan.transform(a.spectrum)
+
+	for i, bins := range a.bands {
+		var power float64
+		for _, x := range a.spectrum[bins[0]:bins[1]] {
+			abs := cmplx.Abs(x)
+			power += abs * abs
+		}
+
+		// The RMS of the band relative to that of a full-scale sine.
+		rms := math.Sqrt(power * a.winNorm)
+		db := 20 * math.Log10(rms*math.Sqrt2+1e-12)
+
+		level := 1 + db/a.opts.DynamicRange
+		level = min(max(level, 0), 1)
+
+		// Rise right away, but fall off slowly.
+		a.levels[i] = max(level, a.levels[i]*a.decay)
+	}
+
+	return a.levels
+}
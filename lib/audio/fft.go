@@ -0,0 +1,65 @@
+package audio
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// fftPlan holds the precomputed tables for a radix-2 FFT of a fixed size. It
+// is plain Go, which is fast enough for a Raspberry Pi at audio rates.
+type fftPlan struct {
+	twiddles []complex128
+	reversed []int
+}
+
+func newFFTPlan(n int) fftPlan {
+	if n < 2 || n&(n-1) != 0 {
+		panic("FFT size must be a power of two")
+	}
+
+	twiddles := make([]complex128, n/2)
+	for k := range twiddles {
+		twiddles[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+
+	shift := bits.UintSize - bits.TrailingZeros(uint(n))
+	reversed := make([]int, n)
+	for i := range reversed {
+		reversed[i] = int(bits.Reverse(uint(i)) >> shift)
+	}
+
+	return fftPlan{
+		twiddles: twiddles,
+		reversed: reversed,
+	}
+}
+
+// transform computes the discrete Fourier transform of x in place. len(x)
+// must be the size of the plan.
+func (p *fftPlan) transform(x []complex128) {
+	n := len(x)
+	if n != len(p.reversed) {
+		panic("FFT input size does not match the plan")
+	}
+
+	for i, j := range p.reversed {
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size *= 2 {
+		half := size / 2
+		step := n / size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				w := p.twiddles[k*step]
+				a := x[start+k]
+				b := x[start+k+half] * w
+				x[start+k] = a + b
+				x[start+k+half] = a - b
+			}
+		}
+	}
+}
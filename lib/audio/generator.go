@@ -0,0 +1,78 @@
+package audio
+
+import (
+	"fmt"
+	"image"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// GeneratorOpts is a set of options for creating a new Generator.
+type GeneratorOpts struct {
+	// Analyzer is the options for the analyzer. SampleRate is taken from
+	// the audio and does not need to be set.
+	Analyzer AnalyzerOpts
+	// Visualizer is the options for the visualizer.
+	Visualizer VisualizerOpts
+}
+
+// Generator turns audio into animation frames. Each frame shows the band
+// levels of one hop of audio, and lasts as long as that hop.
+type Generator struct {
+	pcm      *PCMReader
+	analyzer *Analyzer
+	visual   *Visualizer
+	hop      []float64
+
+	samples int64 // total samples read
+	elapsed animation.Milliseconds
+}
+
+// NewGenerator creates a new Generator that reads audio from pcm and draws it
+// onto the LEDs at the given positions.
+func NewGenerator(pcm *PCMReader, ledPositions []image.Point, opts GeneratorOpts) (*Generator, error) {
+	opts.Analyzer.SampleRate = pcm.Format().SampleRate
+
+	analyzer, err := NewAnalyzer(opts.Analyzer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create analyzer: %w", err)
+	}
+
+	aopts := analyzer.Opts()
+	return &Generator{
+		pcm:      pcm,
+		analyzer: analyzer,
+		visual:   NewVisualizer(ledPositions, aopts.Bands, opts.Visualizer),
+		hop:      make([]float64, aopts.HopSize),
+	}, nil
+}
+
+// Levels returns the band levels of the last frame.
+func (g *Generator) Levels() []float64 {
+	return g.analyzer.levels
+}
+
+// Next reads the next hop of audio and returns the frame for it, drawn onto
+// dst. dst is reused if it is large enough. It returns io.EOF once the audio
+// ends; a partial hop at the end is dropped.
+func (g *Generator) Next(dst leddraw.LEDStrip) (animation.Frame[leddraw.LEDStrip], error) {
+	n, err := g.pcm.Read(g.hop)
+	if n < len(g.hop) {
+		return animation.Frame[leddraw.LEDStrip]{}, err
+	}
+
+	levels := g.analyzer.Analyze(g.hop)
+
+	// Track the total time so that rounding to milliseconds never makes the
+	// lights drift from the audio.
+	g.samples += int64(n)
+	elapsed := animation.Milliseconds(g.samples * 1000 / int64(g.pcm.Format().SampleRate))
+	duration := elapsed - g.elapsed
+	g.elapsed = elapsed
+
+	return animation.Frame[leddraw.LEDStrip]{
+		Image:      g.visual.Render(dst, levels),
+		DurationMs: duration,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// testTree is a column of LEDs from the top of the tree (Y = 0) down to the
+// bottom (Y = 90).
+var testTree = func() []image.Point {
+	pts := make([]image.Point, 10)
+	for i := range pts {
+		pts[i] = image.Pt(50, i*10)
+	}
+	return pts
+}()
+
+func TestAnalyzer(t *testing.T) {
+	tests := []struct {
+		name string
+		wav  []byte
+		band int // loudest band
+	}{
+		{"bass", bassWAV, 0},
+		{"treble", trebleWAV, 7},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pcm, err := NewWAVReader(bytes.NewReader(test.wav))
+			assert.NoError(t, err)
+
+			analyzer, err := NewAnalyzer(AnalyzerOpts{
+				SampleRate: pcm.Format().SampleRate,
+			})
+			assert.NoError(t, err)
+
+			hop := make([]float64, analyzer.Opts().HopSize)
+			var levels []float64
+			for {
+				_, err := pcm.Read(hop)
+				if err == io.EOF {
+					break
+				}
+				assert.NoError(t, err)
+				levels = analyzer.Analyze(hop)
+			}
+
+			t.Log("levels:", levels)
+
+			// A sine at half scale is 6 dB below full scale.
+			loudest := levels[test.band]
+			assert.True(t, loudest > 0.8 && loudest < 0.95, "loudest level %v", loudest)
+
+			// Some of the tone leaks into the bands next to it, but it should
+			// stay out of the ones further away.
+			for i, level := range levels {
+				switch {
+				case i == test.band:
+				case i == test.band-1 || i == test.band+1:
+					assert.True(t, level < loudest, "band %d is too loud: %v", i, level)
+				default:
+					assert.True(t, level < loudest-0.5, "band %d is too loud: %v", i, level)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	run := func(t *testing.T, wav []byte) (leddraw.LEDStrip, []animation.Frame[leddraw.LEDStrip]) {
+		pcm, err := NewWAVReader(bytes.NewReader(wav))
+		assert.NoError(t, err)
+
+		gen, err := NewGenerator(pcm, testTree, GeneratorOpts{})
+		assert.NoError(t, err)
+
+		var frames []animation.Frame[leddraw.LEDStrip]
+		for {
+			frame, err := gen.Next(nil)
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			frames = append(frames, frame)
+		}
+
+		assert.True(t, len(frames) > 0, "no frames generated")
+		return frames[len(frames)-1].Image, frames
+	}
+
+	brightness := func(strip leddraw.LEDStrip, i int) int {
+		c := strip[i]
+		return int(c.R) + int(c.G) + int(c.B)
+	}
+
+	t.Run("bass", func(t *testing.T) {
+		strip, _ := run(t, bassWAV)
+		bottom, top := brightness(strip, len(strip)-1), brightness(strip, 0)
+		assert.True(t, bottom > 2*top, "bottom %d is not brighter than top %d", bottom, top)
+	})
+
+	t.Run("treble", func(t *testing.T) {
+		strip, _ := run(t, trebleWAV)
+		bottom, top := brightness(strip, len(strip)-1), brightness(strip, 0)
+		assert.True(t, top > 2*bottom, "top %d is not brighter than bottom %d", top, bottom)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		_, frames := run(t, bassWAV)
+
+		// 10 hops of 512 samples at 22050 Hz add up to 232ms, even though
+		// each hop is 23.2ms.
+		var total animation.Milliseconds
+		for _, frame := range frames {
+			total += frame.DurationMs
+		}
+		assert.Equal(t, 10, len(frames))
+		assert.Equal(t, animation.Milliseconds(232), total)
+	})
+}
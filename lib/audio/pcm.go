@@ -0,0 +1,163 @@
+// Package audio turns audio into light. It reads PCM audio, splits it into
+// frequency bands and maps the bands onto the tree.
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SampleFormat is the encoding of a single PCM sample. All multi-byte formats
+// are little-endian.
+type SampleFormat uint8
+
+const (
+	// S16LE is signed 16-bit. It is the default.
+	S16LE SampleFormat = iota
+	// U8 is unsigned 8-bit.
+	U8
+	// S24LE is signed 24-bit, packed into 3 bytes.
+	S24LE
+	// S32LE is signed 32-bit.
+	S32LE
+	// F32LE is 32-bit IEEE float.
+	F32LE
+)
+
+var sampleFormatNames = [...]string{
+	S16LE: "s16le",
+	U8:    "u8",
+	S24LE: "s24le",
+	S32LE: "s32le",
+	F32LE: "f32le",
+}
+
+// ParseSampleFormat parses the name of a sample format. The names are the
+// ones that ffmpeg uses, such as "s16le".
+func ParseSampleFormat(name string) (SampleFormat, error) {
+	for f, n := range sampleFormatNames {
+		if n == name {
+			return SampleFormat(f), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown sample format %q", name)
+}
+
+// String returns the name of the sample format.
+func (f SampleFormat) String() string {
+	if int(f) < len(sampleFormatNames) {
+		return sampleFormatNames[f]
+	}
+	return fmt.Sprintf("SampleFormat(%d)", f)
+}
+
+// Size returns the size of a single sample in bytes.
+func (f SampleFormat) Size() int {
+	switch f {
+	case U8:
+		return 1
+	case S16LE:
+		return 2
+	case S24LE:
+		return 3
+	case S32LE, F32LE:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Format describes a stream of interleaved PCM samples.
+type Format struct {
+	SampleRate int
+	Channels   int
+	Sample     SampleFormat
+}
+
+func (f Format) validate() error {
+	if f.SampleRate <= 0 {
+		return fmt.Errorf("invalid sample rate %d", f.SampleRate)
+	}
+	if f.Channels <= 0 {
+		return fmt.Errorf("invalid channel count %d", f.Channels)
+	}
+	if f.Sample.Size() == 0 {
+		return fmt.Errorf("invalid sample format %v", f.Sample)
+	}
+	return nil
+}
+
+// PCMReader reads interleaved PCM samples and mixes them down to mono.
+type PCMReader struct {
+	r      io.Reader
+	format Format
+	buf    []byte
+}
+
+// NewPCMReader creates a new PCMReader that reads samples of the given format
+// from r.
+func NewPCMReader(r io.Reader, format Format) (*PCMReader, error) {
+	if err := format.validate(); err != nil {
+		return nil, err
+	}
+	return &PCMReader{r: r, format: format}, nil
+}
+
+// Format returns the format of the samples.
+func (r *PCMReader) Format() Format {
+	return r.format
+}
+
+// Read reads up to len(dst) mono samples into dst, scaled to [-1, 1]. It
+// blocks until dst is full or the stream ends, which makes it suitable for
+// reading a live stream one block at a time. At the end of the stream, the
+// samples that were read are returned along with io.EOF.
+func (r *PCMReader) Read(dst []float64) (int, error) {
+	sampleSize := r.format.Sample.Size()
+	frameSize := sampleSize * r.format.Channels
+
+	if need := len(dst) * frameSize; cap(r.buf) < need {
+		r.buf = make([]byte, need)
+	}
+	buf := r.buf[:len(dst)*frameSize]
+
+	n, err := io.ReadFull(r.r, buf)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+
+	// A partial frame at the end is dropped.
+	frames := n / frameSize
+	for i := 0; i < frames; i++ {
+		frame := buf[i*frameSize : (i+1)*frameSize]
+
+		var sum float64
+		for c := 0; c < r.format.Channels; c++ {
+			sum += decodeSample(frame[c*sampleSize:], r.format.Sample)
+		}
+		dst[i] = sum / float64(r.format.Channels)
+	}
+
+	return frames, err
+}
+
+func decodeSample(b []byte, f SampleFormat) float64 {
+	switch f {
+	case U8:
+		return (float64(b[0]) - 128) / 128
+	case S16LE:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+	case S24LE:
+		v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+		return float64(v) / (1 << 23)
+	case S32LE:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+	case F32LE:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	default:
+		panic("unreachable")
+	}
+}
@@ -0,0 +1,105 @@
+package audio
+
+import (
+	"image"
+	"math"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// VisualizerOpts is a set of options for creating a new Visualizer.
+type VisualizerOpts struct {
+	// Saturation is the saturation of the band colors. It defaults to 1.
+	Saturation float64
+	// HueOffset is the hue of the lowest band, in turns. The bands go from
+	// HueOffset up to HueOffset+HueRange. It defaults to 0, which is red.
+	HueOffset float64
+	// HueRange is the range of hues that the bands span, in turns. It
+	// defaults to 0.75, which goes from red to violet.
+	HueRange float64
+}
+
+// Visualizer maps frequency band levels onto the tree. Lower bands light up
+// the bottom of the tree and higher bands light up the top, so bass lights
+// the base of the tree and treble lights the tip.
+type Visualizer struct {
+	// bands is the position of each LED in band space, where 0 is the
+	// middle of the lowest band.
+	bands  []float64
+	colors []xcolor.RGB // full brightness color of each band
+}
+
+// NewVisualizer creates a new Visualizer for the LEDs at the given positions,
+// such as the ones from led-points.csv. The Y axis points down, so the LED
+// with the largest Y is at the bottom of the tree.
+func NewVisualizer(ledPositions []image.Point, bands int, opts VisualizerOpts) *Visualizer {
+	if bands <= 0 {
+		panic("audio: NewVisualizer called with no bands")
+	}
+	if opts.Saturation == 0 {
+		opts.Saturation = 1
+	}
+	if opts.HueRange == 0 {
+		opts.HueRange = 0.75
+	}
+
+	minY, maxY := math.MaxInt, math.MinInt
+	for _, pt := range ledPositions {
+		minY = min(minY, pt.Y)
+		maxY = max(maxY, pt.Y)
+	}
+
+	ledBands := make([]float64, len(ledPositions))
+	for i, pt := range ledPositions {
+		var height float64 // 0 at the bottom, 1 at the top
+		if maxY > minY {
+			height = float64(maxY-pt.Y) / float64(maxY-minY)
+		}
+		ledBands[i] = height * float64(bands-1)
+	}
+
+	colors := make([]xcolor.RGB, bands)
+	for i := range colors {
+		var t float64
+		if bands > 1 {
+			t = float64(i) / float64(bands-1)
+		}
+		colors[i] = xcolor.HSV(opts.HueOffset+t*opts.HueRange, opts.Saturation, 1)
+	}
+
+	return &Visualizer{
+		bands:  ledBands,
+		colors: colors,
+	}
+}
+
+// Render draws the band levels onto dst and returns it. dst is reused if it
+// is large enough. levels must have as many bands as the visualizer.
+func (v *Visualizer) Render(dst leddraw.LEDStrip, levels []float64) leddraw.LEDStrip {
+	if len(levels) != len(v.colors) {
+		panic("audio: Render called with the wrong number of bands")
+	}
+
+	if cap(dst) < len(v.bands) {
+		dst = make(leddraw.LEDStrip, len(v.bands))
+	}
+	dst = dst[:len(v.bands)]
+
+	last := len(v.colors) - 1
+	for i, b := range v.bands {
+		// Blend between the two closest bands so that the lights don't
+		// have hard edges.
+		lo := min(int(b), last)
+		hi := min(lo+1, last)
+		t := b - float64(lo)
+
+		c := xcolor.Lerp(
+			xcolor.Scale(v.colors[lo], levels[lo]),
+			xcolor.Scale(v.colors[hi], levels[hi]),
+			t)
+		dst[i] = c
+	}
+
+	return dst
+}
@@ -0,0 +1,133 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// NewWAVReader reads the header of a WAV file from r and returns a reader for
+// its samples. Integer PCM of 8 to 32 bits and 32-bit float samples are
+// supported.
+//
+// WAV files that are streamed, such as from ffmpeg writing to a pipe, often
+// have a bogus data size. The samples are then read until the end of r.
+func NewWAVReader(r io.Reader) (*PCMReader, error) {
+	var riff struct {
+		ID   [4]byte
+		Size uint32
+		Type [4]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &riff); err != nil {
+		return nil, fmt.Errorf("cannot read RIFF header: %w", err)
+	}
+	if string(riff.ID[:]) != "RIFF" || string(riff.Type[:]) != "WAVE" {
+		return nil, fmt.Errorf("not a WAV file")
+	}
+
+	var format Format
+	var hasFormat bool
+
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &chunk); err != nil {
+			return nil, fmt.Errorf("cannot read chunk header: %w", err)
+		}
+
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			f, err := readWAVFormat(io.LimitReader(r, int64(chunk.Size)))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size%2)); err != nil {
+				return nil, fmt.Errorf("cannot read fmt chunk: %w", err)
+			}
+			format = f
+			hasFormat = true
+
+		case "data":
+			if !hasFormat {
+				return nil, fmt.Errorf("data chunk before fmt chunk")
+			}
+			data := r
+			if chunk.Size != 0 && chunk.Size != 0xFFFFFFFF {
+				data = io.LimitReader(r, int64(chunk.Size))
+			}
+			return NewPCMReader(data, format)
+
+		default:
+			// Skip the chunk, including its padding byte.
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size)+int64(chunk.Size%2)); err != nil {
+				return nil, fmt.Errorf("cannot skip %q chunk: %w", chunk.ID[:], err)
+			}
+		}
+	}
+}
+
+// readWAVFormat reads the fmt chunk from r, which must end with the chunk.
+func readWAVFormat(r io.Reader) (Format, error) {
+	var fmtChunk struct {
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}
+	if err := binary.Read(r, binary.LittleEndian, &fmtChunk); err != nil {
+		return Format{}, fmt.Errorf("cannot read fmt chunk: %w", err)
+	}
+
+	audioFormat := fmtChunk.AudioFormat
+	if audioFormat == wavFormatExtensible {
+		var ext struct {
+			Size          uint16
+			ValidBits     uint16
+			ChannelMask   uint32
+			SubFormatCode uint16
+		}
+		if err := binary.Read(r, binary.LittleEndian, &ext); err != nil {
+			return Format{}, fmt.Errorf("cannot read extensible fmt chunk: %w", err)
+		}
+		audioFormat = ext.SubFormatCode
+	}
+
+	// Skip whatever is left of the chunk.
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return Format{}, fmt.Errorf("cannot read fmt chunk: %w", err)
+	}
+
+	format := Format{
+		SampleRate: int(fmtChunk.SampleRate),
+		Channels:   int(fmtChunk.Channels),
+	}
+
+	switch {
+	case audioFormat == wavFormatPCM && fmtChunk.BitsPerSample == 8:
+		format.Sample = U8
+	case audioFormat == wavFormatPCM && fmtChunk.BitsPerSample == 16:
+		format.Sample = S16LE
+	case audioFormat == wavFormatPCM && fmtChunk.BitsPerSample == 24:
+		format.Sample = S24LE
+	case audioFormat == wavFormatPCM && fmtChunk.BitsPerSample == 32:
+		format.Sample = S32LE
+	case audioFormat == wavFormatFloat && fmtChunk.BitsPerSample == 32:
+		format.Sample = F32LE
+	default:
+		return Format{}, fmt.Errorf(
+			"unsupported WAV format 0x%04X with %d bits per sample",
+			audioFormat, fmtChunk.BitsPerSample)
+	}
+
+	return format, nil
+}
@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	_ "embed"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+var (
+	//go:embed bass.wav
+	bassWAV []byte
+	//go:embed treble.wav
+	trebleWAV []byte
+	//go:embed tone-f32.wav
+	toneF32WAV []byte
+)
+
+func TestWAVReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		wav     []byte
+		format  Format
+		samples int
+		freq    float64
+	}{
+		{
+			name:    "bass",
+			wav:     bassWAV,
+			format:  Format{SampleRate: 22050, Channels: 2, Sample: S16LE},
+			samples: 5512,
+			freq:    60,
+		},
+		{
+			name:    "treble",
+			wav:     trebleWAV,
+			format:  Format{SampleRate: 22050, Channels: 1, Sample: S16LE},
+			samples: 5512,
+			freq:    6000,
+		},
+		{
+			name:    "f32",
+			wav:     toneF32WAV,
+			format:  Format{SampleRate: 8000, Channels: 1, Sample: F32LE},
+			samples: 800,
+			freq:    440,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pcm, err := NewWAVReader(bytes.NewReader(test.wav))
+			assert.NoError(t, err)
+			assert.Equal(t, test.format, pcm.Format())
+
+			var samples []float64
+			buf := make([]float64, 1000)
+			for {
+				n, err := pcm.Read(buf)
+				samples = append(samples, buf[:n]...)
+				if err == io.EOF {
+					break
+				}
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.samples, len(samples))
+
+			// The fixtures are sines at half of full scale.
+			rate := float64(test.format.SampleRate)
+			for i, s := range samples {
+				want := 0.5 * math.Sin(2*math.Pi*test.freq*float64(i)/rate)
+				if math.Abs(s-want) > 1e-4 {
+					t.Fatalf("sample %d: got %v, want %v", i, s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWAVReaderInvalid(t *testing.T) {
+	_, err := NewWAVReader(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00AVI ")))
+	assert.EqualError(t, err, "not a WAV file")
+
+	_, err = NewWAVReader(bytes.NewReader(bassWAV[:20]))
+	assert.Error(t, err)
+}
+
+func TestPCMReader(t *testing.T) {
+	// Two stereo frames of u8 and half of a third one.
+	raw := []byte{0xFF, 0x01, 0xC0, 0xC0, 0x80}
+
+	pcm, err := NewPCMReader(bytes.NewReader(raw), Format{
+		SampleRate: 8000,
+		Channels:   2,
+		Sample:     U8,
+	})
+	assert.NoError(t, err)
+
+	buf := make([]float64, 4)
+	n, err := pcm.Read(buf)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []float64{(127.0 - 127.0) / 2 / 128, 0.5}, buf[:n])
+}
@@ -20,6 +20,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BlendMode is how a layer is blended onto the layers below it.
+type BlendMode int32
+
+const (
+	// Paint the layer over the layers below it.
+	BlendMode_BLEND_MODE_NORMAL BlendMode = 0
+	// Add the colors together, which brightens them.
+	BlendMode_BLEND_MODE_ADD BlendMode = 1
+	// Multiply the colors, which darkens them. White keeps the colors below and
+	// black turns them off.
+	BlendMode_BLEND_MODE_MULTIPLY BlendMode = 2
+	// Brighten the colors without clipping as harshly as BLEND_MODE_ADD.
+	BlendMode_BLEND_MODE_SCREEN BlendMode = 3
+)
+
+// Enum value maps for BlendMode.
+var (
+	BlendMode_name = map[int32]string{
+		0: "BLEND_MODE_NORMAL",
+		1: "BLEND_MODE_ADD",
+		2: "BLEND_MODE_MULTIPLY",
+		3: "BLEND_MODE_SCREEN",
+	}
+	BlendMode_value = map[string]int32{
+		"BLEND_MODE_NORMAL":   0,
+		"BLEND_MODE_ADD":      1,
+		"BLEND_MODE_MULTIPLY": 2,
+		"BLEND_MODE_SCREEN":   3,
+	}
+)
+
+func (x BlendMode) Enum() *BlendMode {
+	p := new(BlendMode)
+	*p = x
+	return p
+}
+
+func (x BlendMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlendMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_christmas_proto_enumTypes[0].Descriptor()
+}
+
+func (BlendMode) Type() protoreflect.EnumType {
+	return &file_christmas_proto_enumTypes[0]
+}
+
+func (x BlendMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlendMode.Descriptor instead.
+func (BlendMode) EnumDescriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{0}
+}
+
 type LEDClientMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -32,7 +90,25 @@ type LEDClientMessage struct {
 	//	*LEDClientMessage_SetLedCanvas
 	//	*LEDClientMessage_GetLeds
 	//	*LEDClientMessage_SetLeds
+	//	*LEDClientMessage_GetPlayerStatus
+	//	*LEDClientMessage_PausePlayback
+	//	*LEDClientMessage_ResumePlayback
+	//	*LEDClientMessage_SeekPlayback
+	//	*LEDClientMessage_SetPlaybackSpeed
+	//	*LEDClientMessage_SetTempo
+	//	*LEDClientMessage_TapTempo
+	//	*LEDClientMessage_GetLayers
+	//	*LEDClientMessage_SetLayer
+	//	*LEDClientMessage_ListEffects
+	//	*LEDClientMessage_PlayEffect
+	//	*LEDClientMessage_PlayScript
+	//	*LEDClientMessage_UploadAnimation
 	Message isLEDClientMessage_Message `protobuf_oneof:"message"`
+	// The layer that this message targets. The canvas, LED and playback APIs
+	// act on the canvas of this layer. Empty targets the default canvas. If the
+	// server has no such layer, or the client is not allowed to control it, the
+	// connection is closed with an error.
+	Layer string `protobuf:"bytes,100,opt,name=layer,proto3" json:"layer,omitempty"`
 }
 
 func (x *LEDClientMessage) Reset() {
@@ -109,6 +185,104 @@ func (x *LEDClientMessage) GetSetLeds() *SetLEDsRequest {
 	return nil
 }
 
+func (x *LEDClientMessage) GetGetPlayerStatus() *GetPlayerStatusRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_GetPlayerStatus); ok {
+		return x.GetPlayerStatus
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetPausePlayback() *PausePlaybackRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_PausePlayback); ok {
+		return x.PausePlayback
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetResumePlayback() *ResumePlaybackRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_ResumePlayback); ok {
+		return x.ResumePlayback
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetSeekPlayback() *SeekPlaybackRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_SeekPlayback); ok {
+		return x.SeekPlayback
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetSetPlaybackSpeed() *SetPlaybackSpeedRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_SetPlaybackSpeed); ok {
+		return x.SetPlaybackSpeed
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetSetTempo() *SetTempoRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_SetTempo); ok {
+		return x.SetTempo
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetTapTempo() *TapTempoRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_TapTempo); ok {
+		return x.TapTempo
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetGetLayers() *GetLayersRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_GetLayers); ok {
+		return x.GetLayers
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetSetLayer() *SetLayerRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_SetLayer); ok {
+		return x.SetLayer
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetListEffects() *ListEffectsRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_ListEffects); ok {
+		return x.ListEffects
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetPlayEffect() *PlayEffectRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_PlayEffect); ok {
+		return x.PlayEffect
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetPlayScript() *PlayScriptRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_PlayScript); ok {
+		return x.PlayScript
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetUploadAnimation() *UploadAnimationRequest {
+	if x, ok := x.GetMessage().(*LEDClientMessage_UploadAnimation); ok {
+		return x.UploadAnimation
+	}
+	return nil
+}
+
+func (x *LEDClientMessage) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
 type isLEDClientMessage_Message interface {
 	isLEDClientMessage_Message()
 }
@@ -146,6 +320,77 @@ type LEDClientMessage_SetLeds struct {
 	SetLeds *SetLEDsRequest `protobuf:"bytes,5,opt,name=set_leds,json=setLeds,proto3,oneof"`
 }
 
+type LEDClientMessage_GetPlayerStatus struct {
+	// Get the state of the animation player. Sends back a
+	// GetPlayerStatusResponse.
+	GetPlayerStatus *GetPlayerStatusRequest `protobuf:"bytes,6,opt,name=get_player_status,json=getPlayerStatus,proto3,oneof"`
+}
+
+type LEDClientMessage_PausePlayback struct {
+	// Pause playback. The shown frame stays on, and frames can still be
+	// added.
+	PausePlayback *PausePlaybackRequest `protobuf:"bytes,7,opt,name=pause_playback,json=pausePlayback,proto3,oneof"`
+}
+
+type LEDClientMessage_ResumePlayback struct {
+	// Resume paused playback.
+	ResumePlayback *ResumePlaybackRequest `protobuf:"bytes,8,opt,name=resume_playback,json=resumePlayback,proto3,oneof"`
+}
+
+type LEDClientMessage_SeekPlayback struct {
+	// Show the given frame right away and continue playing from there.
+	SeekPlayback *SeekPlaybackRequest `protobuf:"bytes,9,opt,name=seek_playback,json=seekPlayback,proto3,oneof"`
+}
+
+type LEDClientMessage_SetPlaybackSpeed struct {
+	// Set the playback rate.
+	SetPlaybackSpeed *SetPlaybackSpeedRequest `protobuf:"bytes,10,opt,name=set_playback_speed,json=setPlaybackSpeed,proto3,oneof"`
+}
+
+type LEDClientMessage_SetTempo struct {
+	// Set the tempo that frames timed in beats follow.
+	SetTempo *SetTempoRequest `protobuf:"bytes,11,opt,name=set_tempo,json=setTempo,proto3,oneof"`
+}
+
+type LEDClientMessage_TapTempo struct {
+	// Tap the tempo. Each tap lands on a beat; taps in quick succession set
+	// the tempo to their average interval.
+	TapTempo *TapTempoRequest `protobuf:"bytes,12,opt,name=tap_tempo,json=tapTempo,proto3,oneof"`
+}
+
+type LEDClientMessage_GetLayers struct {
+	// List the layers that the server combines into the LEDs. Sends back a
+	// GetLayersResponse.
+	GetLayers *GetLayersRequest `protobuf:"bytes,13,opt,name=get_layers,json=getLayers,proto3,oneof"`
+}
+
+type LEDClientMessage_SetLayer struct {
+	// Change the opacity or blend mode of the layer named by layer.
+	SetLayer *SetLayerRequest `protobuf:"bytes,14,opt,name=set_layer,json=setLayer,proto3,oneof"`
+}
+
+type LEDClientMessage_ListEffects struct {
+	// List the built-in effects and the effect scripts of the server. Sends
+	// back a ListEffectsResponse.
+	ListEffects *ListEffectsRequest `protobuf:"bytes,15,opt,name=list_effects,json=listEffects,proto3,oneof"`
+}
+
+type LEDClientMessage_PlayEffect struct {
+	// Replace the frames of the canvas with a built-in effect or an effect
+	// script of the server.
+	PlayEffect *PlayEffectRequest `protobuf:"bytes,16,opt,name=play_effect,json=playEffect,proto3,oneof"`
+}
+
+type LEDClientMessage_PlayScript struct {
+	// Replace the frames of the canvas with an effect script.
+	PlayScript *PlayScriptRequest `protobuf:"bytes,18,opt,name=play_script,json=playScript,proto3,oneof"`
+}
+
+type LEDClientMessage_UploadAnimation struct {
+	// Replace the frames of the canvas with those of an animation file.
+	UploadAnimation *UploadAnimationRequest `protobuf:"bytes,17,opt,name=upload_animation,json=uploadAnimation,proto3,oneof"`
+}
+
 func (*LEDClientMessage_Authenticate) isLEDClientMessage_Message() {}
 
 func (*LEDClientMessage_GetLedCanvasInfo) isLEDClientMessage_Message() {}
@@ -156,6 +401,32 @@ func (*LEDClientMessage_GetLeds) isLEDClientMessage_Message() {}
 
 func (*LEDClientMessage_SetLeds) isLEDClientMessage_Message() {}
 
+func (*LEDClientMessage_GetPlayerStatus) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_PausePlayback) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_ResumePlayback) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_SeekPlayback) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_SetPlaybackSpeed) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_SetTempo) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_TapTempo) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_GetLayers) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_SetLayer) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_ListEffects) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_PlayEffect) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_PlayScript) isLEDClientMessage_Message() {}
+
+func (*LEDClientMessage_UploadAnimation) isLEDClientMessage_Message() {}
+
 type LEDServerMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -166,6 +437,9 @@ type LEDServerMessage struct {
 	//	*LEDServerMessage_Authenticate
 	//	*LEDServerMessage_GetLedCanvasInfo
 	//	*LEDServerMessage_GetLeds
+	//	*LEDServerMessage_GetPlayerStatus
+	//	*LEDServerMessage_GetLayers
+	//	*LEDServerMessage_ListEffects
 	Message isLEDServerMessage_Message `protobuf_oneof:"message"`
 	// If present, the server encountered an error. This is a string describing
 	// the error.
@@ -232,6 +506,27 @@ func (x *LEDServerMessage) GetGetLeds() *GetLEDsResponse {
 	return nil
 }
 
+func (x *LEDServerMessage) GetGetPlayerStatus() *GetPlayerStatusResponse {
+	if x, ok := x.GetMessage().(*LEDServerMessage_GetPlayerStatus); ok {
+		return x.GetPlayerStatus
+	}
+	return nil
+}
+
+func (x *LEDServerMessage) GetGetLayers() *GetLayersResponse {
+	if x, ok := x.GetMessage().(*LEDServerMessage_GetLayers); ok {
+		return x.GetLayers
+	}
+	return nil
+}
+
+func (x *LEDServerMessage) GetListEffects() *ListEffectsResponse {
+	if x, ok := x.GetMessage().(*LEDServerMessage_ListEffects); ok {
+		return x.ListEffects
+	}
+	return nil
+}
+
 func (x *LEDServerMessage) GetError() string {
 	if x != nil && x.Error != nil {
 		return *x.Error
@@ -258,12 +553,33 @@ type LEDServerMessage_GetLeds struct {
 	GetLeds *GetLEDsResponse `protobuf:"bytes,3,opt,name=get_leds,json=getLeds,proto3,oneof"`
 }
 
+type LEDServerMessage_GetPlayerStatus struct {
+	// Response to GetPlayerStatusRequest.
+	GetPlayerStatus *GetPlayerStatusResponse `protobuf:"bytes,4,opt,name=get_player_status,json=getPlayerStatus,proto3,oneof"`
+}
+
+type LEDServerMessage_GetLayers struct {
+	// Response to GetLayersRequest.
+	GetLayers *GetLayersResponse `protobuf:"bytes,5,opt,name=get_layers,json=getLayers,proto3,oneof"`
+}
+
+type LEDServerMessage_ListEffects struct {
+	// Response to ListEffectsRequest.
+	ListEffects *ListEffectsResponse `protobuf:"bytes,6,opt,name=list_effects,json=listEffects,proto3,oneof"`
+}
+
 func (*LEDServerMessage_Authenticate) isLEDServerMessage_Message() {}
 
 func (*LEDServerMessage_GetLedCanvasInfo) isLEDServerMessage_Message() {}
 
 func (*LEDServerMessage_GetLeds) isLEDServerMessage_Message() {}
 
+func (*LEDServerMessage_GetPlayerStatus) isLEDServerMessage_Message() {}
+
+func (*LEDServerMessage_GetLayers) isLEDServerMessage_Message() {}
+
+func (*LEDServerMessage_ListEffects) isLEDServerMessage_Message() {}
+
 type AuthenticateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -453,7 +769,7 @@ type SetLEDsRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// A 1D array of colors. The number of colors must match the number of LEDs.
-	// To get the number of LEDs, call GetLEDs.
+	// To get the number of LEDs, take the length of GetLEDsResponse.
 	Leds []*Color `protobuf:"bytes,1,rep,name=leds,proto3" json:"leds,omitempty"`
 }
 
@@ -736,179 +1052,1334 @@ func (x *RGBAPixels) GetPixels() []byte {
 	return nil
 }
 
-var File_christmas_proto protoreflect.FileDescriptor
-
-var file_christmas_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x22, 0xf0, 0x02, 0x0a,
-	0x10, 0x4c, 0x45, 0x44, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x44, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74,
-	0x6d, 0x61, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65,
-	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x67, 0x65, 0x74, 0x5f, 0x6c,
-	0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73,
-	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x67, 0x65, 0x74, 0x4c,
-	0x65, 0x64, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x0e,
-	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73,
-	0x2e, 0x53, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x43, 0x61,
-	0x6e, 0x76, 0x61, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
-	0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x48, 0x00, 0x52, 0x07, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x73, 0x12, 0x36, 0x0a, 0x08,
-	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x45,
-	0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x74,
-	0x4c, 0x65, 0x64, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x98, 0x02, 0x0a, 0x10, 0x4c, 0x45, 0x44, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x68, 0x72,
-	0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x61,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x54, 0x0a, 0x13, 0x67,
-	0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x5f, 0x69, 0x6e,
-	0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73,
-	0x74, 0x6d, 0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61,
-	0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
-	0x10, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x37, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e,
-	0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
-	0x00, 0x52, 0x07, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2d, 0x0a, 0x13, 0x41, 0x75,
-	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x30, 0x0a, 0x14, 0x41, 0x75, 0x74,
-	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x47,
-	0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a,
-	0x0f, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x24, 0x0a, 0x04, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
-	0x52, 0x04, 0x6c, 0x65, 0x64, 0x73, 0x22, 0x36, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4c, 0x45, 0x44,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x65, 0x64, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
-	0x61, 0x73, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x04, 0x6c, 0x65, 0x64, 0x73, 0x22, 0x19,
-	0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x67, 0x62, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x06, 0x52, 0x03, 0x72, 0x67, 0x62, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x74,
-	0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61,
-	0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x44,
-	0x0a, 0x13, 0x53, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61,
-	0x73, 0x2e, 0x52, 0x47, 0x42, 0x41, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x52, 0x06, 0x70, 0x69,
-	0x78, 0x65, 0x6c, 0x73, 0x22, 0x24, 0x0a, 0x0a, 0x52, 0x47, 0x42, 0x41, 0x50, 0x69, 0x78, 0x65,
-	0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x6c, 0x69,
-	0x62, 0x64, 0x62, 0x2e, 0x73, 0x6f, 0x2f, 0x61, 0x63, 0x6d, 0x2d, 0x63, 0x68, 0x72, 0x69, 0x73,
-	0x74, 0x6d, 0x61, 0x73, 0x2f, 0x6c, 0x69, 0x62, 0x2f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
-	0x61, 0x73, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type GetPlayerStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-var (
-	file_christmas_proto_rawDescOnce sync.Once
-	file_christmas_proto_rawDescData = file_christmas_proto_rawDesc
-)
+func (x *GetPlayerStatusRequest) Reset() {
+	*x = GetPlayerStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_christmas_proto_rawDescGZIP() []byte {
-	file_christmas_proto_rawDescOnce.Do(func() {
-		file_christmas_proto_rawDescData = protoimpl.X.CompressGZIP(file_christmas_proto_rawDescData)
-	})
-	return file_christmas_proto_rawDescData
+func (x *GetPlayerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_christmas_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_christmas_proto_goTypes = []interface{}{
-	(*LEDClientMessage)(nil),         // 0: christmas.LEDClientMessage
-	(*LEDServerMessage)(nil),         // 1: christmas.LEDServerMessage
-	(*AuthenticateRequest)(nil),      // 2: christmas.AuthenticateRequest
-	(*AuthenticateResponse)(nil),     // 3: christmas.AuthenticateResponse
-	(*GetLEDsRequest)(nil),           // 4: christmas.GetLEDsRequest
-	(*GetLEDsResponse)(nil),          // 5: christmas.GetLEDsResponse
-	(*SetLEDsRequest)(nil),           // 6: christmas.SetLEDsRequest
-	(*Color)(nil),                    // 7: christmas.Color
-	(*GetLEDCanvasInfoRequest)(nil),  // 8: christmas.GetLEDCanvasInfoRequest
-	(*GetLEDCanvasInfoResponse)(nil), // 9: christmas.GetLEDCanvasInfoResponse
-	(*SetLEDCanvasRequest)(nil),      // 10: christmas.SetLEDCanvasRequest
-	(*RGBAPixels)(nil),               // 11: christmas.RGBAPixels
+func (*GetPlayerStatusRequest) ProtoMessage() {}
+
+func (x *GetPlayerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_christmas_proto_depIdxs = []int32{
-	2,  // 0: christmas.LEDClientMessage.authenticate:type_name -> christmas.AuthenticateRequest
-	8,  // 1: christmas.LEDClientMessage.get_led_canvas_info:type_name -> christmas.GetLEDCanvasInfoRequest
-	10, // 2: christmas.LEDClientMessage.set_led_canvas:type_name -> christmas.SetLEDCanvasRequest
-	4,  // 3: christmas.LEDClientMessage.get_leds:type_name -> christmas.GetLEDsRequest
-	6,  // 4: christmas.LEDClientMessage.set_leds:type_name -> christmas.SetLEDsRequest
-	3,  // 5: christmas.LEDServerMessage.authenticate:type_name -> christmas.AuthenticateResponse
-	9,  // 6: christmas.LEDServerMessage.get_led_canvas_info:type_name -> christmas.GetLEDCanvasInfoResponse
-	5,  // 7: christmas.LEDServerMessage.get_leds:type_name -> christmas.GetLEDsResponse
-	7,  // 8: christmas.GetLEDsResponse.leds:type_name -> christmas.Color
-	7,  // 9: christmas.SetLEDsRequest.leds:type_name -> christmas.Color
-	11, // 10: christmas.SetLEDCanvasRequest.pixels:type_name -> christmas.RGBAPixels
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+// Deprecated: Use GetPlayerStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{12}
 }
 
-func init() { file_christmas_proto_init() }
-func file_christmas_proto_init() {
-	if File_christmas_proto != nil {
-		return
+type GetPlayerStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of frames that have been added but not shown yet.
+	QueuedFrames uint32 `protobuf:"varint,1,opt,name=queued_frames,json=queuedFrames,proto3" json:"queued_frames,omitempty"`
+	// The maximum number of frames that the player can hold.
+	MaxFrames uint32 `protobuf:"varint,2,opt,name=max_frames,json=maxFrames,proto3" json:"max_frames,omitempty"`
+	// The index of the frame that is currently shown. Frames are indexed in the
+	// order that they were added, starting from 0. Absent if no frame is shown.
+	FrameIndex *uint64 `protobuf:"varint,3,opt,name=frame_index,json=frameIndex,proto3,oneof" json:"frame_index,omitempty"`
+	// How long it takes until all queued frames have been shown once, in
+	// milliseconds. Loops are not accounted for.
+	RemainingMs uint32 `protobuf:"varint,4,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"`
+	// Whether the queued frames will loop, in which case playing them takes
+	// longer than remaining_ms, possibly forever.
+	Looping bool `protobuf:"varint,5,opt,name=looping,proto3" json:"looping,omitempty"`
+	// Whether playback is paused.
+	Paused bool `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"`
+	// The playback rate. 1 is normal speed.
+	Speed float64 `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	// The tempo that frames timed in beats follow, in beats per minute.
+	Bpm float64 `protobuf:"fixed64,8,opt,name=bpm,proto3" json:"bpm,omitempty"`
+}
+
+func (x *GetPlayerStatusResponse) Reset() {
+	*x = GetPlayerStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_christmas_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LEDClientMessage); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_christmas_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LEDServerMessage); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_christmas_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticateRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *GetPlayerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerStatusResponse) ProtoMessage() {}
+
+func (x *GetPlayerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_christmas_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticateResponse); i {
-			case 0:
-				return &v.state
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPlayerStatusResponse) GetQueuedFrames() uint32 {
+	if x != nil {
+		return x.QueuedFrames
+	}
+	return 0
+}
+
+func (x *GetPlayerStatusResponse) GetMaxFrames() uint32 {
+	if x != nil {
+		return x.MaxFrames
+	}
+	return 0
+}
+
+func (x *GetPlayerStatusResponse) GetFrameIndex() uint64 {
+	if x != nil && x.FrameIndex != nil {
+		return *x.FrameIndex
+	}
+	return 0
+}
+
+func (x *GetPlayerStatusResponse) GetRemainingMs() uint32 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *GetPlayerStatusResponse) GetLooping() bool {
+	if x != nil {
+		return x.Looping
+	}
+	return false
+}
+
+func (x *GetPlayerStatusResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *GetPlayerStatusResponse) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *GetPlayerStatusResponse) GetBpm() float64 {
+	if x != nil {
+		return x.Bpm
+	}
+	return 0
+}
+
+type PausePlaybackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PausePlaybackRequest) Reset() {
+	*x = PausePlaybackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PausePlaybackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PausePlaybackRequest) ProtoMessage() {}
+
+func (x *PausePlaybackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PausePlaybackRequest.ProtoReflect.Descriptor instead.
+func (*PausePlaybackRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{14}
+}
+
+type ResumePlaybackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResumePlaybackRequest) Reset() {
+	*x = ResumePlaybackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumePlaybackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumePlaybackRequest) ProtoMessage() {}
+
+func (x *ResumePlaybackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumePlaybackRequest.ProtoReflect.Descriptor instead.
+func (*ResumePlaybackRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{15}
+}
+
+type SeekPlaybackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the frame to seek to, as in
+	// GetPlayerStatusResponse.frame_index. The frame must still be held by the
+	// player, otherwise an error is sent back.
+	FrameIndex uint64 `protobuf:"varint,1,opt,name=frame_index,json=frameIndex,proto3" json:"frame_index,omitempty"`
+}
+
+func (x *SeekPlaybackRequest) Reset() {
+	*x = SeekPlaybackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeekPlaybackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekPlaybackRequest) ProtoMessage() {}
+
+func (x *SeekPlaybackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekPlaybackRequest.ProtoReflect.Descriptor instead.
+func (*SeekPlaybackRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SeekPlaybackRequest) GetFrameIndex() uint64 {
+	if x != nil {
+		return x.FrameIndex
+	}
+	return 0
+}
+
+type SetPlaybackSpeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The playback rate. 1 is normal speed, 2 plays twice as fast and 0.5 half
+	// as fast. It must be positive; use PausePlaybackRequest to stop.
+	Speed float64 `protobuf:"fixed64,1,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *SetPlaybackSpeedRequest) Reset() {
+	*x = SetPlaybackSpeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetPlaybackSpeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlaybackSpeedRequest) ProtoMessage() {}
+
+func (x *SetPlaybackSpeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlaybackSpeedRequest.ProtoReflect.Descriptor instead.
+func (*SetPlaybackSpeedRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetPlaybackSpeedRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type SetTempoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The tempo in beats per minute. It must be between 1 and 1000. The
+	// current beat position is kept.
+	Bpm float64 `protobuf:"fixed64,1,opt,name=bpm,proto3" json:"bpm,omitempty"`
+	// If present, the phase of the current beat, between 0 (on the beat) and 1.
+	// Use this to line the beat up with the music.
+	Phase *float64 `protobuf:"fixed64,2,opt,name=phase,proto3,oneof" json:"phase,omitempty"`
+}
+
+func (x *SetTempoRequest) Reset() {
+	*x = SetTempoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTempoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTempoRequest) ProtoMessage() {}
+
+func (x *SetTempoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTempoRequest.ProtoReflect.Descriptor instead.
+func (*SetTempoRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetTempoRequest) GetBpm() float64 {
+	if x != nil {
+		return x.Bpm
+	}
+	return 0
+}
+
+func (x *SetTempoRequest) GetPhase() float64 {
+	if x != nil && x.Phase != nil {
+		return *x.Phase
+	}
+	return 0
+}
+
+type TapTempoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TapTempoRequest) Reset() {
+	*x = TapTempoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapTempoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapTempoRequest) ProtoMessage() {}
+
+func (x *TapTempoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapTempoRequest.ProtoReflect.Descriptor instead.
+func (*TapTempoRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{19}
+}
+
+type GetLayersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLayersRequest) Reset() {
+	*x = GetLayersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLayersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLayersRequest) ProtoMessage() {}
+
+func (x *GetLayersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLayersRequest.ProtoReflect.Descriptor instead.
+func (*GetLayersRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{20}
+}
+
+type GetLayersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The layers, from the bottom to the top.
+	Layers []*Layer `protobuf:"bytes,1,rep,name=layers,proto3" json:"layers,omitempty"`
+}
+
+func (x *GetLayersResponse) Reset() {
+	*x = GetLayersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLayersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLayersResponse) ProtoMessage() {}
+
+func (x *GetLayersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLayersResponse.ProtoReflect.Descriptor instead.
+func (*GetLayersResponse) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetLayersResponse) GetLayers() []*Layer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+type Layer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the layer, which LEDClientMessage.layer refers to.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The opacity of the layer, between 0 and 1.
+	Opacity float64 `protobuf:"fixed64,2,opt,name=opacity,proto3" json:"opacity,omitempty"`
+	// How the layer is blended onto the layers below it.
+	Blend BlendMode `protobuf:"varint,3,opt,name=blend,proto3,enum=christmas.BlendMode" json:"blend,omitempty"`
+	// Whether the layer has a canvas that clients can draw to.
+	Drawable bool `protobuf:"varint,4,opt,name=drawable,proto3" json:"drawable,omitempty"`
+	// Whether this client may target the layer. Clients that authenticated
+	// with a secret that was granted only some layers cannot target the others.
+	Granted bool `protobuf:"varint,5,opt,name=granted,proto3" json:"granted,omitempty"`
+	// The indices of the LEDs that the layer is limited to, in ascending order.
+	// Empty if the layer covers all LEDs. Drawing on the layer's canvas only
+	// changes these LEDs.
+	RegionLeds []uint32 `protobuf:"varint,6,rep,packed,name=region_leds,json=regionLeds,proto3" json:"region_leds,omitempty"`
+}
+
+func (x *Layer) Reset() {
+	*x = Layer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Layer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Layer) ProtoMessage() {}
+
+func (x *Layer) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Layer.ProtoReflect.Descriptor instead.
+func (*Layer) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Layer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Layer) GetOpacity() float64 {
+	if x != nil {
+		return x.Opacity
+	}
+	return 0
+}
+
+func (x *Layer) GetBlend() BlendMode {
+	if x != nil {
+		return x.Blend
+	}
+	return BlendMode_BLEND_MODE_NORMAL
+}
+
+func (x *Layer) GetDrawable() bool {
+	if x != nil {
+		return x.Drawable
+	}
+	return false
+}
+
+func (x *Layer) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+func (x *Layer) GetRegionLeds() []uint32 {
+	if x != nil {
+		return x.RegionLeds
+	}
+	return nil
+}
+
+type SetLayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If present, the new opacity of the layer, between 0 and 1. 0 hides the
+	// layer.
+	Opacity *float64 `protobuf:"fixed64,1,opt,name=opacity,proto3,oneof" json:"opacity,omitempty"`
+	// If present, the new blend mode of the layer.
+	Blend *BlendMode `protobuf:"varint,2,opt,name=blend,proto3,enum=christmas.BlendMode,oneof" json:"blend,omitempty"`
+}
+
+func (x *SetLayerRequest) Reset() {
+	*x = SetLayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLayerRequest) ProtoMessage() {}
+
+func (x *SetLayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLayerRequest.ProtoReflect.Descriptor instead.
+func (*SetLayerRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetLayerRequest) GetOpacity() float64 {
+	if x != nil && x.Opacity != nil {
+		return *x.Opacity
+	}
+	return 0
+}
+
+func (x *SetLayerRequest) GetBlend() BlendMode {
+	if x != nil && x.Blend != nil {
+		return *x.Blend
+	}
+	return BlendMode_BLEND_MODE_NORMAL
+}
+
+type ListEffectsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListEffectsRequest) Reset() {
+	*x = ListEffectsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEffectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEffectsRequest) ProtoMessage() {}
+
+func (x *ListEffectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEffectsRequest.ProtoReflect.Descriptor instead.
+func (*ListEffectsRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{24}
+}
+
+type ListEffectsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The names of the built-in effects, such as "rainbow", and of the effect
+	// scripts of the server, in sorted order.
+	Effects []string `protobuf:"bytes,1,rep,name=effects,proto3" json:"effects,omitempty"`
+}
+
+func (x *ListEffectsResponse) Reset() {
+	*x = ListEffectsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEffectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEffectsResponse) ProtoMessage() {}
+
+func (x *ListEffectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEffectsResponse.ProtoReflect.Descriptor instead.
+func (*ListEffectsResponse) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListEffectsResponse) GetEffects() []string {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+type PlayEffectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the effect, as listed by ListEffectsResponse.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// How long the effect plays for, in milliseconds. The effect must fit in
+	// the frames that the player can hold, which is also the default.
+	DurationMs uint32 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// The time between frames, in milliseconds. Defaults to 50.
+	IntervalMs uint32 `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	// Whether the effect starts over once it ends, until other frames replace
+	// it.
+	Loop bool `protobuf:"varint,4,opt,name=loop,proto3" json:"loop,omitempty"`
+}
+
+func (x *PlayEffectRequest) Reset() {
+	*x = PlayEffectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayEffectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayEffectRequest) ProtoMessage() {}
+
+func (x *PlayEffectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayEffectRequest.ProtoReflect.Descriptor instead.
+func (*PlayEffectRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PlayEffectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayEffectRequest) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *PlayEffectRequest) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *PlayEffectRequest) GetLoop() bool {
+	if x != nil {
+		return x.Loop
+	}
+	return false
+}
+
+type PlayScriptRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Starlark source of the script. It defines a function
+	// led(i, x, y, t) that returns the color of the LED at index i and
+	// normalized position (x, y) at time t in seconds. The server limits how
+	// long the script may run for each frame.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// How long the effect plays for, like in PlayEffectRequest.
+	DurationMs uint32 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// The time between frames, like in PlayEffectRequest.
+	IntervalMs uint32 `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	// Whether the effect starts over once it ends, like in PlayEffectRequest.
+	Loop bool `protobuf:"varint,4,opt,name=loop,proto3" json:"loop,omitempty"`
+}
+
+func (x *PlayScriptRequest) Reset() {
+	*x = PlayScriptRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayScriptRequest) ProtoMessage() {}
+
+func (x *PlayScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayScriptRequest.ProtoReflect.Descriptor instead.
+func (*PlayScriptRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PlayScriptRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PlayScriptRequest) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *PlayScriptRequest) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *PlayScriptRequest) GetLoop() bool {
+	if x != nil {
+		return x.Loop
+	}
+	return false
+}
+
+type UploadAnimationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The animation file, in either the binary or the JSON format of the
+	// ledanim package. It must have as many LEDs as the canvas, and if it has a
+	// position hash, the hash must match the positions of the canvas LEDs. All
+	// of its frames must fit in the player.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UploadAnimationRequest) Reset() {
+	*x = UploadAnimationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_christmas_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAnimationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAnimationRequest) ProtoMessage() {}
+
+func (x *UploadAnimationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_christmas_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAnimationRequest.ProtoReflect.Descriptor instead.
+func (*UploadAnimationRequest) Descriptor() ([]byte, []int) {
+	return file_christmas_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UploadAnimationRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_christmas_proto protoreflect.FileDescriptor
+
+var file_christmas_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x22, 0x8e, 0x0a, 0x0a,
+	0x10, 0x4c, 0x45, 0x44, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x44, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74,
+	0x6d, 0x61, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x67, 0x65, 0x74, 0x5f, 0x6c,
+	0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x67, 0x65, 0x74, 0x4c,
+	0x65, 0x64, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x0e,
+	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73,
+	0x2e, 0x53, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x43, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
+	0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x07, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x73, 0x12, 0x36, 0x0a, 0x08,
+	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x45,
+	0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x74,
+	0x4c, 0x65, 0x64, 0x73, 0x12, 0x4f, 0x0a, 0x11, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x67, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x50,
+	0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0d, 0x70, 0x61, 0x75, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x12,
+	0x4b, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73,
+	0x74, 0x6d, 0x61, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x45, 0x0a, 0x0d,
+	0x73, 0x65, 0x65, 0x6b, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e,
+	0x53, 0x65, 0x65, 0x6b, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x65, 0x65, 0x6b, 0x50, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x12, 0x52, 0x0a, 0x12, 0x73, 0x65, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x50,
+	0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x73, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x65, 0x74, 0x5f, 0x74,
+	0x65, 0x6d, 0x70, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x72,
+	0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x74, 0x54, 0x65, 0x6d,
+	0x70, 0x6f, 0x12, 0x39, 0x0a, 0x09, 0x74, 0x61, 0x70, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61,
+	0x73, 0x2e, 0x54, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x08, 0x74, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x12, 0x3c, 0x0a,
+	0x0a, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x09, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x73,
+	0x65, 0x74, 0x5f, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65,
+	0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63,
+	0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6c,
+	0x69, 0x73, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x70, 0x6c, 0x61, 0x79, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x4e, 0x0a, 0x10,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
+	0x61, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xee, 0x03,
+	0x0a, 0x10, 0x4c, 0x45, 0x44, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73,
+	0x74, 0x6d, 0x61, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x54, 0x0a, 0x13, 0x67, 0x65, 0x74,
+	0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d,
+	0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x10, 0x67,
+	0x65, 0x74, 0x4c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x37, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x73, 0x12, 0x50, 0x0a, 0x11, 0x67, 0x65, 0x74, 0x5f,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x67, 0x65, 0x74, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x67, 0x65,
+	0x74, 0x5f, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61,
+	0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x67, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x0c, 0x6c, 0x69, 0x73,
+	0x74, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x12, 0x19,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2d,
+	0x0a, 0x13, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x30, 0x0a,
+	0x14, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
+	0x10, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x37, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x04, 0x6c, 0x65, 0x64, 0x73, 0x22, 0x36, 0x0a, 0x0e, 0x53, 0x65,
+	0x74, 0x4c, 0x45, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x04,
+	0x6c, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x68, 0x72,
+	0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x04, 0x6c, 0x65,
+	0x64, 0x73, 0x22, 0x19, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x67, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x03, 0x72, 0x67, 0x62, 0x22, 0x19, 0x0a,
+	0x17, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4c,
+	0x45, 0x44, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0x44, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x4c, 0x45, 0x44, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x69, 0x78,
+	0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68, 0x72, 0x69,
+	0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x52, 0x47, 0x42, 0x41, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x52, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x22, 0x24, 0x0a, 0x0a, 0x52, 0x47, 0x42, 0x41,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x22, 0x18,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x90, 0x02, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x5f, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52,
+	0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x88, 0x01, 0x01, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6f, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x6c, 0x6f, 0x6f, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x70, 0x6d,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x70, 0x6d, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x16, 0x0a, 0x14, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x6c, 0x61,
+	0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x13,
+	0x53, 0x65, 0x65, 0x6b, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0x2f, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x48, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x70, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x70, 0x6d, 0x12, 0x19, 0x0a, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x70, 0x68, 0x61, 0x73, 0x65, 0x22,
+	0x11, 0x0a, 0x0f, 0x54, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x68,
+	0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x05, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6f, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x0a,
+	0x05, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63,
+	0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x05, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61,
+	0x77, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61,
+	0x77, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x64, 0x73,
+	0x22, 0x77, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x88,
+	0x01, 0x01, 0x12, 0x2f, 0x0a, 0x05, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x2e, 0x42, 0x6c,
+	0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x48, 0x01, 0x52, 0x05, 0x62, 0x6c, 0x65, 0x6e, 0x64,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x42,
+	0x08, 0x0a, 0x06, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73,
+	0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73,
+	0x22, 0x7d, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x79, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x6f, 0x6f, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x22,
+	0x81, 0x01, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x79, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c,
+	0x6f, 0x6f, 0x70, 0x22, 0x2c, 0x0a, 0x16, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x69,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x2a, 0x66, 0x0a, 0x09, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x15,
+	0x0a, 0x11, 0x42, 0x4c, 0x45, 0x4e, 0x44, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x52,
+	0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x4c, 0x45, 0x4e, 0x44, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x4c, 0x45,
+	0x4e, 0x44, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x59,
+	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x4c, 0x45, 0x4e, 0x44, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x03, 0x42, 0x35, 0x5a, 0x33, 0x6c, 0x69, 0x62,
+	0x64, 0x62, 0x2e, 0x73, 0x6f, 0x2f, 0x61, 0x63, 0x6d, 0x2d, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74,
+	0x6d, 0x61, 0x73, 0x2f, 0x6c, 0x69, 0x62, 0x2f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61,
+	0x73, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x68, 0x72, 0x69, 0x73, 0x74, 0x6d, 0x61, 0x73, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_christmas_proto_rawDescOnce sync.Once
+	file_christmas_proto_rawDescData = file_christmas_proto_rawDesc
+)
+
+func file_christmas_proto_rawDescGZIP() []byte {
+	file_christmas_proto_rawDescOnce.Do(func() {
+		file_christmas_proto_rawDescData = protoimpl.X.CompressGZIP(file_christmas_proto_rawDescData)
+	})
+	return file_christmas_proto_rawDescData
+}
+
+var file_christmas_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_christmas_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_christmas_proto_goTypes = []interface{}{
+	(BlendMode)(0),                   // 0: christmas.BlendMode
+	(*LEDClientMessage)(nil),         // 1: christmas.LEDClientMessage
+	(*LEDServerMessage)(nil),         // 2: christmas.LEDServerMessage
+	(*AuthenticateRequest)(nil),      // 3: christmas.AuthenticateRequest
+	(*AuthenticateResponse)(nil),     // 4: christmas.AuthenticateResponse
+	(*GetLEDsRequest)(nil),           // 5: christmas.GetLEDsRequest
+	(*GetLEDsResponse)(nil),          // 6: christmas.GetLEDsResponse
+	(*SetLEDsRequest)(nil),           // 7: christmas.SetLEDsRequest
+	(*Color)(nil),                    // 8: christmas.Color
+	(*GetLEDCanvasInfoRequest)(nil),  // 9: christmas.GetLEDCanvasInfoRequest
+	(*GetLEDCanvasInfoResponse)(nil), // 10: christmas.GetLEDCanvasInfoResponse
+	(*SetLEDCanvasRequest)(nil),      // 11: christmas.SetLEDCanvasRequest
+	(*RGBAPixels)(nil),               // 12: christmas.RGBAPixels
+	(*GetPlayerStatusRequest)(nil),   // 13: christmas.GetPlayerStatusRequest
+	(*GetPlayerStatusResponse)(nil),  // 14: christmas.GetPlayerStatusResponse
+	(*PausePlaybackRequest)(nil),     // 15: christmas.PausePlaybackRequest
+	(*ResumePlaybackRequest)(nil),    // 16: christmas.ResumePlaybackRequest
+	(*SeekPlaybackRequest)(nil),      // 17: christmas.SeekPlaybackRequest
+	(*SetPlaybackSpeedRequest)(nil),  // 18: christmas.SetPlaybackSpeedRequest
+	(*SetTempoRequest)(nil),          // 19: christmas.SetTempoRequest
+	(*TapTempoRequest)(nil),          // 20: christmas.TapTempoRequest
+	(*GetLayersRequest)(nil),         // 21: christmas.GetLayersRequest
+	(*GetLayersResponse)(nil),        // 22: christmas.GetLayersResponse
+	(*Layer)(nil),                    // 23: christmas.Layer
+	(*SetLayerRequest)(nil),          // 24: christmas.SetLayerRequest
+	(*ListEffectsRequest)(nil),       // 25: christmas.ListEffectsRequest
+	(*ListEffectsResponse)(nil),      // 26: christmas.ListEffectsResponse
+	(*PlayEffectRequest)(nil),        // 27: christmas.PlayEffectRequest
+	(*PlayScriptRequest)(nil),        // 28: christmas.PlayScriptRequest
+	(*UploadAnimationRequest)(nil),   // 29: christmas.UploadAnimationRequest
+}
+var file_christmas_proto_depIdxs = []int32{
+	3,  // 0: christmas.LEDClientMessage.authenticate:type_name -> christmas.AuthenticateRequest
+	9,  // 1: christmas.LEDClientMessage.get_led_canvas_info:type_name -> christmas.GetLEDCanvasInfoRequest
+	11, // 2: christmas.LEDClientMessage.set_led_canvas:type_name -> christmas.SetLEDCanvasRequest
+	5,  // 3: christmas.LEDClientMessage.get_leds:type_name -> christmas.GetLEDsRequest
+	7,  // 4: christmas.LEDClientMessage.set_leds:type_name -> christmas.SetLEDsRequest
+	13, // 5: christmas.LEDClientMessage.get_player_status:type_name -> christmas.GetPlayerStatusRequest
+	15, // 6: christmas.LEDClientMessage.pause_playback:type_name -> christmas.PausePlaybackRequest
+	16, // 7: christmas.LEDClientMessage.resume_playback:type_name -> christmas.ResumePlaybackRequest
+	17, // 8: christmas.LEDClientMessage.seek_playback:type_name -> christmas.SeekPlaybackRequest
+	18, // 9: christmas.LEDClientMessage.set_playback_speed:type_name -> christmas.SetPlaybackSpeedRequest
+	19, // 10: christmas.LEDClientMessage.set_tempo:type_name -> christmas.SetTempoRequest
+	20, // 11: christmas.LEDClientMessage.tap_tempo:type_name -> christmas.TapTempoRequest
+	21, // 12: christmas.LEDClientMessage.get_layers:type_name -> christmas.GetLayersRequest
+	24, // 13: christmas.LEDClientMessage.set_layer:type_name -> christmas.SetLayerRequest
+	25, // 14: christmas.LEDClientMessage.list_effects:type_name -> christmas.ListEffectsRequest
+	27, // 15: christmas.LEDClientMessage.play_effect:type_name -> christmas.PlayEffectRequest
+	28, // 16: christmas.LEDClientMessage.play_script:type_name -> christmas.PlayScriptRequest
+	29, // 17: christmas.LEDClientMessage.upload_animation:type_name -> christmas.UploadAnimationRequest
+	4,  // 18: christmas.LEDServerMessage.authenticate:type_name -> christmas.AuthenticateResponse
+	10, // 19: christmas.LEDServerMessage.get_led_canvas_info:type_name -> christmas.GetLEDCanvasInfoResponse
+	6,  // 20: christmas.LEDServerMessage.get_leds:type_name -> christmas.GetLEDsResponse
+	14, // 21: christmas.LEDServerMessage.get_player_status:type_name -> christmas.GetPlayerStatusResponse
+	22, // 22: christmas.LEDServerMessage.get_layers:type_name -> christmas.GetLayersResponse
+	26, // 23: christmas.LEDServerMessage.list_effects:type_name -> christmas.ListEffectsResponse
+	8,  // 24: christmas.GetLEDsResponse.leds:type_name -> christmas.Color
+	8,  // 25: christmas.SetLEDsRequest.leds:type_name -> christmas.Color
+	12, // 26: christmas.SetLEDCanvasRequest.pixels:type_name -> christmas.RGBAPixels
+	23, // 27: christmas.GetLayersResponse.layers:type_name -> christmas.Layer
+	0,  // 28: christmas.Layer.blend:type_name -> christmas.BlendMode
+	0,  // 29: christmas.SetLayerRequest.blend:type_name -> christmas.BlendMode
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_christmas_proto_init() }
+func file_christmas_proto_init() {
+	if File_christmas_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_christmas_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LEDClientMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LEDServerMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticateResponse); i {
+			case 0:
+				return &v.state
 			case 1:
 				return &v.sizeCache
 			case 2:
@@ -1013,6 +2484,210 @@ func file_christmas_proto_init() {
 				return nil
 			}
 		}
+		file_christmas_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPlayerStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPlayerStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PausePlaybackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumePlaybackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeekPlaybackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetPlaybackSpeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTempoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapTempoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLayersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLayersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Layer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListEffectsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListEffectsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayEffectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayScriptRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_christmas_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAnimationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_christmas_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*LEDClientMessage_Authenticate)(nil),
@@ -1020,24 +2695,44 @@ func file_christmas_proto_init() {
 		(*LEDClientMessage_SetLedCanvas)(nil),
 		(*LEDClientMessage_GetLeds)(nil),
 		(*LEDClientMessage_SetLeds)(nil),
+		(*LEDClientMessage_GetPlayerStatus)(nil),
+		(*LEDClientMessage_PausePlayback)(nil),
+		(*LEDClientMessage_ResumePlayback)(nil),
+		(*LEDClientMessage_SeekPlayback)(nil),
+		(*LEDClientMessage_SetPlaybackSpeed)(nil),
+		(*LEDClientMessage_SetTempo)(nil),
+		(*LEDClientMessage_TapTempo)(nil),
+		(*LEDClientMessage_GetLayers)(nil),
+		(*LEDClientMessage_SetLayer)(nil),
+		(*LEDClientMessage_ListEffects)(nil),
+		(*LEDClientMessage_PlayEffect)(nil),
+		(*LEDClientMessage_PlayScript)(nil),
+		(*LEDClientMessage_UploadAnimation)(nil),
 	}
 	file_christmas_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*LEDServerMessage_Authenticate)(nil),
 		(*LEDServerMessage_GetLedCanvasInfo)(nil),
 		(*LEDServerMessage_GetLeds)(nil),
+		(*LEDServerMessage_GetPlayerStatus)(nil),
+		(*LEDServerMessage_GetLayers)(nil),
+		(*LEDServerMessage_ListEffects)(nil),
 	}
+	file_christmas_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_christmas_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_christmas_proto_msgTypes[23].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_christmas_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_christmas_proto_goTypes,
 		DependencyIndexes: file_christmas_proto_depIdxs,
+		EnumInfos:         file_christmas_proto_enumTypes,
 		MessageInfos:      file_christmas_proto_msgTypes,
 	}.Build()
 	File_christmas_proto = out.File
@@ -27,6 +27,57 @@ export interface LEDClientMessage {
      * followed by AddFrames with a single frame.
      */
     setLeds?: SetLEDsRequest | undefined;
+    /**
+     * Get the state of the animation player. Sends back a
+     * GetPlayerStatusResponse.
+     */
+    getPlayerStatus?: GetPlayerStatusRequest | undefined;
+    /**
+     * Pause playback. The shown frame stays on, and frames can still be
+     * added.
+     */
+    pausePlayback?: PausePlaybackRequest | undefined;
+    /** Resume paused playback. */
+    resumePlayback?: ResumePlaybackRequest | undefined;
+    /** Show the given frame right away and continue playing from there. */
+    seekPlayback?: SeekPlaybackRequest | undefined;
+    /** Set the playback rate. */
+    setPlaybackSpeed?: SetPlaybackSpeedRequest | undefined;
+    /** Set the tempo that frames timed in beats follow. */
+    setTempo?: SetTempoRequest | undefined;
+    /**
+     * Tap the tempo. Each tap lands on a beat; taps in quick succession set
+     * the tempo to their average interval.
+     */
+    tapTempo?: TapTempoRequest | undefined;
+    /**
+     * List the layers that the server combines into the LEDs. Sends back a
+     * GetLayersResponse.
+     */
+    getLayers?: GetLayersRequest | undefined;
+    /** Change the opacity or blend mode of the layer named by layer. */
+    setLayer?: SetLayerRequest | undefined;
+    /**
+     * List the built-in effects and the effect scripts of the server. Sends
+     * back a ListEffectsResponse.
+     */
+    listEffects?: ListEffectsRequest | undefined;
+    /**
+     * Replace the frames of the canvas with a built-in effect or an effect
+     * script of the server.
+     */
+    playEffect?: PlayEffectRequest | undefined;
+    /** Replace the frames of the canvas with an effect script. */
+    playScript?: PlayScriptRequest | undefined;
+    /** Replace the frames of the canvas with those of an animation file. */
+    uploadAnimation?: UploadAnimationRequest | undefined;
+    /**
+     * The layer that this message targets. The canvas, LED and playback APIs
+     * act on the canvas of this layer. Empty targets the default canvas. If the
+     * server has no such layer, or the client is not allowed to control it, the
+     * connection is closed with an error.
+     */
+    layer: string;
 }
 export interface LEDServerMessage {
     /** Response to AuthenticateRequest. */
@@ -35,6 +86,17 @@ export interface LEDServerMessage {
     getLedCanvasInfo?: GetLEDCanvasInfoResponse | undefined;
     /** Response to GetLEDsRequest. */
     getLeds?: GetLEDsResponse | undefined;
+    /** Response to GetPlayerStatusRequest. */
+    getPlayerStatus?: GetPlayerStatusResponse | undefined;
+    /** Response to GetLayersRequest. */
+    getLayers?: GetLayersResponse | undefined;
+    /** Response to ListEffectsRequest. */
+    listEffects?: ListEffectsResponse | undefined;
+    /**
+     * If present, the server encountered an error. This is a string describing
+     * the error.
+     */
+    error?: string | undefined;
 }
 export interface AuthenticateRequest {
     /**
@@ -56,7 +118,7 @@ export interface GetLEDsResponse {
 export interface SetLEDsRequest {
     /**
      * A 1D array of colors. The number of colors must match the number of LEDs.
-     * To get the number of LEDs, call GetLEDs.
+     * To get the number of LEDs, take the length of GetLEDsResponse.
      */
     leds: Color[];
 }
@@ -86,6 +148,170 @@ export interface RGBAPixels {
      */
     pixels: Uint8Array;
 }
+export interface GetPlayerStatusRequest {
+}
+export interface GetPlayerStatusResponse {
+    /** The number of frames that have been added but not shown yet. */
+    queuedFrames: number;
+    /** The maximum number of frames that the player can hold. */
+    maxFrames: number;
+    /**
+     * The index of the frame that is currently shown. Frames are indexed in the
+     * order that they were added, starting from 0. Absent if no frame is shown.
+     */
+    frameIndex?: number | undefined;
+    /**
+     * How long it takes until all queued frames have been shown once, in
+     * milliseconds. Loops are not accounted for.
+     */
+    remainingMs: number;
+    /**
+     * Whether the queued frames will loop, in which case playing them takes
+     * longer than remaining_ms, possibly forever.
+     */
+    looping: boolean;
+    /** Whether playback is paused. */
+    paused: boolean;
+    /** The playback rate. 1 is normal speed. */
+    speed: number;
+    /** The tempo that frames timed in beats follow, in beats per minute. */
+    bpm: number;
+}
+export interface PausePlaybackRequest {
+}
+export interface ResumePlaybackRequest {
+}
+export interface SeekPlaybackRequest {
+    /**
+     * The index of the frame to seek to, as in
+     * GetPlayerStatusResponse.frame_index. The frame must still be held by the
+     * player, otherwise an error is sent back.
+     */
+    frameIndex: number;
+}
+export interface SetPlaybackSpeedRequest {
+    /**
+     * The playback rate. 1 is normal speed, 2 plays twice as fast and 0.5 half
+     * as fast. It must be positive; use PausePlaybackRequest to stop.
+     */
+    speed: number;
+}
+export interface SetTempoRequest {
+    /**
+     * The tempo in beats per minute. It must be between 1 and 1000. The
+     * current beat position is kept.
+     */
+    bpm: number;
+    /**
+     * If present, the phase of the current beat, between 0 (on the beat) and 1.
+     * Use this to line the beat up with the music.
+     */
+    phase?: number | undefined;
+}
+export interface TapTempoRequest {
+}
+/** BlendMode is how a layer is blended onto the layers below it. */
+export declare enum BlendMode {
+    /** BLEND_MODE_NORMAL - Paint the layer over the layers below it. */
+    BLEND_MODE_NORMAL = 0,
+    /** BLEND_MODE_ADD - Add the colors together, which brightens them. */
+    BLEND_MODE_ADD = 1,
+    /**
+     * BLEND_MODE_MULTIPLY - Multiply the colors, which darkens them. White keeps the colors below and
+     * black turns them off.
+     */
+    BLEND_MODE_MULTIPLY = 2,
+    /** BLEND_MODE_SCREEN - Brighten the colors without clipping as harshly as BLEND_MODE_ADD. */
+    BLEND_MODE_SCREEN = 3,
+    UNRECOGNIZED = -1
+}
+export declare function blendModeFromJSON(object: any): BlendMode;
+export declare function blendModeToJSON(object: BlendMode): string;
+export interface GetLayersRequest {
+}
+export interface GetLayersResponse {
+    /** The layers, from the bottom to the top. */
+    layers: Layer[];
+}
+export interface Layer {
+    /** The name of the layer, which LEDClientMessage.layer refers to. */
+    name: string;
+    /** The opacity of the layer, between 0 and 1. */
+    opacity: number;
+    /** How the layer is blended onto the layers below it. */
+    blend: BlendMode;
+    /** Whether the layer has a canvas that clients can draw to. */
+    drawable: boolean;
+    /**
+     * Whether this client may target the layer. Clients that authenticated
+     * with a secret that was granted only some layers cannot target the others.
+     */
+    granted: boolean;
+    /**
+     * The indices of the LEDs that the layer is limited to, in ascending order.
+     * Empty if the layer covers all LEDs. Drawing on the layer's canvas only
+     * changes these LEDs.
+     */
+    regionLeds: number[];
+}
+export interface SetLayerRequest {
+    /**
+     * If present, the new opacity of the layer, between 0 and 1. 0 hides the
+     * layer.
+     */
+    opacity?: number | undefined;
+    /** If present, the new blend mode of the layer. */
+    blend?: BlendMode | undefined;
+}
+export interface ListEffectsRequest {
+}
+export interface ListEffectsResponse {
+    /**
+     * The names of the built-in effects, such as "rainbow", and of the effect
+     * scripts of the server, in sorted order.
+     */
+    effects: string[];
+}
+export interface PlayEffectRequest {
+    /** The name of the effect, as listed by ListEffectsResponse. */
+    name: string;
+    /**
+     * How long the effect plays for, in milliseconds. The effect must fit in
+     * the frames that the player can hold, which is also the default.
+     */
+    durationMs: number;
+    /** The time between frames, in milliseconds. Defaults to 50. */
+    intervalMs: number;
+    /**
+     * Whether the effect starts over once it ends, until other frames replace
+     * it.
+     */
+    loop: boolean;
+}
+export interface PlayScriptRequest {
+    /**
+     * The Starlark source of the script. It defines a function
+     * led(i, x, y, t) that returns the color of the LED at index i and
+     * normalized position (x, y) at time t in seconds. The server limits how
+     * long the script may run for each frame.
+     */
+    source: string;
+    /** How long the effect plays for, like in PlayEffectRequest. */
+    durationMs: number;
+    /** The time between frames, like in PlayEffectRequest. */
+    intervalMs: number;
+    /** Whether the effect starts over once it ends, like in PlayEffectRequest. */
+    loop: boolean;
+}
+export interface UploadAnimationRequest {
+    /**
+     * The animation file, in either the binary or the JSON format of the
+     * ledanim package. It must have as many LEDs as the canvas, and if it has a
+     * position hash, the hash must match the positions of the canvas LEDs. All
+     * of its frames must fit in the player.
+     */
+    data: Uint8Array;
+}
 export declare const LEDClientMessage: {
     encode(message: LEDClientMessage, writer?: _m0.Writer): _m0.Writer;
     decode(input: _m0.Reader | Uint8Array, length?: number): LEDClientMessage;
@@ -107,6 +333,42 @@ export declare const LEDClientMessage: {
                 rgb?: number | undefined;
             }[] | undefined;
         } | undefined;
+        getPlayerStatus?: {} | undefined;
+        pausePlayback?: {} | undefined;
+        resumePlayback?: {} | undefined;
+        seekPlayback?: {
+            frameIndex?: number | undefined;
+        } | undefined;
+        setPlaybackSpeed?: {
+            speed?: number | undefined;
+        } | undefined;
+        setTempo?: {
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } | undefined;
+        tapTempo?: {} | undefined;
+        getLayers?: {} | undefined;
+        setLayer?: {
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } | undefined;
+        listEffects?: {} | undefined;
+        playEffect?: {
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } | undefined;
+        playScript?: {
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } | undefined;
+        uploadAnimation?: {
+            data?: Uint8Array | undefined;
+        } | undefined;
+        layer?: string | undefined;
     } & {
         authenticate?: ({
             secret?: string | undefined;
@@ -141,7 +403,65 @@ export declare const LEDClientMessage: {
                 rgb?: number | undefined;
             }[]>]: never; }) | undefined;
         } & { [K_7 in Exclude<keyof I["setLeds"], "leds">]: never; }) | undefined;
-    } & { [K_8 in Exclude<keyof I, keyof LEDClientMessage>]: never; }>(base?: I | undefined): LEDClientMessage;
+        getPlayerStatus?: ({} & {} & { [K_8 in Exclude<keyof I["getPlayerStatus"], never>]: never; }) | undefined;
+        pausePlayback?: ({} & {} & { [K_9 in Exclude<keyof I["pausePlayback"], never>]: never; }) | undefined;
+        resumePlayback?: ({} & {} & { [K_10 in Exclude<keyof I["resumePlayback"], never>]: never; }) | undefined;
+        seekPlayback?: ({
+            frameIndex?: number | undefined;
+        } & {
+            frameIndex?: number | undefined;
+        } & { [K_11 in Exclude<keyof I["seekPlayback"], "frameIndex">]: never; }) | undefined;
+        setPlaybackSpeed?: ({
+            speed?: number | undefined;
+        } & {
+            speed?: number | undefined;
+        } & { [K_12 in Exclude<keyof I["setPlaybackSpeed"], "speed">]: never; }) | undefined;
+        setTempo?: ({
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } & {
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } & { [K_13 in Exclude<keyof I["setTempo"], keyof SetTempoRequest>]: never; }) | undefined;
+        tapTempo?: ({} & {} & { [K_14 in Exclude<keyof I["tapTempo"], never>]: never; }) | undefined;
+        getLayers?: ({} & {} & { [K_15 in Exclude<keyof I["getLayers"], never>]: never; }) | undefined;
+        setLayer?: ({
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } & {
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } & { [K_16 in Exclude<keyof I["setLayer"], keyof SetLayerRequest>]: never; }) | undefined;
+        listEffects?: ({} & {} & { [K_17 in Exclude<keyof I["listEffects"], never>]: never; }) | undefined;
+        playEffect?: ({
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & {
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & { [K_18 in Exclude<keyof I["playEffect"], keyof PlayEffectRequest>]: never; }) | undefined;
+        playScript?: ({
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & {
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & { [K_19 in Exclude<keyof I["playScript"], keyof PlayScriptRequest>]: never; }) | undefined;
+        uploadAnimation?: ({
+            data?: Uint8Array | undefined;
+        } & {
+            data?: Uint8Array | undefined;
+        } & { [K_20 in Exclude<keyof I["uploadAnimation"], "data">]: never; }) | undefined;
+        layer?: string | undefined;
+    } & { [K_21 in Exclude<keyof I, keyof LEDClientMessage>]: never; }>(base?: I | undefined): LEDClientMessage;
     fromPartial<I_1 extends {
         authenticate?: {
             secret?: string | undefined;
@@ -158,13 +478,49 @@ export declare const LEDClientMessage: {
                 rgb?: number | undefined;
             }[] | undefined;
         } | undefined;
+        getPlayerStatus?: {} | undefined;
+        pausePlayback?: {} | undefined;
+        resumePlayback?: {} | undefined;
+        seekPlayback?: {
+            frameIndex?: number | undefined;
+        } | undefined;
+        setPlaybackSpeed?: {
+            speed?: number | undefined;
+        } | undefined;
+        setTempo?: {
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } | undefined;
+        tapTempo?: {} | undefined;
+        getLayers?: {} | undefined;
+        setLayer?: {
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } | undefined;
+        listEffects?: {} | undefined;
+        playEffect?: {
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } | undefined;
+        playScript?: {
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } | undefined;
+        uploadAnimation?: {
+            data?: Uint8Array | undefined;
+        } | undefined;
+        layer?: string | undefined;
     } & {
         authenticate?: ({
             secret?: string | undefined;
         } & {
             secret?: string | undefined;
-        } & { [K_9 in Exclude<keyof I_1["authenticate"], "secret">]: never; }) | undefined;
-        getLedCanvasInfo?: ({} & {} & { [K_10 in Exclude<keyof I_1["getLedCanvasInfo"], never>]: never; }) | undefined;
+        } & { [K_22 in Exclude<keyof I_1["authenticate"], "secret">]: never; }) | undefined;
+        getLedCanvasInfo?: ({} & {} & { [K_23 in Exclude<keyof I_1["getLedCanvasInfo"], never>]: never; }) | undefined;
         setLedCanvas?: ({
             pixels?: {
                 pixels?: Uint8Array | undefined;
@@ -174,9 +530,9 @@ export declare const LEDClientMessage: {
                 pixels?: Uint8Array | undefined;
             } & {
                 pixels?: Uint8Array | undefined;
-            } & { [K_11 in Exclude<keyof I_1["setLedCanvas"]["pixels"], "pixels">]: never; }) | undefined;
-        } & { [K_12 in Exclude<keyof I_1["setLedCanvas"], "pixels">]: never; }) | undefined;
-        getLeds?: ({} & {} & { [K_13 in Exclude<keyof I_1["getLeds"], never>]: never; }) | undefined;
+            } & { [K_24 in Exclude<keyof I_1["setLedCanvas"]["pixels"], "pixels">]: never; }) | undefined;
+        } & { [K_25 in Exclude<keyof I_1["setLedCanvas"], "pixels">]: never; }) | undefined;
+        getLeds?: ({} & {} & { [K_26 in Exclude<keyof I_1["getLeds"], never>]: never; }) | undefined;
         setLeds?: ({
             leds?: {
                 rgb?: number | undefined;
@@ -188,11 +544,69 @@ export declare const LEDClientMessage: {
                 rgb?: number | undefined;
             } & {
                 rgb?: number | undefined;
-            } & { [K_14 in Exclude<keyof I_1["setLeds"]["leds"][number], "rgb">]: never; })[] & { [K_15 in Exclude<keyof I_1["setLeds"]["leds"], keyof {
+            } & { [K_27 in Exclude<keyof I_1["setLeds"]["leds"][number], "rgb">]: never; })[] & { [K_28 in Exclude<keyof I_1["setLeds"]["leds"], keyof {
                 rgb?: number | undefined;
             }[]>]: never; }) | undefined;
-        } & { [K_16 in Exclude<keyof I_1["setLeds"], "leds">]: never; }) | undefined;
-    } & { [K_17 in Exclude<keyof I_1, keyof LEDClientMessage>]: never; }>(object: I_1): LEDClientMessage;
+        } & { [K_29 in Exclude<keyof I_1["setLeds"], "leds">]: never; }) | undefined;
+        getPlayerStatus?: ({} & {} & { [K_30 in Exclude<keyof I_1["getPlayerStatus"], never>]: never; }) | undefined;
+        pausePlayback?: ({} & {} & { [K_31 in Exclude<keyof I_1["pausePlayback"], never>]: never; }) | undefined;
+        resumePlayback?: ({} & {} & { [K_32 in Exclude<keyof I_1["resumePlayback"], never>]: never; }) | undefined;
+        seekPlayback?: ({
+            frameIndex?: number | undefined;
+        } & {
+            frameIndex?: number | undefined;
+        } & { [K_33 in Exclude<keyof I_1["seekPlayback"], "frameIndex">]: never; }) | undefined;
+        setPlaybackSpeed?: ({
+            speed?: number | undefined;
+        } & {
+            speed?: number | undefined;
+        } & { [K_34 in Exclude<keyof I_1["setPlaybackSpeed"], "speed">]: never; }) | undefined;
+        setTempo?: ({
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } & {
+            bpm?: number | undefined;
+            phase?: number | undefined;
+        } & { [K_35 in Exclude<keyof I_1["setTempo"], keyof SetTempoRequest>]: never; }) | undefined;
+        tapTempo?: ({} & {} & { [K_36 in Exclude<keyof I_1["tapTempo"], never>]: never; }) | undefined;
+        getLayers?: ({} & {} & { [K_37 in Exclude<keyof I_1["getLayers"], never>]: never; }) | undefined;
+        setLayer?: ({
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } & {
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+        } & { [K_38 in Exclude<keyof I_1["setLayer"], keyof SetLayerRequest>]: never; }) | undefined;
+        listEffects?: ({} & {} & { [K_39 in Exclude<keyof I_1["listEffects"], never>]: never; }) | undefined;
+        playEffect?: ({
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & {
+            name?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & { [K_40 in Exclude<keyof I_1["playEffect"], keyof PlayEffectRequest>]: never; }) | undefined;
+        playScript?: ({
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & {
+            source?: string | undefined;
+            durationMs?: number | undefined;
+            intervalMs?: number | undefined;
+            loop?: boolean | undefined;
+        } & { [K_41 in Exclude<keyof I_1["playScript"], keyof PlayScriptRequest>]: never; }) | undefined;
+        uploadAnimation?: ({
+            data?: Uint8Array | undefined;
+        } & {
+            data?: Uint8Array | undefined;
+        } & { [K_42 in Exclude<keyof I_1["uploadAnimation"], "data">]: never; }) | undefined;
+        layer?: string | undefined;
+    } & { [K_43 in Exclude<keyof I_1, keyof LEDClientMessage>]: never; }>(object: I_1): LEDClientMessage;
 };
 export declare const LEDServerMessage: {
     encode(message: LEDServerMessage, writer?: _m0.Writer): _m0.Writer;
@@ -212,6 +626,30 @@ export declare const LEDServerMessage: {
                 rgb?: number | undefined;
             }[] | undefined;
         } | undefined;
+        getPlayerStatus?: {
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } | undefined;
+        getLayers?: {
+            layers?: {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] | undefined;
+        } | undefined;
+        listEffects?: {
+            effects?: string[] | undefined;
+        } | undefined;
+        error?: string | undefined;
     } & {
         authenticate?: ({
             success?: boolean | undefined;
@@ -240,7 +678,72 @@ export declare const LEDServerMessage: {
                 rgb?: number | undefined;
             }[]>]: never; }) | undefined;
         } & { [K_4 in Exclude<keyof I["getLeds"], "leds">]: never; }) | undefined;
-    } & { [K_5 in Exclude<keyof I, keyof LEDServerMessage>]: never; }>(base?: I | undefined): LEDServerMessage;
+        getPlayerStatus?: ({
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } & {
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } & { [K_5 in Exclude<keyof I["getPlayerStatus"], keyof GetPlayerStatusResponse>]: never; }) | undefined;
+        getLayers?: ({
+            layers?: {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] | undefined;
+        } & {
+            layers?: ({
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] & ({
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            } & {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: (number[] & number[] & { [K_6 in Exclude<keyof I["getLayers"]["layers"][number]["regionLeds"], keyof number[]>]: never; }) | undefined;
+            } & { [K_7 in Exclude<keyof I["getLayers"]["layers"][number], keyof Layer>]: never; })[] & { [K_8 in Exclude<keyof I["getLayers"]["layers"], keyof {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[]>]: never; }) | undefined;
+        } & { [K_9 in Exclude<keyof I["getLayers"], "layers">]: never; }) | undefined;
+        listEffects?: ({
+            effects?: string[] | undefined;
+        } & {
+            effects?: (string[] & string[] & { [K_10 in Exclude<keyof I["listEffects"]["effects"], keyof string[]>]: never; }) | undefined;
+        } & { [K_11 in Exclude<keyof I["listEffects"], "effects">]: never; }) | undefined;
+        error?: string | undefined;
+    } & { [K_12 in Exclude<keyof I, keyof LEDServerMessage>]: never; }>(base?: I | undefined): LEDServerMessage;
     fromPartial<I_1 extends {
         authenticate?: {
             success?: boolean | undefined;
@@ -254,19 +757,43 @@ export declare const LEDServerMessage: {
                 rgb?: number | undefined;
             }[] | undefined;
         } | undefined;
+        getPlayerStatus?: {
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } | undefined;
+        getLayers?: {
+            layers?: {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] | undefined;
+        } | undefined;
+        listEffects?: {
+            effects?: string[] | undefined;
+        } | undefined;
+        error?: string | undefined;
     } & {
         authenticate?: ({
             success?: boolean | undefined;
         } & {
             success?: boolean | undefined;
-        } & { [K_6 in Exclude<keyof I_1["authenticate"], "success">]: never; }) | undefined;
+        } & { [K_13 in Exclude<keyof I_1["authenticate"], "success">]: never; }) | undefined;
         getLedCanvasInfo?: ({
             width?: number | undefined;
             height?: number | undefined;
         } & {
             width?: number | undefined;
             height?: number | undefined;
-        } & { [K_7 in Exclude<keyof I_1["getLedCanvasInfo"], keyof GetLEDCanvasInfoResponse>]: never; }) | undefined;
+        } & { [K_14 in Exclude<keyof I_1["getLedCanvasInfo"], keyof GetLEDCanvasInfoResponse>]: never; }) | undefined;
         getLeds?: ({
             leds?: {
                 rgb?: number | undefined;
@@ -278,11 +805,76 @@ export declare const LEDServerMessage: {
                 rgb?: number | undefined;
             } & {
                 rgb?: number | undefined;
-            } & { [K_8 in Exclude<keyof I_1["getLeds"]["leds"][number], "rgb">]: never; })[] & { [K_9 in Exclude<keyof I_1["getLeds"]["leds"], keyof {
+            } & { [K_15 in Exclude<keyof I_1["getLeds"]["leds"][number], "rgb">]: never; })[] & { [K_16 in Exclude<keyof I_1["getLeds"]["leds"], keyof {
                 rgb?: number | undefined;
             }[]>]: never; }) | undefined;
-        } & { [K_10 in Exclude<keyof I_1["getLeds"], "leds">]: never; }) | undefined;
-    } & { [K_11 in Exclude<keyof I_1, keyof LEDServerMessage>]: never; }>(object: I_1): LEDServerMessage;
+        } & { [K_17 in Exclude<keyof I_1["getLeds"], "leds">]: never; }) | undefined;
+        getPlayerStatus?: ({
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } & {
+            queuedFrames?: number | undefined;
+            maxFrames?: number | undefined;
+            frameIndex?: number | undefined;
+            remainingMs?: number | undefined;
+            looping?: boolean | undefined;
+            paused?: boolean | undefined;
+            speed?: number | undefined;
+            bpm?: number | undefined;
+        } & { [K_18 in Exclude<keyof I_1["getPlayerStatus"], keyof GetPlayerStatusResponse>]: never; }) | undefined;
+        getLayers?: ({
+            layers?: {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] | undefined;
+        } & {
+            layers?: ({
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[] & ({
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            } & {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: (number[] & number[] & { [K_19 in Exclude<keyof I_1["getLayers"]["layers"][number]["regionLeds"], keyof number[]>]: never; }) | undefined;
+            } & { [K_20 in Exclude<keyof I_1["getLayers"]["layers"][number], keyof Layer>]: never; })[] & { [K_21 in Exclude<keyof I_1["getLayers"]["layers"], keyof {
+                name?: string | undefined;
+                opacity?: number | undefined;
+                blend?: BlendMode | undefined;
+                drawable?: boolean | undefined;
+                granted?: boolean | undefined;
+                regionLeds?: number[] | undefined;
+            }[]>]: never; }) | undefined;
+        } & { [K_22 in Exclude<keyof I_1["getLayers"], "layers">]: never; }) | undefined;
+        listEffects?: ({
+            effects?: string[] | undefined;
+        } & {
+            effects?: (string[] & string[] & { [K_23 in Exclude<keyof I_1["listEffects"]["effects"], keyof string[]>]: never; }) | undefined;
+        } & { [K_24 in Exclude<keyof I_1["listEffects"], "effects">]: never; }) | undefined;
+        error?: string | undefined;
+    } & { [K_25 in Exclude<keyof I_1, keyof LEDServerMessage>]: never; }>(object: I_1): LEDServerMessage;
 };
 export declare const AuthenticateRequest: {
     encode(message: AuthenticateRequest, writer?: _m0.Writer): _m0.Writer;
@@ -484,6 +1076,380 @@ export declare const RGBAPixels: {
         pixels?: Uint8Array | undefined;
     } & { [K_1 in Exclude<keyof I_1, "pixels">]: never; }>(object: I_1): RGBAPixels;
 };
+export declare const GetPlayerStatusRequest: {
+    encode(_: GetPlayerStatusRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): GetPlayerStatusRequest;
+    fromJSON(_: any): GetPlayerStatusRequest;
+    toJSON(_: GetPlayerStatusRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): GetPlayerStatusRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): GetPlayerStatusRequest;
+};
+export declare const GetPlayerStatusResponse: {
+    encode(message: GetPlayerStatusResponse, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): GetPlayerStatusResponse;
+    fromJSON(object: any): GetPlayerStatusResponse;
+    toJSON(message: GetPlayerStatusResponse): unknown;
+    create<I extends {
+        queuedFrames?: number | undefined;
+        maxFrames?: number | undefined;
+        frameIndex?: number | undefined;
+        remainingMs?: number | undefined;
+        looping?: boolean | undefined;
+        paused?: boolean | undefined;
+        speed?: number | undefined;
+        bpm?: number | undefined;
+    } & {
+        queuedFrames?: number | undefined;
+        maxFrames?: number | undefined;
+        frameIndex?: number | undefined;
+        remainingMs?: number | undefined;
+        looping?: boolean | undefined;
+        paused?: boolean | undefined;
+        speed?: number | undefined;
+        bpm?: number | undefined;
+    } & { [K in Exclude<keyof I, keyof GetPlayerStatusResponse>]: never; }>(base?: I | undefined): GetPlayerStatusResponse;
+    fromPartial<I_1 extends {
+        queuedFrames?: number | undefined;
+        maxFrames?: number | undefined;
+        frameIndex?: number | undefined;
+        remainingMs?: number | undefined;
+        looping?: boolean | undefined;
+        paused?: boolean | undefined;
+        speed?: number | undefined;
+        bpm?: number | undefined;
+    } & {
+        queuedFrames?: number | undefined;
+        maxFrames?: number | undefined;
+        frameIndex?: number | undefined;
+        remainingMs?: number | undefined;
+        looping?: boolean | undefined;
+        paused?: boolean | undefined;
+        speed?: number | undefined;
+        bpm?: number | undefined;
+    } & { [K_1 in Exclude<keyof I_1, keyof GetPlayerStatusResponse>]: never; }>(object: I_1): GetPlayerStatusResponse;
+};
+export declare const PausePlaybackRequest: {
+    encode(_: PausePlaybackRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): PausePlaybackRequest;
+    fromJSON(_: any): PausePlaybackRequest;
+    toJSON(_: PausePlaybackRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): PausePlaybackRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): PausePlaybackRequest;
+};
+export declare const ResumePlaybackRequest: {
+    encode(_: ResumePlaybackRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): ResumePlaybackRequest;
+    fromJSON(_: any): ResumePlaybackRequest;
+    toJSON(_: ResumePlaybackRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): ResumePlaybackRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): ResumePlaybackRequest;
+};
+export declare const SeekPlaybackRequest: {
+    encode(message: SeekPlaybackRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): SeekPlaybackRequest;
+    fromJSON(object: any): SeekPlaybackRequest;
+    toJSON(message: SeekPlaybackRequest): unknown;
+    create<I extends {
+        frameIndex?: number | undefined;
+    } & {
+        frameIndex?: number | undefined;
+    } & { [K in Exclude<keyof I, "frameIndex">]: never; }>(base?: I | undefined): SeekPlaybackRequest;
+    fromPartial<I_1 extends {
+        frameIndex?: number | undefined;
+    } & {
+        frameIndex?: number | undefined;
+    } & { [K_1 in Exclude<keyof I_1, "frameIndex">]: never; }>(object: I_1): SeekPlaybackRequest;
+};
+export declare const SetPlaybackSpeedRequest: {
+    encode(message: SetPlaybackSpeedRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): SetPlaybackSpeedRequest;
+    fromJSON(object: any): SetPlaybackSpeedRequest;
+    toJSON(message: SetPlaybackSpeedRequest): unknown;
+    create<I extends {
+        speed?: number | undefined;
+    } & {
+        speed?: number | undefined;
+    } & { [K in Exclude<keyof I, "speed">]: never; }>(base?: I | undefined): SetPlaybackSpeedRequest;
+    fromPartial<I_1 extends {
+        speed?: number | undefined;
+    } & {
+        speed?: number | undefined;
+    } & { [K_1 in Exclude<keyof I_1, "speed">]: never; }>(object: I_1): SetPlaybackSpeedRequest;
+};
+export declare const SetTempoRequest: {
+    encode(message: SetTempoRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): SetTempoRequest;
+    fromJSON(object: any): SetTempoRequest;
+    toJSON(message: SetTempoRequest): unknown;
+    create<I extends {
+        bpm?: number | undefined;
+        phase?: number | undefined;
+    } & {
+        bpm?: number | undefined;
+        phase?: number | undefined;
+    } & { [K in Exclude<keyof I, keyof SetTempoRequest>]: never; }>(base?: I | undefined): SetTempoRequest;
+    fromPartial<I_1 extends {
+        bpm?: number | undefined;
+        phase?: number | undefined;
+    } & {
+        bpm?: number | undefined;
+        phase?: number | undefined;
+    } & { [K_1 in Exclude<keyof I_1, keyof SetTempoRequest>]: never; }>(object: I_1): SetTempoRequest;
+};
+export declare const TapTempoRequest: {
+    encode(_: TapTempoRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): TapTempoRequest;
+    fromJSON(_: any): TapTempoRequest;
+    toJSON(_: TapTempoRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): TapTempoRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): TapTempoRequest;
+};
+export declare const GetLayersRequest: {
+    encode(_: GetLayersRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): GetLayersRequest;
+    fromJSON(_: any): GetLayersRequest;
+    toJSON(_: GetLayersRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): GetLayersRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): GetLayersRequest;
+};
+export declare const GetLayersResponse: {
+    encode(message: GetLayersResponse, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): GetLayersResponse;
+    fromJSON(object: any): GetLayersResponse;
+    toJSON(message: GetLayersResponse): unknown;
+    create<I extends {
+        layers?: {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[] | undefined;
+    } & {
+        layers?: ({
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[] & ({
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        } & {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: (number[] & number[] & { [K in Exclude<keyof I["layers"][number]["regionLeds"], keyof number[]>]: never; }) | undefined;
+        } & { [K_1 in Exclude<keyof I["layers"][number], keyof Layer>]: never; })[] & { [K_2 in Exclude<keyof I["layers"], keyof {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[]>]: never; }) | undefined;
+    } & { [K_3 in Exclude<keyof I, "layers">]: never; }>(base?: I | undefined): GetLayersResponse;
+    fromPartial<I_1 extends {
+        layers?: {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[] | undefined;
+    } & {
+        layers?: ({
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[] & ({
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        } & {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: (number[] & number[] & { [K_4 in Exclude<keyof I_1["layers"][number]["regionLeds"], keyof number[]>]: never; }) | undefined;
+        } & { [K_5 in Exclude<keyof I_1["layers"][number], keyof Layer>]: never; })[] & { [K_6 in Exclude<keyof I_1["layers"], keyof {
+            name?: string | undefined;
+            opacity?: number | undefined;
+            blend?: BlendMode | undefined;
+            drawable?: boolean | undefined;
+            granted?: boolean | undefined;
+            regionLeds?: number[] | undefined;
+        }[]>]: never; }) | undefined;
+    } & { [K_7 in Exclude<keyof I_1, "layers">]: never; }>(object: I_1): GetLayersResponse;
+};
+export declare const Layer: {
+    encode(message: Layer, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): Layer;
+    fromJSON(object: any): Layer;
+    toJSON(message: Layer): unknown;
+    create<I extends {
+        name?: string | undefined;
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+        drawable?: boolean | undefined;
+        granted?: boolean | undefined;
+        regionLeds?: number[] | undefined;
+    } & {
+        name?: string | undefined;
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+        drawable?: boolean | undefined;
+        granted?: boolean | undefined;
+        regionLeds?: (number[] & number[] & { [K in Exclude<keyof I["regionLeds"], keyof number[]>]: never; }) | undefined;
+    } & { [K_1 in Exclude<keyof I, keyof Layer>]: never; }>(base?: I | undefined): Layer;
+    fromPartial<I_1 extends {
+        name?: string | undefined;
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+        drawable?: boolean | undefined;
+        granted?: boolean | undefined;
+        regionLeds?: number[] | undefined;
+    } & {
+        name?: string | undefined;
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+        drawable?: boolean | undefined;
+        granted?: boolean | undefined;
+        regionLeds?: (number[] & number[] & { [K_2 in Exclude<keyof I_1["regionLeds"], keyof number[]>]: never; }) | undefined;
+    } & { [K_3 in Exclude<keyof I_1, keyof Layer>]: never; }>(object: I_1): Layer;
+};
+export declare const SetLayerRequest: {
+    encode(message: SetLayerRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): SetLayerRequest;
+    fromJSON(object: any): SetLayerRequest;
+    toJSON(message: SetLayerRequest): unknown;
+    create<I extends {
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+    } & {
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+    } & { [K in Exclude<keyof I, keyof SetLayerRequest>]: never; }>(base?: I | undefined): SetLayerRequest;
+    fromPartial<I_1 extends {
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+    } & {
+        opacity?: number | undefined;
+        blend?: BlendMode | undefined;
+    } & { [K_1 in Exclude<keyof I_1, keyof SetLayerRequest>]: never; }>(object: I_1): SetLayerRequest;
+};
+export declare const ListEffectsRequest: {
+    encode(_: ListEffectsRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): ListEffectsRequest;
+    fromJSON(_: any): ListEffectsRequest;
+    toJSON(_: ListEffectsRequest): unknown;
+    create<I extends {} & {} & { [K in Exclude<keyof I, never>]: never; }>(base?: I | undefined): ListEffectsRequest;
+    fromPartial<I_1 extends {} & {} & { [K_1 in Exclude<keyof I_1, never>]: never; }>(_: I_1): ListEffectsRequest;
+};
+export declare const ListEffectsResponse: {
+    encode(message: ListEffectsResponse, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): ListEffectsResponse;
+    fromJSON(object: any): ListEffectsResponse;
+    toJSON(message: ListEffectsResponse): unknown;
+    create<I extends {
+        effects?: string[] | undefined;
+    } & {
+        effects?: (string[] & string[] & { [K in Exclude<keyof I["effects"], keyof string[]>]: never; }) | undefined;
+    } & { [K_1 in Exclude<keyof I, "effects">]: never; }>(base?: I | undefined): ListEffectsResponse;
+    fromPartial<I_1 extends {
+        effects?: string[] | undefined;
+    } & {
+        effects?: (string[] & string[] & { [K_2 in Exclude<keyof I_1["effects"], keyof string[]>]: never; }) | undefined;
+    } & { [K_3 in Exclude<keyof I_1, "effects">]: never; }>(object: I_1): ListEffectsResponse;
+};
+export declare const PlayEffectRequest: {
+    encode(message: PlayEffectRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): PlayEffectRequest;
+    fromJSON(object: any): PlayEffectRequest;
+    toJSON(message: PlayEffectRequest): unknown;
+    create<I extends {
+        name?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & {
+        name?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & { [K in Exclude<keyof I, keyof PlayEffectRequest>]: never; }>(base?: I | undefined): PlayEffectRequest;
+    fromPartial<I_1 extends {
+        name?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & {
+        name?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & { [K_1 in Exclude<keyof I_1, keyof PlayEffectRequest>]: never; }>(object: I_1): PlayEffectRequest;
+};
+export declare const PlayScriptRequest: {
+    encode(message: PlayScriptRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): PlayScriptRequest;
+    fromJSON(object: any): PlayScriptRequest;
+    toJSON(message: PlayScriptRequest): unknown;
+    create<I extends {
+        source?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & {
+        source?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & { [K in Exclude<keyof I, keyof PlayScriptRequest>]: never; }>(base?: I | undefined): PlayScriptRequest;
+    fromPartial<I_1 extends {
+        source?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & {
+        source?: string | undefined;
+        durationMs?: number | undefined;
+        intervalMs?: number | undefined;
+        loop?: boolean | undefined;
+    } & { [K_1 in Exclude<keyof I_1, keyof PlayScriptRequest>]: never; }>(object: I_1): PlayScriptRequest;
+};
+export declare const UploadAnimationRequest: {
+    encode(message: UploadAnimationRequest, writer?: _m0.Writer): _m0.Writer;
+    decode(input: _m0.Reader | Uint8Array, length?: number): UploadAnimationRequest;
+    fromJSON(object: any): UploadAnimationRequest;
+    toJSON(message: UploadAnimationRequest): unknown;
+    create<I extends {
+        data?: Uint8Array | undefined;
+    } & {
+        data?: Uint8Array | undefined;
+    } & { [K in Exclude<keyof I, "data">]: never; }>(base?: I | undefined): UploadAnimationRequest;
+    fromPartial<I_1 extends {
+        data?: Uint8Array | undefined;
+    } & {
+        data?: Uint8Array | undefined;
+    } & { [K_1 in Exclude<keyof I_1, "data">]: never; }>(object: I_1): UploadAnimationRequest;
+};
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 export type DeepPartial<T> = T extends Builtin ? T : T extends globalThis.Array<infer U> ? globalThis.Array<DeepPartial<U>> : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>> : T extends {} ? {
     [K in keyof T]?: DeepPartial<T[K]>;
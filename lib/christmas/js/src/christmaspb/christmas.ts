@@ -38,7 +38,86 @@ export interface LEDClientMessage {
    * number of LEDs. Calling this is equivalent to calling DeleteFrames
    * followed by AddFrames with a single frame.
    */
-  setLeds?: SetLEDsRequest | undefined;
+  setLeds?:
+    | SetLEDsRequest
+    | undefined;
+  /**
+   * Get the state of the animation player. Sends back a
+   * GetPlayerStatusResponse.
+   */
+  getPlayerStatus?:
+    | GetPlayerStatusRequest
+    | undefined;
+  /**
+   * Pause playback. The shown frame stays on, and frames can still be
+   * added.
+   */
+  pausePlayback?:
+    | PausePlaybackRequest
+    | undefined;
+  /** Resume paused playback. */
+  resumePlayback?:
+    | ResumePlaybackRequest
+    | undefined;
+  /** Show the given frame right away and continue playing from there. */
+  seekPlayback?:
+    | SeekPlaybackRequest
+    | undefined;
+  /** Set the playback rate. */
+  setPlaybackSpeed?:
+    | SetPlaybackSpeedRequest
+    | undefined;
+  /** Set the tempo that frames timed in beats follow. */
+  setTempo?:
+    | SetTempoRequest
+    | undefined;
+  /**
+   * Tap the tempo. Each tap lands on a beat; taps in quick succession set
+   * the tempo to their average interval.
+   */
+  tapTempo?:
+    | TapTempoRequest
+    | undefined;
+  /**
+   * List the layers that the server combines into the LEDs. Sends back a
+   * GetLayersResponse.
+   */
+  getLayers?:
+    | GetLayersRequest
+    | undefined;
+  /** Change the opacity or blend mode of the layer named by layer. */
+  setLayer?:
+    | SetLayerRequest
+    | undefined;
+  /**
+   * List the built-in effects and the effect scripts of the server. Sends
+   * back a ListEffectsResponse.
+   */
+  listEffects?:
+    | ListEffectsRequest
+    | undefined;
+  /**
+   * Replace the frames of the canvas with a built-in effect or an effect
+   * script of the server.
+   */
+  playEffect?:
+    | PlayEffectRequest
+    | undefined;
+  /** Replace the frames of the canvas with an effect script. */
+  playScript?:
+    | PlayScriptRequest
+    | undefined;
+  /** Replace the frames of the canvas with those of an animation file. */
+  uploadAnimation?:
+    | UploadAnimationRequest
+    | undefined;
+  /**
+   * The layer that this message targets. The canvas, LED and playback APIs
+   * act on the canvas of this layer. Empty targets the default canvas. If the
+   * server has no such layer, or the client is not allowed to control it, the
+   * connection is closed with an error.
+   */
+  layer: string;
 }
 
 export interface LEDServerMessage {
@@ -54,6 +133,18 @@ export interface LEDServerMessage {
   getLeds?:
     | GetLEDsResponse
     | undefined;
+  /** Response to GetPlayerStatusRequest. */
+  getPlayerStatus?:
+    | GetPlayerStatusResponse
+    | undefined;
+  /** Response to GetLayersRequest. */
+  getLayers?:
+    | GetLayersResponse
+    | undefined;
+  /** Response to ListEffectsRequest. */
+  listEffects?:
+    | ListEffectsResponse
+    | undefined;
   /**
    * If present, the server encountered an error. This is a string describing
    * the error.
@@ -85,7 +176,7 @@ export interface GetLEDsResponse {
 export interface SetLEDsRequest {
   /**
    * A 1D array of colors. The number of colors must match the number of LEDs.
-   * To get the number of LEDs, call GetLEDs.
+   * To get the number of LEDs, take the length of GetLEDsResponse.
    */
   leds: Color[];
 }
@@ -121,6 +212,227 @@ export interface RGBAPixels {
   pixels: Uint8Array;
 }
 
+export interface GetPlayerStatusRequest {
+}
+
+export interface GetPlayerStatusResponse {
+  /** The number of frames that have been added but not shown yet. */
+  queuedFrames: number;
+  /** The maximum number of frames that the player can hold. */
+  maxFrames: number;
+  /**
+   * The index of the frame that is currently shown. Frames are indexed in the
+   * order that they were added, starting from 0. Absent if no frame is shown.
+   */
+  frameIndex?:
+    | number
+    | undefined;
+  /**
+   * How long it takes until all queued frames have been shown once, in
+   * milliseconds. Loops are not accounted for.
+   */
+  remainingMs: number;
+  /**
+   * Whether the queued frames will loop, in which case playing them takes
+   * longer than remaining_ms, possibly forever.
+   */
+  looping: boolean;
+  /** Whether playback is paused. */
+  paused: boolean;
+  /** The playback rate. 1 is normal speed. */
+  speed: number;
+  /** The tempo that frames timed in beats follow, in beats per minute. */
+  bpm: number;
+}
+
+export interface PausePlaybackRequest {
+}
+
+export interface ResumePlaybackRequest {
+}
+
+export interface SeekPlaybackRequest {
+  /**
+   * The index of the frame to seek to, as in
+   * GetPlayerStatusResponse.frame_index. The frame must still be held by the
+   * player, otherwise an error is sent back.
+   */
+  frameIndex: number;
+}
+
+export interface SetPlaybackSpeedRequest {
+  /**
+   * The playback rate. 1 is normal speed, 2 plays twice as fast and 0.5 half
+   * as fast. It must be positive; use PausePlaybackRequest to stop.
+   */
+  speed: number;
+}
+
+export interface SetTempoRequest {
+  /**
+   * The tempo in beats per minute. It must be between 1 and 1000. The
+   * current beat position is kept.
+   */
+  bpm: number;
+  /**
+   * If present, the phase of the current beat, between 0 (on the beat) and 1.
+   * Use this to line the beat up with the music.
+   */
+  phase?: number | undefined;
+}
+
+export interface TapTempoRequest {
+}
+
+/** BlendMode is how a layer is blended onto the layers below it. */
+export enum BlendMode {
+  /** BLEND_MODE_NORMAL - Paint the layer over the layers below it. */
+  BLEND_MODE_NORMAL = 0,
+  /** BLEND_MODE_ADD - Add the colors together, which brightens them. */
+  BLEND_MODE_ADD = 1,
+  /**
+   * BLEND_MODE_MULTIPLY - Multiply the colors, which darkens them. White keeps the colors below and
+   * black turns them off.
+   */
+  BLEND_MODE_MULTIPLY = 2,
+  /** BLEND_MODE_SCREEN - Brighten the colors without clipping as harshly as BLEND_MODE_ADD. */
+  BLEND_MODE_SCREEN = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function blendModeFromJSON(object: any): BlendMode {
+  switch (object) {
+    case 0:
+    case "BLEND_MODE_NORMAL":
+      return BlendMode.BLEND_MODE_NORMAL;
+    case 1:
+    case "BLEND_MODE_ADD":
+      return BlendMode.BLEND_MODE_ADD;
+    case 2:
+    case "BLEND_MODE_MULTIPLY":
+      return BlendMode.BLEND_MODE_MULTIPLY;
+    case 3:
+    case "BLEND_MODE_SCREEN":
+      return BlendMode.BLEND_MODE_SCREEN;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return BlendMode.UNRECOGNIZED;
+  }
+}
+
+export function blendModeToJSON(object: BlendMode): string {
+  switch (object) {
+    case BlendMode.BLEND_MODE_NORMAL:
+      return "BLEND_MODE_NORMAL";
+    case BlendMode.BLEND_MODE_ADD:
+      return "BLEND_MODE_ADD";
+    case BlendMode.BLEND_MODE_MULTIPLY:
+      return "BLEND_MODE_MULTIPLY";
+    case BlendMode.BLEND_MODE_SCREEN:
+      return "BLEND_MODE_SCREEN";
+    case BlendMode.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface GetLayersRequest {
+}
+
+export interface GetLayersResponse {
+  /** The layers, from the bottom to the top. */
+  layers: Layer[];
+}
+
+export interface Layer {
+  /** The name of the layer, which LEDClientMessage.layer refers to. */
+  name: string;
+  /** The opacity of the layer, between 0 and 1. */
+  opacity: number;
+  /** How the layer is blended onto the layers below it. */
+  blend: BlendMode;
+  /** Whether the layer has a canvas that clients can draw to. */
+  drawable: boolean;
+  /**
+   * Whether this client may target the layer. Clients that authenticated
+   * with a secret that was granted only some layers cannot target the others.
+   */
+  granted: boolean;
+  /**
+   * The indices of the LEDs that the layer is limited to, in ascending order.
+   * Empty if the layer covers all LEDs. Drawing on the layer's canvas only
+   * changes these LEDs.
+   */
+  regionLeds: number[];
+}
+
+export interface SetLayerRequest {
+  /**
+   * If present, the new opacity of the layer, between 0 and 1. 0 hides the
+   * layer.
+   */
+  opacity?:
+    | number
+    | undefined;
+  /** If present, the new blend mode of the layer. */
+  blend?: BlendMode | undefined;
+}
+
+export interface ListEffectsRequest {
+}
+
+export interface ListEffectsResponse {
+  /**
+   * The names of the built-in effects, such as "rainbow", and of the effect
+   * scripts of the server, in sorted order.
+   */
+  effects: string[];
+}
+
+export interface PlayEffectRequest {
+  /** The name of the effect, as listed by ListEffectsResponse. */
+  name: string;
+  /**
+   * How long the effect plays for, in milliseconds. The effect must fit in
+   * the frames that the player can hold, which is also the default.
+   */
+  durationMs: number;
+  /** The time between frames, in milliseconds. Defaults to 50. */
+  intervalMs: number;
+  /**
+   * Whether the effect starts over once it ends, until other frames replace
+   * it.
+   */
+  loop: boolean;
+}
+
+export interface PlayScriptRequest {
+  /**
+   * The Starlark source of the script. It defines a function
+   * led(i, x, y, t) that returns the color of the LED at index i and
+   * normalized position (x, y) at time t in seconds. The server limits how
+   * long the script may run for each frame.
+   */
+  source: string;
+  /** How long the effect plays for, like in PlayEffectRequest. */
+  durationMs: number;
+  /** The time between frames, like in PlayEffectRequest. */
+  intervalMs: number;
+  /** Whether the effect starts over once it ends, like in PlayEffectRequest. */
+  loop: boolean;
+}
+
+export interface UploadAnimationRequest {
+  /**
+   * The animation file, in either the binary or the JSON format of the
+   * ledanim package. It must have as many LEDs as the canvas, and if it has a
+   * position hash, the hash must match the positions of the canvas LEDs. All
+   * of its frames must fit in the player.
+   */
+  data: Uint8Array;
+}
+
 function createBaseLEDClientMessage(): LEDClientMessage {
   return {
     authenticate: undefined,
@@ -128,6 +440,20 @@ function createBaseLEDClientMessage(): LEDClientMessage {
     setLedCanvas: undefined,
     getLeds: undefined,
     setLeds: undefined,
+    getPlayerStatus: undefined,
+    pausePlayback: undefined,
+    resumePlayback: undefined,
+    seekPlayback: undefined,
+    setPlaybackSpeed: undefined,
+    setTempo: undefined,
+    tapTempo: undefined,
+    getLayers: undefined,
+    setLayer: undefined,
+    listEffects: undefined,
+    playEffect: undefined,
+    playScript: undefined,
+    uploadAnimation: undefined,
+    layer: "",
   };
 }
 
@@ -148,6 +474,48 @@ export const LEDClientMessage = {
     if (message.setLeds !== undefined) {
       SetLEDsRequest.encode(message.setLeds, writer.uint32(42).fork()).ldelim();
     }
+    if (message.getPlayerStatus !== undefined) {
+      GetPlayerStatusRequest.encode(message.getPlayerStatus, writer.uint32(50).fork()).ldelim();
+    }
+    if (message.pausePlayback !== undefined) {
+      PausePlaybackRequest.encode(message.pausePlayback, writer.uint32(58).fork()).ldelim();
+    }
+    if (message.resumePlayback !== undefined) {
+      ResumePlaybackRequest.encode(message.resumePlayback, writer.uint32(66).fork()).ldelim();
+    }
+    if (message.seekPlayback !== undefined) {
+      SeekPlaybackRequest.encode(message.seekPlayback, writer.uint32(74).fork()).ldelim();
+    }
+    if (message.setPlaybackSpeed !== undefined) {
+      SetPlaybackSpeedRequest.encode(message.setPlaybackSpeed, writer.uint32(82).fork()).ldelim();
+    }
+    if (message.setTempo !== undefined) {
+      SetTempoRequest.encode(message.setTempo, writer.uint32(90).fork()).ldelim();
+    }
+    if (message.tapTempo !== undefined) {
+      TapTempoRequest.encode(message.tapTempo, writer.uint32(98).fork()).ldelim();
+    }
+    if (message.getLayers !== undefined) {
+      GetLayersRequest.encode(message.getLayers, writer.uint32(106).fork()).ldelim();
+    }
+    if (message.setLayer !== undefined) {
+      SetLayerRequest.encode(message.setLayer, writer.uint32(114).fork()).ldelim();
+    }
+    if (message.listEffects !== undefined) {
+      ListEffectsRequest.encode(message.listEffects, writer.uint32(122).fork()).ldelim();
+    }
+    if (message.playEffect !== undefined) {
+      PlayEffectRequest.encode(message.playEffect, writer.uint32(130).fork()).ldelim();
+    }
+    if (message.playScript !== undefined) {
+      PlayScriptRequest.encode(message.playScript, writer.uint32(146).fork()).ldelim();
+    }
+    if (message.uploadAnimation !== undefined) {
+      UploadAnimationRequest.encode(message.uploadAnimation, writer.uint32(138).fork()).ldelim();
+    }
+    if (message.layer !== "") {
+      writer.uint32(802).string(message.layer);
+    }
     return writer;
   },
 
@@ -193,6 +561,104 @@ export const LEDClientMessage = {
 
           message.setLeds = SetLEDsRequest.decode(reader, reader.uint32());
           continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.getPlayerStatus = GetPlayerStatusRequest.decode(reader, reader.uint32());
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.pausePlayback = PausePlaybackRequest.decode(reader, reader.uint32());
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.resumePlayback = ResumePlaybackRequest.decode(reader, reader.uint32());
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.seekPlayback = SeekPlaybackRequest.decode(reader, reader.uint32());
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.setPlaybackSpeed = SetPlaybackSpeedRequest.decode(reader, reader.uint32());
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.setTempo = SetTempoRequest.decode(reader, reader.uint32());
+          continue;
+        case 12:
+          if (tag !== 98) {
+            break;
+          }
+
+          message.tapTempo = TapTempoRequest.decode(reader, reader.uint32());
+          continue;
+        case 13:
+          if (tag !== 106) {
+            break;
+          }
+
+          message.getLayers = GetLayersRequest.decode(reader, reader.uint32());
+          continue;
+        case 14:
+          if (tag !== 114) {
+            break;
+          }
+
+          message.setLayer = SetLayerRequest.decode(reader, reader.uint32());
+          continue;
+        case 15:
+          if (tag !== 122) {
+            break;
+          }
+
+          message.listEffects = ListEffectsRequest.decode(reader, reader.uint32());
+          continue;
+        case 16:
+          if (tag !== 130) {
+            break;
+          }
+
+          message.playEffect = PlayEffectRequest.decode(reader, reader.uint32());
+          continue;
+        case 18:
+          if (tag !== 146) {
+            break;
+          }
+
+          message.playScript = PlayScriptRequest.decode(reader, reader.uint32());
+          continue;
+        case 17:
+          if (tag !== 138) {
+            break;
+          }
+
+          message.uploadAnimation = UploadAnimationRequest.decode(reader, reader.uint32());
+          continue;
+        case 100:
+          if (tag !== 802) {
+            break;
+          }
+
+          message.layer = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -211,6 +677,26 @@ export const LEDClientMessage = {
       setLedCanvas: isSet(object.setLedCanvas) ? SetLEDCanvasRequest.fromJSON(object.setLedCanvas) : undefined,
       getLeds: isSet(object.getLeds) ? GetLEDsRequest.fromJSON(object.getLeds) : undefined,
       setLeds: isSet(object.setLeds) ? SetLEDsRequest.fromJSON(object.setLeds) : undefined,
+      getPlayerStatus: isSet(object.getPlayerStatus)
+        ? GetPlayerStatusRequest.fromJSON(object.getPlayerStatus)
+        : undefined,
+      pausePlayback: isSet(object.pausePlayback) ? PausePlaybackRequest.fromJSON(object.pausePlayback) : undefined,
+      resumePlayback: isSet(object.resumePlayback) ? ResumePlaybackRequest.fromJSON(object.resumePlayback) : undefined,
+      seekPlayback: isSet(object.seekPlayback) ? SeekPlaybackRequest.fromJSON(object.seekPlayback) : undefined,
+      setPlaybackSpeed: isSet(object.setPlaybackSpeed)
+        ? SetPlaybackSpeedRequest.fromJSON(object.setPlaybackSpeed)
+        : undefined,
+      setTempo: isSet(object.setTempo) ? SetTempoRequest.fromJSON(object.setTempo) : undefined,
+      tapTempo: isSet(object.tapTempo) ? TapTempoRequest.fromJSON(object.tapTempo) : undefined,
+      getLayers: isSet(object.getLayers) ? GetLayersRequest.fromJSON(object.getLayers) : undefined,
+      setLayer: isSet(object.setLayer) ? SetLayerRequest.fromJSON(object.setLayer) : undefined,
+      listEffects: isSet(object.listEffects) ? ListEffectsRequest.fromJSON(object.listEffects) : undefined,
+      playEffect: isSet(object.playEffect) ? PlayEffectRequest.fromJSON(object.playEffect) : undefined,
+      playScript: isSet(object.playScript) ? PlayScriptRequest.fromJSON(object.playScript) : undefined,
+      uploadAnimation: isSet(object.uploadAnimation)
+        ? UploadAnimationRequest.fromJSON(object.uploadAnimation)
+        : undefined,
+      layer: isSet(object.layer) ? globalThis.String(object.layer) : "",
     };
   },
 
@@ -231,6 +717,48 @@ export const LEDClientMessage = {
     if (message.setLeds !== undefined) {
       obj.setLeds = SetLEDsRequest.toJSON(message.setLeds);
     }
+    if (message.getPlayerStatus !== undefined) {
+      obj.getPlayerStatus = GetPlayerStatusRequest.toJSON(message.getPlayerStatus);
+    }
+    if (message.pausePlayback !== undefined) {
+      obj.pausePlayback = PausePlaybackRequest.toJSON(message.pausePlayback);
+    }
+    if (message.resumePlayback !== undefined) {
+      obj.resumePlayback = ResumePlaybackRequest.toJSON(message.resumePlayback);
+    }
+    if (message.seekPlayback !== undefined) {
+      obj.seekPlayback = SeekPlaybackRequest.toJSON(message.seekPlayback);
+    }
+    if (message.setPlaybackSpeed !== undefined) {
+      obj.setPlaybackSpeed = SetPlaybackSpeedRequest.toJSON(message.setPlaybackSpeed);
+    }
+    if (message.setTempo !== undefined) {
+      obj.setTempo = SetTempoRequest.toJSON(message.setTempo);
+    }
+    if (message.tapTempo !== undefined) {
+      obj.tapTempo = TapTempoRequest.toJSON(message.tapTempo);
+    }
+    if (message.getLayers !== undefined) {
+      obj.getLayers = GetLayersRequest.toJSON(message.getLayers);
+    }
+    if (message.setLayer !== undefined) {
+      obj.setLayer = SetLayerRequest.toJSON(message.setLayer);
+    }
+    if (message.listEffects !== undefined) {
+      obj.listEffects = ListEffectsRequest.toJSON(message.listEffects);
+    }
+    if (message.playEffect !== undefined) {
+      obj.playEffect = PlayEffectRequest.toJSON(message.playEffect);
+    }
+    if (message.playScript !== undefined) {
+      obj.playScript = PlayScriptRequest.toJSON(message.playScript);
+    }
+    if (message.uploadAnimation !== undefined) {
+      obj.uploadAnimation = UploadAnimationRequest.toJSON(message.uploadAnimation);
+    }
+    if (message.layer !== "") {
+      obj.layer = message.layer;
+    }
     return obj;
   },
 
@@ -254,12 +782,60 @@ export const LEDClientMessage = {
     message.setLeds = (object.setLeds !== undefined && object.setLeds !== null)
       ? SetLEDsRequest.fromPartial(object.setLeds)
       : undefined;
+    message.getPlayerStatus = (object.getPlayerStatus !== undefined && object.getPlayerStatus !== null)
+      ? GetPlayerStatusRequest.fromPartial(object.getPlayerStatus)
+      : undefined;
+    message.pausePlayback = (object.pausePlayback !== undefined && object.pausePlayback !== null)
+      ? PausePlaybackRequest.fromPartial(object.pausePlayback)
+      : undefined;
+    message.resumePlayback = (object.resumePlayback !== undefined && object.resumePlayback !== null)
+      ? ResumePlaybackRequest.fromPartial(object.resumePlayback)
+      : undefined;
+    message.seekPlayback = (object.seekPlayback !== undefined && object.seekPlayback !== null)
+      ? SeekPlaybackRequest.fromPartial(object.seekPlayback)
+      : undefined;
+    message.setPlaybackSpeed = (object.setPlaybackSpeed !== undefined && object.setPlaybackSpeed !== null)
+      ? SetPlaybackSpeedRequest.fromPartial(object.setPlaybackSpeed)
+      : undefined;
+    message.setTempo = (object.setTempo !== undefined && object.setTempo !== null)
+      ? SetTempoRequest.fromPartial(object.setTempo)
+      : undefined;
+    message.tapTempo = (object.tapTempo !== undefined && object.tapTempo !== null)
+      ? TapTempoRequest.fromPartial(object.tapTempo)
+      : undefined;
+    message.getLayers = (object.getLayers !== undefined && object.getLayers !== null)
+      ? GetLayersRequest.fromPartial(object.getLayers)
+      : undefined;
+    message.setLayer = (object.setLayer !== undefined && object.setLayer !== null)
+      ? SetLayerRequest.fromPartial(object.setLayer)
+      : undefined;
+    message.listEffects = (object.listEffects !== undefined && object.listEffects !== null)
+      ? ListEffectsRequest.fromPartial(object.listEffects)
+      : undefined;
+    message.playEffect = (object.playEffect !== undefined && object.playEffect !== null)
+      ? PlayEffectRequest.fromPartial(object.playEffect)
+      : undefined;
+    message.playScript = (object.playScript !== undefined && object.playScript !== null)
+      ? PlayScriptRequest.fromPartial(object.playScript)
+      : undefined;
+    message.uploadAnimation = (object.uploadAnimation !== undefined && object.uploadAnimation !== null)
+      ? UploadAnimationRequest.fromPartial(object.uploadAnimation)
+      : undefined;
+    message.layer = object.layer ?? "";
     return message;
   },
 };
 
 function createBaseLEDServerMessage(): LEDServerMessage {
-  return { authenticate: undefined, getLedCanvasInfo: undefined, getLeds: undefined, error: undefined };
+  return {
+    authenticate: undefined,
+    getLedCanvasInfo: undefined,
+    getLeds: undefined,
+    getPlayerStatus: undefined,
+    getLayers: undefined,
+    listEffects: undefined,
+    error: undefined,
+  };
 }
 
 export const LEDServerMessage = {
@@ -273,6 +849,15 @@ export const LEDServerMessage = {
     if (message.getLeds !== undefined) {
       GetLEDsResponse.encode(message.getLeds, writer.uint32(26).fork()).ldelim();
     }
+    if (message.getPlayerStatus !== undefined) {
+      GetPlayerStatusResponse.encode(message.getPlayerStatus, writer.uint32(34).fork()).ldelim();
+    }
+    if (message.getLayers !== undefined) {
+      GetLayersResponse.encode(message.getLayers, writer.uint32(42).fork()).ldelim();
+    }
+    if (message.listEffects !== undefined) {
+      ListEffectsResponse.encode(message.listEffects, writer.uint32(50).fork()).ldelim();
+    }
     if (message.error !== undefined) {
       writer.uint32(802).string(message.error);
     }
@@ -307,6 +892,27 @@ export const LEDServerMessage = {
 
           message.getLeds = GetLEDsResponse.decode(reader, reader.uint32());
           continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.getPlayerStatus = GetPlayerStatusResponse.decode(reader, reader.uint32());
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.getLayers = GetLayersResponse.decode(reader, reader.uint32());
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.listEffects = ListEffectsResponse.decode(reader, reader.uint32());
+          continue;
         case 100:
           if (tag !== 802) {
             break;
@@ -330,6 +936,11 @@ export const LEDServerMessage = {
         ? GetLEDCanvasInfoResponse.fromJSON(object.getLedCanvasInfo)
         : undefined,
       getLeds: isSet(object.getLeds) ? GetLEDsResponse.fromJSON(object.getLeds) : undefined,
+      getPlayerStatus: isSet(object.getPlayerStatus)
+        ? GetPlayerStatusResponse.fromJSON(object.getPlayerStatus)
+        : undefined,
+      getLayers: isSet(object.getLayers) ? GetLayersResponse.fromJSON(object.getLayers) : undefined,
+      listEffects: isSet(object.listEffects) ? ListEffectsResponse.fromJSON(object.listEffects) : undefined,
       error: isSet(object.error) ? globalThis.String(object.error) : undefined,
     };
   },
@@ -345,6 +956,15 @@ export const LEDServerMessage = {
     if (message.getLeds !== undefined) {
       obj.getLeds = GetLEDsResponse.toJSON(message.getLeds);
     }
+    if (message.getPlayerStatus !== undefined) {
+      obj.getPlayerStatus = GetPlayerStatusResponse.toJSON(message.getPlayerStatus);
+    }
+    if (message.getLayers !== undefined) {
+      obj.getLayers = GetLayersResponse.toJSON(message.getLayers);
+    }
+    if (message.listEffects !== undefined) {
+      obj.listEffects = ListEffectsResponse.toJSON(message.listEffects);
+    }
     if (message.error !== undefined) {
       obj.error = message.error;
     }
@@ -365,6 +985,15 @@ export const LEDServerMessage = {
     message.getLeds = (object.getLeds !== undefined && object.getLeds !== null)
       ? GetLEDsResponse.fromPartial(object.getLeds)
       : undefined;
+    message.getPlayerStatus = (object.getPlayerStatus !== undefined && object.getPlayerStatus !== null)
+      ? GetPlayerStatusResponse.fromPartial(object.getPlayerStatus)
+      : undefined;
+    message.getLayers = (object.getLayers !== undefined && object.getLayers !== null)
+      ? GetLayersResponse.fromPartial(object.getLayers)
+      : undefined;
+    message.listEffects = (object.listEffects !== undefined && object.listEffects !== null)
+      ? ListEffectsResponse.fromPartial(object.listEffects)
+      : undefined;
     message.error = object.error ?? undefined;
     return message;
   },
@@ -931,6 +1560,1228 @@ export const RGBAPixels = {
   },
 };
 
+function createBaseGetPlayerStatusRequest(): GetPlayerStatusRequest {
+  return {};
+}
+
+export const GetPlayerStatusRequest = {
+  encode(_: GetPlayerStatusRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetPlayerStatusRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetPlayerStatusRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): GetPlayerStatusRequest {
+    return {};
+  },
+
+  toJSON(_: GetPlayerStatusRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetPlayerStatusRequest>, I>>(base?: I): GetPlayerStatusRequest {
+    return GetPlayerStatusRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetPlayerStatusRequest>, I>>(_: I): GetPlayerStatusRequest {
+    const message = createBaseGetPlayerStatusRequest();
+    return message;
+  },
+};
+
+function createBaseGetPlayerStatusResponse(): GetPlayerStatusResponse {
+  return {
+    queuedFrames: 0,
+    maxFrames: 0,
+    frameIndex: undefined,
+    remainingMs: 0,
+    looping: false,
+    paused: false,
+    speed: 0,
+    bpm: 0,
+  };
+}
+
+export const GetPlayerStatusResponse = {
+  encode(message: GetPlayerStatusResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.queuedFrames !== 0) {
+      writer.uint32(8).uint32(message.queuedFrames);
+    }
+    if (message.maxFrames !== 0) {
+      writer.uint32(16).uint32(message.maxFrames);
+    }
+    if (message.frameIndex !== undefined) {
+      writer.uint32(24).uint64(message.frameIndex);
+    }
+    if (message.remainingMs !== 0) {
+      writer.uint32(32).uint32(message.remainingMs);
+    }
+    if (message.looping === true) {
+      writer.uint32(40).bool(message.looping);
+    }
+    if (message.paused === true) {
+      writer.uint32(48).bool(message.paused);
+    }
+    if (message.speed !== 0) {
+      writer.uint32(57).double(message.speed);
+    }
+    if (message.bpm !== 0) {
+      writer.uint32(65).double(message.bpm);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetPlayerStatusResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetPlayerStatusResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.queuedFrames = reader.uint32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.maxFrames = reader.uint32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.frameIndex = longToNumber(reader.uint64() as Long);
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.remainingMs = reader.uint32();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.looping = reader.bool();
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.paused = reader.bool();
+          continue;
+        case 7:
+          if (tag !== 57) {
+            break;
+          }
+
+          message.speed = reader.double();
+          continue;
+        case 8:
+          if (tag !== 65) {
+            break;
+          }
+
+          message.bpm = reader.double();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetPlayerStatusResponse {
+    return {
+      queuedFrames: isSet(object.queuedFrames) ? globalThis.Number(object.queuedFrames) : 0,
+      maxFrames: isSet(object.maxFrames) ? globalThis.Number(object.maxFrames) : 0,
+      frameIndex: isSet(object.frameIndex) ? globalThis.Number(object.frameIndex) : undefined,
+      remainingMs: isSet(object.remainingMs) ? globalThis.Number(object.remainingMs) : 0,
+      looping: isSet(object.looping) ? globalThis.Boolean(object.looping) : false,
+      paused: isSet(object.paused) ? globalThis.Boolean(object.paused) : false,
+      speed: isSet(object.speed) ? globalThis.Number(object.speed) : 0,
+      bpm: isSet(object.bpm) ? globalThis.Number(object.bpm) : 0,
+    };
+  },
+
+  toJSON(message: GetPlayerStatusResponse): unknown {
+    const obj: any = {};
+    if (message.queuedFrames !== 0) {
+      obj.queuedFrames = Math.round(message.queuedFrames);
+    }
+    if (message.maxFrames !== 0) {
+      obj.maxFrames = Math.round(message.maxFrames);
+    }
+    if (message.frameIndex !== undefined) {
+      obj.frameIndex = Math.round(message.frameIndex);
+    }
+    if (message.remainingMs !== 0) {
+      obj.remainingMs = Math.round(message.remainingMs);
+    }
+    if (message.looping === true) {
+      obj.looping = message.looping;
+    }
+    if (message.paused === true) {
+      obj.paused = message.paused;
+    }
+    if (message.speed !== 0) {
+      obj.speed = message.speed;
+    }
+    if (message.bpm !== 0) {
+      obj.bpm = message.bpm;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetPlayerStatusResponse>, I>>(base?: I): GetPlayerStatusResponse {
+    return GetPlayerStatusResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetPlayerStatusResponse>, I>>(object: I): GetPlayerStatusResponse {
+    const message = createBaseGetPlayerStatusResponse();
+    message.queuedFrames = object.queuedFrames ?? 0;
+    message.maxFrames = object.maxFrames ?? 0;
+    message.frameIndex = object.frameIndex ?? undefined;
+    message.remainingMs = object.remainingMs ?? 0;
+    message.looping = object.looping ?? false;
+    message.paused = object.paused ?? false;
+    message.speed = object.speed ?? 0;
+    message.bpm = object.bpm ?? 0;
+    return message;
+  },
+};
+
+function createBasePausePlaybackRequest(): PausePlaybackRequest {
+  return {};
+}
+
+export const PausePlaybackRequest = {
+  encode(_: PausePlaybackRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PausePlaybackRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePausePlaybackRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): PausePlaybackRequest {
+    return {};
+  },
+
+  toJSON(_: PausePlaybackRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PausePlaybackRequest>, I>>(base?: I): PausePlaybackRequest {
+    return PausePlaybackRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PausePlaybackRequest>, I>>(_: I): PausePlaybackRequest {
+    const message = createBasePausePlaybackRequest();
+    return message;
+  },
+};
+
+function createBaseResumePlaybackRequest(): ResumePlaybackRequest {
+  return {};
+}
+
+export const ResumePlaybackRequest = {
+  encode(_: ResumePlaybackRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ResumePlaybackRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseResumePlaybackRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): ResumePlaybackRequest {
+    return {};
+  },
+
+  toJSON(_: ResumePlaybackRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ResumePlaybackRequest>, I>>(base?: I): ResumePlaybackRequest {
+    return ResumePlaybackRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ResumePlaybackRequest>, I>>(_: I): ResumePlaybackRequest {
+    const message = createBaseResumePlaybackRequest();
+    return message;
+  },
+};
+
+function createBaseSeekPlaybackRequest(): SeekPlaybackRequest {
+  return { frameIndex: 0 };
+}
+
+export const SeekPlaybackRequest = {
+  encode(message: SeekPlaybackRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.frameIndex !== 0) {
+      writer.uint32(8).uint64(message.frameIndex);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SeekPlaybackRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSeekPlaybackRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.frameIndex = longToNumber(reader.uint64() as Long);
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SeekPlaybackRequest {
+    return { frameIndex: isSet(object.frameIndex) ? globalThis.Number(object.frameIndex) : 0 };
+  },
+
+  toJSON(message: SeekPlaybackRequest): unknown {
+    const obj: any = {};
+    if (message.frameIndex !== 0) {
+      obj.frameIndex = Math.round(message.frameIndex);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SeekPlaybackRequest>, I>>(base?: I): SeekPlaybackRequest {
+    return SeekPlaybackRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SeekPlaybackRequest>, I>>(object: I): SeekPlaybackRequest {
+    const message = createBaseSeekPlaybackRequest();
+    message.frameIndex = object.frameIndex ?? 0;
+    return message;
+  },
+};
+
+function createBaseSetPlaybackSpeedRequest(): SetPlaybackSpeedRequest {
+  return { speed: 0 };
+}
+
+export const SetPlaybackSpeedRequest = {
+  encode(message: SetPlaybackSpeedRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.speed !== 0) {
+      writer.uint32(9).double(message.speed);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SetPlaybackSpeedRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSetPlaybackSpeedRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 9) {
+            break;
+          }
+
+          message.speed = reader.double();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SetPlaybackSpeedRequest {
+    return { speed: isSet(object.speed) ? globalThis.Number(object.speed) : 0 };
+  },
+
+  toJSON(message: SetPlaybackSpeedRequest): unknown {
+    const obj: any = {};
+    if (message.speed !== 0) {
+      obj.speed = message.speed;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SetPlaybackSpeedRequest>, I>>(base?: I): SetPlaybackSpeedRequest {
+    return SetPlaybackSpeedRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SetPlaybackSpeedRequest>, I>>(object: I): SetPlaybackSpeedRequest {
+    const message = createBaseSetPlaybackSpeedRequest();
+    message.speed = object.speed ?? 0;
+    return message;
+  },
+};
+
+function createBaseSetTempoRequest(): SetTempoRequest {
+  return { bpm: 0, phase: undefined };
+}
+
+export const SetTempoRequest = {
+  encode(message: SetTempoRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.bpm !== 0) {
+      writer.uint32(9).double(message.bpm);
+    }
+    if (message.phase !== undefined) {
+      writer.uint32(17).double(message.phase);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SetTempoRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSetTempoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 9) {
+            break;
+          }
+
+          message.bpm = reader.double();
+          continue;
+        case 2:
+          if (tag !== 17) {
+            break;
+          }
+
+          message.phase = reader.double();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SetTempoRequest {
+    return {
+      bpm: isSet(object.bpm) ? globalThis.Number(object.bpm) : 0,
+      phase: isSet(object.phase) ? globalThis.Number(object.phase) : undefined,
+    };
+  },
+
+  toJSON(message: SetTempoRequest): unknown {
+    const obj: any = {};
+    if (message.bpm !== 0) {
+      obj.bpm = message.bpm;
+    }
+    if (message.phase !== undefined) {
+      obj.phase = message.phase;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SetTempoRequest>, I>>(base?: I): SetTempoRequest {
+    return SetTempoRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SetTempoRequest>, I>>(object: I): SetTempoRequest {
+    const message = createBaseSetTempoRequest();
+    message.bpm = object.bpm ?? 0;
+    message.phase = object.phase ?? undefined;
+    return message;
+  },
+};
+
+function createBaseTapTempoRequest(): TapTempoRequest {
+  return {};
+}
+
+export const TapTempoRequest = {
+  encode(_: TapTempoRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): TapTempoRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTapTempoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): TapTempoRequest {
+    return {};
+  },
+
+  toJSON(_: TapTempoRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<TapTempoRequest>, I>>(base?: I): TapTempoRequest {
+    return TapTempoRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<TapTempoRequest>, I>>(_: I): TapTempoRequest {
+    const message = createBaseTapTempoRequest();
+    return message;
+  },
+};
+
+function createBaseGetLayersRequest(): GetLayersRequest {
+  return {};
+}
+
+export const GetLayersRequest = {
+  encode(_: GetLayersRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetLayersRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetLayersRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): GetLayersRequest {
+    return {};
+  },
+
+  toJSON(_: GetLayersRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetLayersRequest>, I>>(base?: I): GetLayersRequest {
+    return GetLayersRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetLayersRequest>, I>>(_: I): GetLayersRequest {
+    const message = createBaseGetLayersRequest();
+    return message;
+  },
+};
+
+function createBaseGetLayersResponse(): GetLayersResponse {
+  return { layers: [] };
+}
+
+export const GetLayersResponse = {
+  encode(message: GetLayersResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.layers) {
+      Layer.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetLayersResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetLayersResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.layers.push(Layer.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetLayersResponse {
+    return { layers: globalThis.Array.isArray(object?.layers) ? object.layers.map((e: any) => Layer.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: GetLayersResponse): unknown {
+    const obj: any = {};
+    if (message.layers?.length) {
+      obj.layers = message.layers.map((e) => Layer.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetLayersResponse>, I>>(base?: I): GetLayersResponse {
+    return GetLayersResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetLayersResponse>, I>>(object: I): GetLayersResponse {
+    const message = createBaseGetLayersResponse();
+    message.layers = object.layers?.map((e) => Layer.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseLayer(): Layer {
+  return { name: "", opacity: 0, blend: 0, drawable: false, granted: false, regionLeds: [] };
+}
+
+export const Layer = {
+  encode(message: Layer, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.opacity !== 0) {
+      writer.uint32(17).double(message.opacity);
+    }
+    if (message.blend !== 0) {
+      writer.uint32(24).int32(message.blend);
+    }
+    if (message.drawable === true) {
+      writer.uint32(32).bool(message.drawable);
+    }
+    if (message.granted === true) {
+      writer.uint32(40).bool(message.granted);
+    }
+    writer.uint32(50).fork();
+    for (const v of message.regionLeds) {
+      writer.uint32(v);
+    }
+    writer.ldelim();
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Layer {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLayer();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 17) {
+            break;
+          }
+
+          message.opacity = reader.double();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.blend = reader.int32() as any;
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.drawable = reader.bool();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.granted = reader.bool();
+          continue;
+        case 6:
+          if (tag === 48) {
+            message.regionLeds.push(reader.uint32());
+
+            continue;
+          }
+
+          if (tag === 50) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.regionLeds.push(reader.uint32());
+            }
+
+            continue;
+          }
+
+          break;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Layer {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      opacity: isSet(object.opacity) ? globalThis.Number(object.opacity) : 0,
+      blend: isSet(object.blend) ? blendModeFromJSON(object.blend) : 0,
+      drawable: isSet(object.drawable) ? globalThis.Boolean(object.drawable) : false,
+      granted: isSet(object.granted) ? globalThis.Boolean(object.granted) : false,
+      regionLeds: globalThis.Array.isArray(object?.regionLeds)
+        ? object.regionLeds.map((e: any) => globalThis.Number(e))
+        : [],
+    };
+  },
+
+  toJSON(message: Layer): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.opacity !== 0) {
+      obj.opacity = message.opacity;
+    }
+    if (message.blend !== 0) {
+      obj.blend = blendModeToJSON(message.blend);
+    }
+    if (message.drawable === true) {
+      obj.drawable = message.drawable;
+    }
+    if (message.granted === true) {
+      obj.granted = message.granted;
+    }
+    if (message.regionLeds?.length) {
+      obj.regionLeds = message.regionLeds.map((e) => Math.round(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Layer>, I>>(base?: I): Layer {
+    return Layer.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Layer>, I>>(object: I): Layer {
+    const message = createBaseLayer();
+    message.name = object.name ?? "";
+    message.opacity = object.opacity ?? 0;
+    message.blend = object.blend ?? 0;
+    message.drawable = object.drawable ?? false;
+    message.granted = object.granted ?? false;
+    message.regionLeds = object.regionLeds?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseSetLayerRequest(): SetLayerRequest {
+  return { opacity: undefined, blend: undefined };
+}
+
+export const SetLayerRequest = {
+  encode(message: SetLayerRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.opacity !== undefined) {
+      writer.uint32(9).double(message.opacity);
+    }
+    if (message.blend !== undefined) {
+      writer.uint32(16).int32(message.blend);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SetLayerRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSetLayerRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 9) {
+            break;
+          }
+
+          message.opacity = reader.double();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.blend = reader.int32() as any;
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SetLayerRequest {
+    return {
+      opacity: isSet(object.opacity) ? globalThis.Number(object.opacity) : undefined,
+      blend: isSet(object.blend) ? blendModeFromJSON(object.blend) : undefined,
+    };
+  },
+
+  toJSON(message: SetLayerRequest): unknown {
+    const obj: any = {};
+    if (message.opacity !== undefined) {
+      obj.opacity = message.opacity;
+    }
+    if (message.blend !== undefined) {
+      obj.blend = blendModeToJSON(message.blend);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SetLayerRequest>, I>>(base?: I): SetLayerRequest {
+    return SetLayerRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SetLayerRequest>, I>>(object: I): SetLayerRequest {
+    const message = createBaseSetLayerRequest();
+    message.opacity = object.opacity ?? undefined;
+    message.blend = object.blend ?? undefined;
+    return message;
+  },
+};
+
+function createBaseListEffectsRequest(): ListEffectsRequest {
+  return {};
+}
+
+export const ListEffectsRequest = {
+  encode(_: ListEffectsRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListEffectsRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListEffectsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): ListEffectsRequest {
+    return {};
+  },
+
+  toJSON(_: ListEffectsRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ListEffectsRequest>, I>>(base?: I): ListEffectsRequest {
+    return ListEffectsRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ListEffectsRequest>, I>>(_: I): ListEffectsRequest {
+    const message = createBaseListEffectsRequest();
+    return message;
+  },
+};
+
+function createBaseListEffectsResponse(): ListEffectsResponse {
+  return { effects: [] };
+}
+
+export const ListEffectsResponse = {
+  encode(message: ListEffectsResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.effects) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListEffectsResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListEffectsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.effects.push(reader.string());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListEffectsResponse {
+    return {
+      effects: globalThis.Array.isArray(object?.effects) ? object.effects.map((e: any) => globalThis.String(e)) : [],
+    };
+  },
+
+  toJSON(message: ListEffectsResponse): unknown {
+    const obj: any = {};
+    if (message.effects?.length) {
+      obj.effects = message.effects;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ListEffectsResponse>, I>>(base?: I): ListEffectsResponse {
+    return ListEffectsResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ListEffectsResponse>, I>>(object: I): ListEffectsResponse {
+    const message = createBaseListEffectsResponse();
+    message.effects = object.effects?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBasePlayEffectRequest(): PlayEffectRequest {
+  return { name: "", durationMs: 0, intervalMs: 0, loop: false };
+}
+
+export const PlayEffectRequest = {
+  encode(message: PlayEffectRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.durationMs !== 0) {
+      writer.uint32(16).uint32(message.durationMs);
+    }
+    if (message.intervalMs !== 0) {
+      writer.uint32(24).uint32(message.intervalMs);
+    }
+    if (message.loop === true) {
+      writer.uint32(32).bool(message.loop);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlayEffectRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlayEffectRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.durationMs = reader.uint32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.intervalMs = reader.uint32();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.loop = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlayEffectRequest {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      durationMs: isSet(object.durationMs) ? globalThis.Number(object.durationMs) : 0,
+      intervalMs: isSet(object.intervalMs) ? globalThis.Number(object.intervalMs) : 0,
+      loop: isSet(object.loop) ? globalThis.Boolean(object.loop) : false,
+    };
+  },
+
+  toJSON(message: PlayEffectRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.durationMs !== 0) {
+      obj.durationMs = Math.round(message.durationMs);
+    }
+    if (message.intervalMs !== 0) {
+      obj.intervalMs = Math.round(message.intervalMs);
+    }
+    if (message.loop === true) {
+      obj.loop = message.loop;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PlayEffectRequest>, I>>(base?: I): PlayEffectRequest {
+    return PlayEffectRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PlayEffectRequest>, I>>(object: I): PlayEffectRequest {
+    const message = createBasePlayEffectRequest();
+    message.name = object.name ?? "";
+    message.durationMs = object.durationMs ?? 0;
+    message.intervalMs = object.intervalMs ?? 0;
+    message.loop = object.loop ?? false;
+    return message;
+  },
+};
+
+function createBasePlayScriptRequest(): PlayScriptRequest {
+  return { source: "", durationMs: 0, intervalMs: 0, loop: false };
+}
+
+export const PlayScriptRequest = {
+  encode(message: PlayScriptRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.source !== "") {
+      writer.uint32(10).string(message.source);
+    }
+    if (message.durationMs !== 0) {
+      writer.uint32(16).uint32(message.durationMs);
+    }
+    if (message.intervalMs !== 0) {
+      writer.uint32(24).uint32(message.intervalMs);
+    }
+    if (message.loop === true) {
+      writer.uint32(32).bool(message.loop);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlayScriptRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlayScriptRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.source = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.durationMs = reader.uint32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.intervalMs = reader.uint32();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.loop = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlayScriptRequest {
+    return {
+      source: isSet(object.source) ? globalThis.String(object.source) : "",
+      durationMs: isSet(object.durationMs) ? globalThis.Number(object.durationMs) : 0,
+      intervalMs: isSet(object.intervalMs) ? globalThis.Number(object.intervalMs) : 0,
+      loop: isSet(object.loop) ? globalThis.Boolean(object.loop) : false,
+    };
+  },
+
+  toJSON(message: PlayScriptRequest): unknown {
+    const obj: any = {};
+    if (message.source !== "") {
+      obj.source = message.source;
+    }
+    if (message.durationMs !== 0) {
+      obj.durationMs = Math.round(message.durationMs);
+    }
+    if (message.intervalMs !== 0) {
+      obj.intervalMs = Math.round(message.intervalMs);
+    }
+    if (message.loop === true) {
+      obj.loop = message.loop;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PlayScriptRequest>, I>>(base?: I): PlayScriptRequest {
+    return PlayScriptRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PlayScriptRequest>, I>>(object: I): PlayScriptRequest {
+    const message = createBasePlayScriptRequest();
+    message.source = object.source ?? "";
+    message.durationMs = object.durationMs ?? 0;
+    message.intervalMs = object.intervalMs ?? 0;
+    message.loop = object.loop ?? false;
+    return message;
+  },
+};
+
+function createBaseUploadAnimationRequest(): UploadAnimationRequest {
+  return { data: new Uint8Array(0) };
+}
+
+export const UploadAnimationRequest = {
+  encode(message: UploadAnimationRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.data.length !== 0) {
+      writer.uint32(10).bytes(message.data);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): UploadAnimationRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUploadAnimationRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.data = reader.bytes();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): UploadAnimationRequest {
+    return { data: isSet(object.data) ? bytesFromBase64(object.data) : new Uint8Array(0) };
+  },
+
+  toJSON(message: UploadAnimationRequest): unknown {
+    const obj: any = {};
+    if (message.data.length !== 0) {
+      obj.data = base64FromBytes(message.data);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<UploadAnimationRequest>, I>>(base?: I): UploadAnimationRequest {
+    return UploadAnimationRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<UploadAnimationRequest>, I>>(object: I): UploadAnimationRequest {
+    const message = createBaseUploadAnimationRequest();
+    message.data = object.data ?? new Uint8Array(0);
+    return message;
+  },
+};
+
 function bytesFromBase64(b64: string): Uint8Array {
   if (globalThis.Buffer) {
     return Uint8Array.from(globalThis.Buffer.from(b64, "base64"));
@@ -10,9 +10,12 @@ from . import christmas_pb2 as cp
 class TreeConnection:
     # one TreeConnection per credentials
     # if they change, make a new TreeConnection
-    def __init__(self, token: str, dest: str):
+    # layer is the layer that the canvas, LED and playback calls act on;
+    # empty is the default canvas
+    def __init__(self, token: str, dest: str, layer: str = ""):
         self.token = token
         self.dest = dest
+        self.layer = layer
         self.ws = None
         self.connected = False
         self.ix = 0  # image width
@@ -27,23 +30,22 @@ class TreeConnection:
         auth.secret = self.token
         if not auth.IsInitialized():
             raise ValueError("Failed to create AuthenticateRequest")
-        resp = self._send(auth)
-        if not resp.message.success:
+        resp = await self._send(auth)
+        if not resp.success:
             raise PermissionError("Invalid token")
         self.connected = True
         resp = await self._send(cp.GetLEDCanvasInfoRequest())
-        self.ix = resp.message.width
-        self.iy = resp.message.height
-        # TODO: use GetLEDs once that endpoint is made
+        self.ix = resp.width
+        self.iy = resp.height
         resp = await self._send(cp.GetLEDsRequest())
-        self.lc = len(resp.message.leds)
+        self.lc = len(resp.leds)
         
     async def send_image(self, img: np.array):
         self._check_connected()
         assert img.dtype == np.uint8
         assert img.size == self.ix * self.iy * 4
         msg = cp.SetLEDCanvasRequest()
-        msg.pixels = img
+        msg.pixels.pixels = img.tobytes()
         await self._send_lt(msg)
 
     async def send_raw_pixels(self, pxs: np.array):
@@ -51,22 +53,92 @@ class TreeConnection:
         assert pxs.dtype == np.uint64
         assert pxs.size == self.lc
         msg = cp.SetLEDsRequest()
-        msg.leds = pxs
+        msg.leds.extend(cp.Color(rgb=int(px)) for px in pxs)
         await self._send_lt(msg)
 
+    async def player_status(self) -> cp.GetPlayerStatusResponse:
+        self._check_connected()
+        return await self._send(cp.GetPlayerStatusRequest())
+
+    async def pause(self):
+        self._check_connected()
+        await self._send_lt(cp.PausePlaybackRequest())
+
+    async def resume(self):
+        self._check_connected()
+        await self._send_lt(cp.ResumePlaybackRequest())
+
+    async def seek(self, frame_index: int):
+        self._check_connected()
+        await self._send_lt(cp.SeekPlaybackRequest(frame_index=frame_index))
+
+    async def set_speed(self, speed: float):
+        self._check_connected()
+        await self._send_lt(cp.SetPlaybackSpeedRequest(speed=speed))
+
+    # phase, if given, lines the beat up with the music: 0 is on the beat
+    async def set_tempo(self, bpm: float, phase: float | None = None):
+        self._check_connected()
+        msg = cp.SetTempoRequest(bpm=bpm)
+        if phase is not None:
+            msg.phase = phase
+        await self._send_lt(msg)
+
+    async def tap_tempo(self):
+        self._check_connected()
+        await self._send_lt(cp.TapTempoRequest())
+
+    async def layers(self) -> list[cp.Layer]:
+        self._check_connected()
+        resp = await self._send(cp.GetLayersRequest())
+        return list(resp.layers)
+
+    # changes self.layer; opacity and blend are left alone if None
+    async def set_layer(self, opacity: float | None = None, blend: int | None = None):
+        self._check_connected()
+        msg = cp.SetLayerRequest()
+        if opacity is not None:
+            msg.opacity = opacity
+        if blend is not None:
+            msg.blend = blend
+        await self._send_lt(msg)
+
+    async def effects(self) -> list[str]:
+        self._check_connected()
+        resp = await self._send(cp.ListEffectsRequest())
+        return list(resp.effects)
+
+    # duration_ms and interval_ms of 0 use the server defaults
+    async def play_effect(self, name: str, duration_ms: int = 0, interval_ms: int = 0, loop: bool = False):
+        self._check_connected()
+        await self._send_lt(cp.PlayEffectRequest(
+            name=name, duration_ms=duration_ms, interval_ms=interval_ms, loop=loop))
+
+    # data is an animation file in either format of the ledanim package
+    async def upload_animation(self, data: bytes):
+        self._check_connected()
+        await self._send_lt(cp.UploadAnimationRequest(data=data))
+
+    async def play_script(self, source: str, duration_ms: int = 0, interval_ms: int = 0, loop: bool = False):
+        self._check_connected()
+        await self._send_lt(cp.PlayScriptRequest(
+            source=source, duration_ms=duration_ms, interval_ms=interval_ms, loop=loop))
+
     async def close(self):
         # because .close() is idempotent, no need to _check_connected()
         await self.ws.close()
         self.connected = False
         
-    async def _send(self, msg) -> cp.LEDServerMessage:
+    # returns the response that is set in the server message
+    async def _send(self, msg):
         await self._send_lt(msg)
-        resp = cp.LEDServerMessage.ParseFromString(await self.ws.recv())
+        resp = cp.LEDServerMessage()
+        resp.ParseFromString(await self.ws.recv())
         _check_error(resp)
-        return resp
+        return getattr(resp, resp.WhichOneof("message"))
 
     async def _send_lt(self, msg):
-        await self.ws.send(_form_msg(msg).SerializeToString)
+        await self.ws.send(_form_msg(msg, self.layer).SerializeToString())
 
     def _check_connected(self):
         if not self.connected:
@@ -74,11 +146,18 @@ class TreeConnection:
         
         
 def _check_error(resp):    
-    if hasattr(resp, "error"):
+    if resp.HasField("error"):
         raise Exception(resp.error)
 
 
-def _form_msg(msg):
+def _form_msg(msg, layer=""):
     cmsg = cp.LEDClientMessage()
-    cmsg.message = msg
+    # find the field of the message oneof that holds this type of request
+    for field in cmsg.DESCRIPTOR.oneofs_by_name["message"].fields:
+        if field.message_type is msg.DESCRIPTOR:
+            getattr(cmsg, field.name).CopyFrom(msg)
+            break
+    else:
+        raise TypeError(f"{type(msg).__name__} is not a client request")
+    cmsg.layer = layer
     return cmsg
@@ -1,15 +1,26 @@
 package christmasd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"slices"
 	"sync/atomic"
+	"time"
 
 	"github.com/gobwas/ws"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/typ.v4/sync2"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/christmas/go/christmaspb"
+	"libdb.so/acm-christmas/lib/effects"
+	"libdb.so/acm-christmas/lib/ledanim"
+	"libdb.so/acm-christmas/lib/leddraw"
 )
 
 // Config is the configuration for handling.
@@ -17,6 +28,38 @@ type Config struct {
 	// Secret is the secret to use for the server.
 	// The secret is used to authenticate the client.
 	Secret string
+	// Grants maps additional secrets to what the clients that authenticate
+	// with them may control. Clients that authenticate with Secret may
+	// control everything.
+	Grants map[string]Grant
+	// Scripts maps the names of effect scripts to their Starlark source.
+	// Clients can list and play them like the built-in effects, which they
+	// replace if they have the same name. See effects.Script.
+	Scripts map[string]string
+	// ScriptOpts limits the effect scripts, both those in Scripts and those
+	// that clients send.
+	ScriptOpts effects.ScriptOpts
+}
+
+// Grant limits a client to some layers of the compositor. Since a layer can
+// be limited to a region of the tree, this lets several clients each paint
+// their own part of the tree at once.
+type Grant struct {
+	// Layers are the names of the layers that the client may target. The
+	// client cannot target the default canvas or any other layer.
+	Layers []string
+}
+
+func (g *Grant) allows(layer string) bool {
+	if g == nil {
+		return true
+	}
+	for _, name := range g.Layers {
+		if name == layer {
+			return true
+		}
+	}
+	return false
 }
 
 // ServerOpts are options for a server.
@@ -25,6 +68,16 @@ type ServerOpts struct {
 	Logger *slog.Logger
 	// HTTPUpgrader is the HTTP-to-Websocket upgrader to use for the server.
 	HTTPUpgrader ws.HTTPUpgrader
+	// Canvas is the animated LED canvas that the server controls. Requests
+	// that need it fail if it is nil.
+	Canvas *leddraw.LEDCanvasAnimated
+	// Compositor, if not nil, is the compositor whose layers clients can list
+	// and change.
+	Compositor *leddraw.Compositor
+	// Layers are the canvases of the compositor layers that clients can draw
+	// to, by name. Messages that name a layer act on its canvas instead of
+	// Canvas.
+	Layers map[string]*leddraw.LEDCanvasAnimated
 }
 
 // Server handles all HTTP requests for the server.
@@ -96,20 +149,31 @@ func (s *Server) upgrade(w http.ResponseWriter, r *http.Request) (*Session, erro
 		"local_addr", wsconn.LocalAddr(),
 		"remote_addr", wsconn.RemoteAddr())
 
-	return &Session{
-		ws:     newWebsocketServer(wsconn, logger),
-		logger: logger,
-		cfg:    *s.cfg.Load(),
-	}, nil
+	return newSession(newWebsocketServer(wsconn, logger), logger, *s.cfg.Load(), s.opts), nil
 }
 
 // Session is a websocket session. It implements handling of messages from a
 // single client.
 type Session struct {
-	ws     *websocketServer
-	logger *slog.Logger
+	ws         *websocketServer
+	logger     *slog.Logger
+	canvas     *leddraw.LEDCanvasAnimated
+	compositor *leddraw.Compositor
+	layers     map[string]*leddraw.LEDCanvasAnimated
 
-	cfg Config
+	cfg   Config
+	grant *Grant // nil if the client may control everything
+}
+
+func newSession(ws *websocketServer, logger *slog.Logger, cfg Config, opts ServerOpts) *Session {
+	return &Session{
+		ws:         ws,
+		logger:     logger,
+		canvas:     opts.Canvas,
+		compositor: opts.Compositor,
+		layers:     opts.Layers,
+		cfg:        cfg,
+	}
 }
 
 // Start starts the server.
@@ -138,6 +202,10 @@ func (s *Session) Start(ctx context.Context) error {
 var (
 	errNotAuthenticated = fmt.Errorf("not authenticated")
 	errInvalidSecret    = fmt.Errorf("invalid secret")
+	errNoCanvas         = fmt.Errorf("server has no LED canvas")
+	errNoCompositor     = fmt.Errorf("server has no compositor")
+	errUnknownLayer     = fmt.Errorf("unknown layer")
+	errLayerNotGranted  = fmt.Errorf("not allowed to control layer")
 )
 
 func (s *Session) mainLoop(ctx context.Context) error {
@@ -157,7 +225,11 @@ func (s *Session) mainLoop(ctx context.Context) error {
 					return errNotAuthenticated
 				}
 				if auth.Secret != s.cfg.Secret {
-					return errInvalidSecret
+					grant, ok := s.cfg.Grants[auth.Secret]
+					if !ok {
+						return errInvalidSecret
+					}
+					s.grant = &grant
 				}
 				authenticated = true
 
@@ -165,12 +237,321 @@ func (s *Session) mainLoop(ctx context.Context) error {
 					"new client authenticated")
 			}
 
-			// switch msg := msg.GetMessage().(type) {
-			// case *christmaspb.LEDClientMessage_GetLedCanvasInfo:
-			// case *christmaspb.LEDClientMessage_SetLedCanvas:
-			// case *christmaspb.LEDClientMessage_GetLeds:
-			// case *christmaspb.LEDClientMessage_SetLeds:
-			// }
+			if err := s.handleMessage(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// layerCanvas returns the canvas of the named layer, or the default canvas if
+// name is empty.
+func (s *Session) layerCanvas(name string) (*leddraw.LEDCanvasAnimated, error) {
+	if !s.grant.allows(name) {
+		return nil, fmt.Errorf("%w %q", errLayerNotGranted, name)
+	}
+
+	if name == "" {
+		if s.canvas == nil {
+			return nil, errNoCanvas
+		}
+		return s.canvas, nil
+	}
+
+	canvas, ok := s.layers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errUnknownLayer, name)
+	}
+	return canvas, nil
+}
+
+func (s *Session) handleMessage(ctx context.Context, clientMsg *christmaspb.LEDClientMessage) error {
+	// Only the messages that act on a canvas return canvasErr.
+	canvas, canvasErr := s.layerCanvas(clientMsg.GetLayer())
+
+	switch msg := clientMsg.GetMessage().(type) {
+	// case *christmaspb.LEDClientMessage_GetLedCanvasInfo:
+	// case *christmaspb.LEDClientMessage_SetLedCanvas:
+	// case *christmaspb.LEDClientMessage_GetLeds:
+	// case *christmaspb.LEDClientMessage_SetLeds:
+	case *christmaspb.LEDClientMessage_GetPlayerStatus:
+		if canvasErr != nil {
+			return canvasErr
+		}
+
+		status := canvas.Status()
+
+		resp := &christmaspb.GetPlayerStatusResponse{
+			QueuedFrames: uint32(status.Queued),
+			MaxFrames:    uint32(status.MaxFrames),
+			RemainingMs:  uint32(status.Remaining.Milliseconds()),
+			Looping:      status.Looping,
+			Paused:       status.Paused,
+			Speed:        status.Speed,
+			Bpm:          status.BPM,
+		}
+		if status.FrameIndex >= 0 {
+			resp.FrameIndex = proto.Uint64(uint64(status.FrameIndex))
+		}
+
+		return s.ws.Send(ctx, &christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetPlayerStatus{
+				GetPlayerStatus: resp,
+			},
+		})
+
+	case *christmaspb.LEDClientMessage_PausePlayback:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		return canvas.Pause(ctx)
+
+	case *christmaspb.LEDClientMessage_ResumePlayback:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		return canvas.Resume(ctx)
+
+	case *christmaspb.LEDClientMessage_SeekPlayback:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		index := msg.SeekPlayback.GetFrameIndex()
+		if index > math.MaxInt64 {
+			return fmt.Errorf("cannot seek to frame %d: %w", index, animation.ErrFrameNotHeld)
+		}
+		if err := canvas.Seek(ctx, int64(index)); err != nil {
+			return fmt.Errorf("cannot seek to frame %d: %w", index, err)
+		}
+
+	case *christmaspb.LEDClientMessage_SetPlaybackSpeed:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		if err := canvas.SetSpeed(ctx, msg.SetPlaybackSpeed.GetSpeed()); err != nil {
+			return fmt.Errorf("cannot set playback speed: %w", err)
+		}
+
+	case *christmaspb.LEDClientMessage_SetTempo:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		beats := canvas.Beats()
+		if err := beats.SetTempo(msg.SetTempo.GetBpm()); err != nil {
+			return fmt.Errorf("cannot set tempo: %w", err)
+		}
+		if msg.SetTempo.Phase != nil {
+			if err := beats.SetPhase(msg.SetTempo.GetPhase()); err != nil {
+				return fmt.Errorf("cannot set tempo: %w", err)
+			}
+		}
+
+	case *christmaspb.LEDClientMessage_TapTempo:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		canvas.Beats().Tap()
+
+	case *christmaspb.LEDClientMessage_GetLayers:
+		if s.compositor == nil {
+			return errNoCompositor
+		}
+
+		layers := s.compositor.Layers()
+		resp := &christmaspb.GetLayersResponse{
+			Layers: make([]*christmaspb.Layer, len(layers)),
+		}
+		for i, layer := range layers {
+			_, drawable := s.layers[layer.Name()]
+			resp.Layers[i] = &christmaspb.Layer{
+				Name:     layer.Name(),
+				Opacity:  layer.Opacity(),
+				Blend:    blendModeToProto(layer.Blend()),
+				Drawable: drawable,
+				Granted:  s.grant.allows(layer.Name()),
+			}
+			if region := layer.Region(); region != nil {
+				resp.Layers[i].RegionLeds = make([]uint32, len(region.LEDs()))
+				for j, led := range region.LEDs() {
+					resp.Layers[i].RegionLeds[j] = uint32(led)
+				}
+			}
+		}
+
+		return s.ws.Send(ctx, &christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetLayers{
+				GetLayers: resp,
+			},
+		})
+
+	case *christmaspb.LEDClientMessage_SetLayer:
+		if s.compositor == nil {
+			return errNoCompositor
+		}
+
+		name := clientMsg.GetLayer()
+		if !s.grant.allows(name) {
+			return fmt.Errorf("%w %q", errLayerNotGranted, name)
+		}
+
+		layer := s.compositor.Layer(name)
+		if layer == nil {
+			return fmt.Errorf("%w %q", errUnknownLayer, name)
+		}
+
+		if msg.SetLayer.Opacity != nil {
+			if err := layer.SetOpacity(msg.SetLayer.GetOpacity()); err != nil {
+				return fmt.Errorf("cannot set layer %q: %w", name, err)
+			}
+		}
+		if msg.SetLayer.Blend != nil {
+			mode, err := blendModeFromProto(msg.SetLayer.GetBlend())
+			if err != nil {
+				return fmt.Errorf("cannot set layer %q: %w", name, err)
+			}
+			if err := layer.SetBlend(mode); err != nil {
+				return fmt.Errorf("cannot set layer %q: %w", name, err)
+			}
+		}
+
+	case *christmaspb.LEDClientMessage_ListEffects:
+		return s.ws.Send(ctx, &christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_ListEffects{
+				ListEffects: &christmaspb.ListEffectsResponse{
+					Effects: s.effectNames(),
+				},
+			},
+		})
+
+	case *christmaspb.LEDClientMessage_PlayEffect:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		req := msg.PlayEffect
+		effect, err := s.effect(req.GetName())
+		if err == nil {
+			err = playEffect(ctx, canvas, effect, req.GetDurationMs(), req.GetIntervalMs(), req.GetLoop())
+		}
+		if err != nil {
+			return fmt.Errorf("cannot play effect %q: %w", req.GetName(), err)
+		}
+
+	case *christmaspb.LEDClientMessage_PlayScript:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		req := msg.PlayScript
+		script, err := effects.NewScript("script", []byte(req.GetSource()), s.cfg.ScriptOpts)
+		if err == nil {
+			err = playEffect(ctx, canvas, script, req.GetDurationMs(), req.GetIntervalMs(), req.GetLoop())
+		}
+		if err != nil {
+			return fmt.Errorf("cannot play script: %w", err)
+		}
+
+	case *christmaspb.LEDClientMessage_UploadAnimation:
+		if canvasErr != nil {
+			return canvasErr
+		}
+		if err := uploadAnimation(ctx, canvas, msg.UploadAnimation.GetData()); err != nil {
+			return fmt.Errorf("cannot upload animation: %w", err)
+		}
+	}
+	return nil
+}
+
+func uploadAnimation(ctx context.Context, canvas *leddraw.LEDCanvasAnimated, data []byte) error {
+	anim, err := ledanim.Read(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if err := anim.CheckPositions(canvas.LEDPositions()); err != nil {
+		return err
+	}
+
+	// Like with effects, the frames must fit in the player.
+	if maxFrames := canvas.Status().MaxFrames; len(anim.Frames) > maxFrames {
+		return fmt.Errorf("%d frames do not fit in the player, max %d", len(anim.Frames), maxFrames)
+	}
+
+	return canvas.ReplaceLEDFrames(ctx, anim.Frames)
+}
+
+// effectNames returns the names of the built-in effects and the effect
+// scripts in sorted order.
+func (s *Session) effectNames() []string {
+	names := effects.Presets()
+	for name := range s.cfg.Scripts {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
 		}
 	}
+	slices.Sort(names)
+	return names
+}
+
+// effect returns the effect script or built-in effect with the given name.
+func (s *Session) effect(name string) (effects.Effect, error) {
+	if src, ok := s.cfg.Scripts[name]; ok {
+		return effects.NewScript(name, []byte(src), s.cfg.ScriptOpts)
+	}
+	return effects.Preset(name)
+}
+
+func playEffect(ctx context.Context, canvas *leddraw.LEDCanvasAnimated, effect effects.Effect, durationMs, intervalMs uint32, loop bool) error {
+	interval := 50 * time.Millisecond
+	if intervalMs > 0 {
+		interval = time.Duration(intervalMs) * time.Millisecond
+	}
+
+	// The frames must fit in the player, or else replacing the frames would
+	// block until they have been played.
+	maxFrames := canvas.Status().MaxFrames
+	duration := time.Duration(maxFrames) * interval
+	if durationMs > 0 {
+		duration = time.Duration(durationMs) * time.Millisecond
+	}
+	if n := duration / interval; n > time.Duration(maxFrames) {
+		return fmt.Errorf("%d frames do not fit in the player, max %d", n, maxFrames)
+	}
+
+	frames, err := effects.Animate(effect, effects.NewLayout(canvas.LEDPositions()), effects.AnimateOpts{
+		Duration: duration,
+		Interval: interval,
+		Loop:     loop,
+	})
+	if err != nil {
+		return err
+	}
+
+	return canvas.ReplaceLEDFrames(ctx, frames)
+}
+
+func blendModeFromProto(mode christmaspb.BlendMode) (xcolor.BlendMode, error) {
+	switch mode {
+	case christmaspb.BlendMode_BLEND_MODE_NORMAL:
+		return xcolor.BlendNormal, nil
+	case christmaspb.BlendMode_BLEND_MODE_ADD:
+		return xcolor.BlendAdd, nil
+	case christmaspb.BlendMode_BLEND_MODE_MULTIPLY:
+		return xcolor.BlendMultiply, nil
+	case christmaspb.BlendMode_BLEND_MODE_SCREEN:
+		return xcolor.BlendScreen, nil
+	default:
+		return 0, fmt.Errorf("invalid blend mode %v", mode)
+	}
+}
+
+func blendModeToProto(mode xcolor.BlendMode) christmaspb.BlendMode {
+	switch mode {
+	case xcolor.BlendAdd:
+		return christmaspb.BlendMode_BLEND_MODE_ADD
+	case xcolor.BlendMultiply:
+		return christmaspb.BlendMode_BLEND_MODE_MULTIPLY
+	case xcolor.BlendScreen:
+		return christmaspb.BlendMode_BLEND_MODE_SCREEN
+	default:
+		return christmaspb.BlendMode_BLEND_MODE_NORMAL
+	}
 }
@@ -1,9 +1,13 @@
 package christmasd
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"image"
 	"io"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/gobwas/ws/wsutil"
@@ -11,11 +15,16 @@ import (
 	"github.com/neilotoole/slogt"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/internal/xcolor"
 	"libdb.so/acm-christmas/lib/christmas/go/christmaspb"
+	"libdb.so/acm-christmas/lib/effects"
+	"libdb.so/acm-christmas/lib/ledanim"
+	"libdb.so/acm-christmas/lib/leddraw"
 )
 
 func TestSession(t *testing.T) {
-	conn := startTestSession(t, Config{Secret: "test"})
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{})
 
 	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
 		Message: &christmaspb.LEDClientMessage_Authenticate{
@@ -30,6 +39,520 @@ func TestSession(t *testing.T) {
 	expectCloseFrame(t, conn)
 }
 
+func TestSessionPlayerStatus(t *testing.T) {
+	canvas := newTestCanvas(t)
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{Canvas: canvas})
+
+	authenticateTestSession(t, conn, "test")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetPlayerStatus{
+				GetPlayerStatus: &christmaspb.GetPlayerStatusResponse{
+					MaxFrames: 100,
+					Speed:     1,
+					Bpm:       120,
+				},
+			},
+		},
+		readServerMessage(t, conn))
+}
+
+func TestSessionPlaybackControl(t *testing.T) {
+	canvas := newTestCanvas(t)
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{Canvas: canvas})
+
+	authenticateTestSession(t, conn, "test")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_SetPlaybackSpeed{
+			SetPlaybackSpeed: &christmaspb.SetPlaybackSpeedRequest{Speed: 2},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_SetTempo{
+			SetTempo: &christmaspb.SetTempoRequest{Bpm: 90, Phase: proto.Float64(0)},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetPlayerStatus{
+				GetPlayerStatus: &christmaspb.GetPlayerStatusResponse{
+					MaxFrames: 100,
+					Paused:    true,
+					Speed:     2,
+					Bpm:       90,
+				},
+			},
+		},
+		readServerMessage(t, conn))
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_ResumePlayback{
+			ResumePlayback: &christmaspb.ResumePlaybackRequest{},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetPlayerStatus{
+				GetPlayerStatus: &christmaspb.GetPlayerStatusResponse{
+					MaxFrames: 100,
+					Speed:     2,
+					Bpm:       90,
+				},
+			},
+		},
+		readServerMessage(t, conn))
+
+	// Seeking to a frame that was never added is an error.
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_SeekPlayback{
+			SeekPlayback: &christmaspb.SeekPlaybackRequest{FrameIndex: 5},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Error: proto.String("cannot seek to frame 5: frame is not held by the player"),
+		},
+		readServerMessage(t, conn))
+	expectCloseFrame(t, conn)
+}
+
+func TestSessionTempoRange(t *testing.T) {
+	tests := []struct {
+		bpm float64
+		err string
+	}{
+		{1e-10, "cannot set tempo: tempo 1e-10 BPM is not between 1 and 1000"},
+		{1e12, "cannot set tempo: tempo 1e+12 BPM is not between 1 and 1000"},
+	}
+
+	for _, test := range tests {
+		canvas := newTestCanvas(t)
+		conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{Canvas: canvas})
+
+		authenticateTestSession(t, conn, "test")
+		writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+			Message: &christmaspb.LEDClientMessage_SetTempo{
+				SetTempo: &christmaspb.SetTempoRequest{Bpm: test.bpm},
+			},
+		})
+		assertEq(t,
+			&christmaspb.LEDServerMessage{Error: proto.String(test.err)},
+			readServerMessage(t, conn))
+		expectCloseFrame(t, conn)
+	}
+}
+
+func TestSessionLayers(t *testing.T) {
+	canvas := newTestCanvas(t)
+	overlay := newTestCanvas(t)
+
+	compositor := leddraw.NewCompositor(3)
+	_, err := compositor.AddLayer("background", leddraw.LayerOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = compositor.AddLayer("overlay", leddraw.LayerOpts{Blend: xcolor.BlendAdd})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{
+		Canvas:     canvas,
+		Compositor: compositor,
+		Layers:     map[string]*leddraw.LEDCanvasAnimated{"overlay": overlay},
+	})
+
+	authenticateTestSession(t, conn, "test")
+
+	// Playback messages act on the canvas of the named layer.
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_SetPlaybackSpeed{
+			SetPlaybackSpeed: &christmaspb.SetPlaybackSpeedRequest{Speed: 3},
+		},
+		Layer: "overlay",
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_SetLayer{
+			SetLayer: &christmaspb.SetLayerRequest{
+				Opacity: proto.Float64(0.5),
+				Blend:   christmaspb.BlendMode_BLEND_MODE_SCREEN.Enum(),
+			},
+		},
+		Layer: "overlay",
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetLayers{
+			GetLayers: &christmaspb.GetLayersRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetLayers{
+				GetLayers: &christmaspb.GetLayersResponse{
+					Layers: []*christmaspb.Layer{
+						{Name: "background", Opacity: 1, Granted: true},
+						{
+							Name:     "overlay",
+							Opacity:  0.5,
+							Blend:    christmaspb.BlendMode_BLEND_MODE_SCREEN,
+							Drawable: true,
+							Granted:  true,
+						},
+					},
+				},
+			},
+		},
+		readServerMessage(t, conn))
+
+	if speed := overlay.Status().Speed; speed != 3 {
+		t.Errorf("overlay speed = %v, want 3", speed)
+	}
+	if speed := canvas.Status().Speed; speed != 1 {
+		t.Errorf("default canvas speed = %v, want 1", speed)
+	}
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+		Layer: "missing",
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Error: proto.String(`unknown layer "missing"`),
+		},
+		readServerMessage(t, conn))
+	expectCloseFrame(t, conn)
+}
+
+func TestSessionGrants(t *testing.T) {
+	canvas := newTestCanvas(t)
+	left := newTestCanvas(t)
+	right := newTestCanvas(t)
+
+	// The test canvas has LEDs at (0, 0), (10, 10) and (20, 0).
+	ledPositions := []image.Point{{0, 0}, {10, 10}, {20, 0}}
+	leftRegion := leddraw.NewRegion("left", ledPositions, leddraw.Rectangle(image.Rect(0, 0, 10, 20)))
+	rightRegion := leddraw.NewRegion("right", ledPositions, leddraw.Rectangle(image.Rect(10, 0, 30, 20)))
+
+	compositor := leddraw.NewCompositor(3)
+	for _, region := range []*leddraw.Region{leftRegion, rightRegion} {
+		if _, err := compositor.AddLayer(region.Name(), leddraw.LayerOpts{Region: region}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := Config{
+		Secret: "admin",
+		Grants: map[string]Grant{
+			"left": {Layers: []string{"left"}},
+		},
+	}
+	conn := startTestSession(t, cfg, ServerOpts{
+		Canvas:     canvas,
+		Compositor: compositor,
+		Layers: map[string]*leddraw.LEDCanvasAnimated{
+			"left":  left,
+			"right": right,
+		},
+	})
+
+	authenticateTestSession(t, conn, "left")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+		Layer: "left",
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetLayers{
+			GetLayers: &christmaspb.GetLayersRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_GetLayers{
+				GetLayers: &christmaspb.GetLayersResponse{
+					Layers: []*christmaspb.Layer{
+						{
+							Name:       "left",
+							Opacity:    1,
+							Drawable:   true,
+							Granted:    true,
+							RegionLeds: []uint32{0},
+						},
+						{
+							Name:       "right",
+							Opacity:    1,
+							Drawable:   true,
+							RegionLeds: []uint32{1, 2},
+						},
+					},
+				},
+			},
+		},
+		readServerMessage(t, conn))
+
+	if !left.Status().Paused {
+		t.Error("left canvas is not paused")
+	}
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+		Layer: "right",
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Error: proto.String(`not allowed to control layer "right"`),
+		},
+		readServerMessage(t, conn))
+	expectCloseFrame(t, conn)
+
+	if right.Status().Paused {
+		t.Error("right canvas is paused")
+	}
+}
+
+func TestSessionEffects(t *testing.T) {
+	canvas := newTestCanvas(t)
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{Canvas: canvas})
+
+	authenticateTestSession(t, conn, "test")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_ListEffects{
+			ListEffects: &christmaspb.ListEffectsRequest{},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Message: &christmaspb.LEDServerMessage_ListEffects{
+				ListEffects: &christmaspb.ListEffectsResponse{
+					Effects: effects.Presets(),
+				},
+			},
+		},
+		readServerMessage(t, conn))
+
+	// The whole player is filled with a looping rainbow by default.
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PlayEffect{
+			PlayEffect: &christmaspb.PlayEffectRequest{Name: "rainbow", Loop: true},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+
+	status := readServerMessage(t, conn).GetGetPlayerStatus()
+	if status == nil {
+		t.Fatal("expected player status")
+	}
+	if !status.Looping {
+		t.Error("effect is not looping")
+	}
+	if status.QueuedFrames+uint32(status.GetFrameIndex()) < 99 {
+		t.Errorf("expected 100 frames, got %d queued after frame %d",
+			status.QueuedFrames, status.GetFrameIndex())
+	}
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PlayEffect{
+			PlayEffect: &christmaspb.PlayEffectRequest{Name: "rainbow", DurationMs: 10000},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Error: proto.String(`cannot play effect "rainbow": 200 frames do not fit in the player, max 100`),
+		},
+		readServerMessage(t, conn))
+	expectCloseFrame(t, conn)
+}
+
+func TestSessionScripts(t *testing.T) {
+	canvas := newTestCanvas(t)
+	conn := startTestSession(t, Config{
+		Secret: "test",
+		Scripts: map[string]string{
+			"white": "def led(i, x, y, t):\n    return rgb(1, 1, 1)",
+		},
+	}, ServerOpts{Canvas: canvas})
+
+	authenticateTestSession(t, conn, "test")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_ListEffects{
+			ListEffects: &christmaspb.ListEffectsRequest{},
+		},
+	})
+	names := readServerMessage(t, conn).GetListEffects().GetEffects()
+	if !slices.Contains(names, "white") || !slices.Contains(names, "rainbow") || !slices.IsSorted(names) {
+		t.Errorf("unexpected effects %q", names)
+	}
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PlayEffect{
+			PlayEffect: &christmaspb.PlayEffectRequest{Name: "white", DurationMs: 100},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PlayScript{
+			PlayScript: &christmaspb.PlayScriptRequest{
+				Source: "def led(i, x, y, t):\n    return hsv(x, 1, 1)",
+				Loop:   true,
+			},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+	if status := readServerMessage(t, conn).GetGetPlayerStatus(); !status.GetLooping() {
+		t.Error("script is not looping")
+	}
+
+	// Scripts that run for too long are stopped.
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PlayScript{
+			PlayScript: &christmaspb.PlayScriptRequest{
+				Source: "def led(i, x, y, t):\n    return [j for j in range(10000000)] and None",
+			},
+		},
+	})
+	msg := readServerMessage(t, conn)
+	if !strings.HasPrefix(msg.GetError(), `cannot play script: script "script": at 0s: Starlark computation cancelled: too many steps`) {
+		t.Errorf("unexpected error %q", msg.GetError())
+	}
+	expectCloseFrame(t, conn)
+}
+
+func TestSessionAnimations(t *testing.T) {
+	canvas := newTestCanvas(t)
+	conn := startTestSession(t, Config{Secret: "test"}, ServerOpts{Canvas: canvas})
+
+	// The animation is made for the same LEDs, only moved.
+	positions := []image.Point{{100, 50}, {110, 60}, {120, 50}}
+	anim := &ledanim.File{
+		LEDCount:     3,
+		PositionHash: ledanim.HashPositions(positions),
+		Frames: []animation.Frame[leddraw.LEDStrip]{
+			{Image: leddraw.LEDStrip{{R: 0xFF}, {G: 0xFF}, {B: 0xFF}}},
+			{Image: leddraw.LEDStrip{{}, {}, {}}, DurationMs: 100, JumpBackAmount: 1},
+		},
+	}
+	encode := func(anim *ledanim.File) []byte {
+		var buf bytes.Buffer
+		if err := ledanim.Write(&buf, anim, ledanim.FormatBinary); err != nil {
+			t.Fatal("cannot encode animation:", err)
+		}
+		return buf.Bytes()
+	}
+
+	authenticateTestSession(t, conn, "test")
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_UploadAnimation{
+			UploadAnimation: &christmaspb.UploadAnimationRequest{Data: encode(anim)},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_PausePlayback{
+			PausePlayback: &christmaspb.PausePlaybackRequest{},
+		},
+	})
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_GetPlayerStatus{
+			GetPlayerStatus: &christmaspb.GetPlayerStatusRequest{},
+		},
+	})
+
+	status := readServerMessage(t, conn).GetGetPlayerStatus()
+	if status == nil {
+		t.Fatal("expected player status")
+	}
+	if !status.Looping {
+		t.Error("animation is not looping")
+	}
+
+	positions[1].Y++
+	anim.PositionHash = ledanim.HashPositions(positions)
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_UploadAnimation{
+			UploadAnimation: &christmaspb.UploadAnimationRequest{Data: encode(anim)},
+		},
+	})
+	assertEq(t,
+		&christmaspb.LEDServerMessage{
+			Error: proto.String("cannot upload animation: " + ledanim.ErrPositionMismatch.Error()),
+		},
+		readServerMessage(t, conn))
+	expectCloseFrame(t, conn)
+}
+
+func newTestCanvas(t *testing.T) *leddraw.LEDCanvasAnimated {
+	t.Helper()
+
+	canvas, err := leddraw.NewLEDCanvasAnimated(
+		[]image.Point{{0, 0}, {10, 10}, {20, 0}},
+		leddraw.LEDCanvasAnimatedOpts{
+			LEDCanvasOpts: leddraw.LEDCanvasOpts{PPI: 16},
+		})
+	if err != nil {
+		t.Fatal("cannot create canvas:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go canvas.Run(ctx)
+
+	return canvas
+}
+
+func authenticateTestSession(t *testing.T, conn combinedPipe, secret string) {
+	t.Helper()
+
+	writeClientMessage(t, conn, &christmaspb.LEDClientMessage{
+		Message: &christmaspb.LEDClientMessage_Authenticate{
+			Authenticate: &christmaspb.AuthenticateRequest{
+				Secret: secret,
+			},
+		},
+	})
+}
+
 func writeClientMessage(t *testing.T, conn combinedPipe, msg *christmaspb.LEDClientMessage) {
 	t.Helper()
 
@@ -83,7 +606,7 @@ func expectCloseFrame(t *testing.T, conn combinedPipe) {
 	// See wsutil/handler.go @ ControlHandler.HandleClose.
 }
 
-func startTestSession(t *testing.T, cfg Config) combinedPipe {
+func startTestSession(t *testing.T, cfg Config, opts ServerOpts) combinedPipe {
 	t.Helper()
 
 	r1, w1 := io.Pipe()
@@ -100,11 +623,7 @@ func startTestSession(t *testing.T, cfg Config) combinedPipe {
 
 	logger := slogt.New(t)
 
-	session := &Session{
-		ws:     newWebsocketServer(conn1, logger),
-		logger: logger,
-		cfg:    cfg,
-	}
+	session := newSession(newWebsocketServer(conn1, logger), logger, cfg, opts)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
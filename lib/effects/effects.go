@@ -0,0 +1,214 @@
+// Package effects contains procedural animations that are drawn from the
+// positions of the LEDs on the tree, such as rainbows, fire and snowfall.
+package effects
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"time"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// Effect is an animation that can be drawn at any point in time.
+type Effect interface {
+	// Render draws the effect at time t onto dst, which has one color for
+	// each LED in layout. Render must only depend on its arguments, so that
+	// the same time always draws the same image.
+	Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration)
+}
+
+// Point is a normalized LED position. Y goes from 0 at the bottom of the tree
+// to 1 at the top. X is 0 on the vertical axis through the middle of the tree
+// and uses the same scale as Y, so that distances are the same in all
+// directions.
+type Point struct {
+	X, Y float64
+}
+
+// Point3 is a normalized 3D LED position. X and Y are the same as in Point,
+// and Z uses the same scale, from 0 on the vertical axis through the middle of
+// the tree towards the camera that the positions were captured from.
+type Point3 struct {
+	X, Y, Z float64
+}
+
+// Layout is the normalized geometry of the LEDs that effects draw on.
+type Layout struct {
+	// Points are the normalized positions of the LEDs.
+	Points []Point
+	// Points3 are the normalized 3D positions of the LEDs. It is nil unless
+	// the layout was created from 3D positions, and effects then draw as if
+	// every LED were on the plane facing the camera.
+	Points3 []Point3
+	// MinX and MaxX are the smallest and largest X of all Points.
+	MinX, MaxX float64
+}
+
+// NewLayout creates a Layout from the positions of the LEDs, such as the ones
+// from led-points.csv. The Y axis of ledPositions points down.
+func NewLayout(ledPositions []image.Point) *Layout {
+	l, _ := newLayout(ledpos.Points(ledPositions))
+	return l
+}
+
+// NewLayout3 creates a Layout from the 3D positions of the LEDs, such as the
+// ones from ledpos.Reconstruct.
+func NewLayout3(ledPositions []ledpos.Point3) *Layout {
+	l, scale := newLayout(ledpos.XYs(ledPositions))
+	if len(ledPositions) == 0 {
+		return l
+	}
+
+	minZ, maxZ := ledPositions[0].Z, ledPositions[0].Z
+	for _, pt := range ledPositions[1:] {
+		minZ = math.Min(minZ, pt.Z)
+		maxZ = math.Max(maxZ, pt.Z)
+	}
+	centerZ := (minZ + maxZ) / 2
+
+	l.Points3 = make([]Point3, len(ledPositions))
+	for i, pt := range ledPositions {
+		l.Points3[i] = Point3{
+			X: l.Points[i].X,
+			Y: l.Points[i].Y,
+			Z: (pt.Z - centerZ) / scale,
+		}
+	}
+	return l
+}
+
+// newLayout creates a Layout from 2D positions and returns how much it scaled
+// them down by.
+func newLayout(ledPositions []ledpos.Point) (*Layout, float64) {
+	if len(ledPositions) == 0 {
+		return &Layout{}, 1
+	}
+
+	min, max := ledpos.Bounds(ledPositions)
+	size := max.Sub(min)
+
+	// Scale by the height so that the tree is 1 tall. A flat row of LEDs is
+	// scaled by its width instead.
+	scale := math.Max(math.Max(size.Y, size.X), 1)
+	if size.Y > 0 {
+		scale = size.Y
+	}
+	centerX := (min.X + max.X) / 2
+
+	l := &Layout{
+		Points: make([]Point, len(ledPositions)),
+		MinX:   (min.X - centerX) / scale,
+		MaxX:   (max.X - centerX) / scale,
+	}
+	for i, pt := range ledPositions {
+		l.Points[i] = Point{
+			X: (pt.X - centerX) / scale,
+			Y: (max.Y - pt.Y) / scale,
+		}
+	}
+	return l, scale
+}
+
+// Point3 returns the 3D position of the LED at index i. If the layout has no
+// 3D positions, the LED is on the plane facing the camera, where Z is 0.
+func (l *Layout) Point3(i int) Point3 {
+	if l.Points3 != nil {
+		return l.Points3[i]
+	}
+	return Point3{X: l.Points[i].X, Y: l.Points[i].Y}
+}
+
+// AnimateOpts is a set of options for Animate.
+type AnimateOpts struct {
+	// Duration is how long the animation is. It is required.
+	Duration time.Duration
+	// Interval is the time between frames. It defaults to 50ms.
+	Interval time.Duration
+	// Loop, if true, makes the last frame jump back to the first one so that
+	// the animation plays forever. The player must be able to hold all
+	// frames for this to work.
+	Loop bool
+}
+
+// Animate renders the effect into frames, one per Interval, for Duration.
+func Animate(effect Effect, layout *Layout, opts AnimateOpts) ([]animation.Frame[leddraw.LEDStrip], error) {
+	if opts.Interval == 0 {
+		opts.Interval = 50 * time.Millisecond
+	}
+	if opts.Interval < time.Millisecond {
+		return nil, fmt.Errorf("interval %v is shorter than 1ms", opts.Interval)
+	}
+	if opts.Duration < opts.Interval {
+		return nil, fmt.Errorf("duration %v is shorter than the interval %v", opts.Duration, opts.Interval)
+	}
+
+	n := int(opts.Duration / opts.Interval)
+
+	// Render all frames into one backing array.
+	backing := make(leddraw.LEDStrip, n*len(layout.Points))
+	frames := make([]animation.Frame[leddraw.LEDStrip], n)
+	for i := range frames {
+		strip := backing[i*len(layout.Points) : (i+1)*len(layout.Points)]
+		effect.Render(strip, layout, time.Duration(i)*opts.Interval)
+		if err := effectErr(effect); err != nil {
+			return nil, err
+		}
+
+		frames[i] = animation.Frame[leddraw.LEDStrip]{
+			Image:      strip,
+			DurationMs: animation.DurationToMs(opts.Interval),
+		}
+	}
+
+	if opts.Loop && n > 1 {
+		frames[n-1].JumpBackAmount = int32(n - 1)
+	}
+
+	return frames, nil
+}
+
+// effectErr returns the error of an effect that can fail, such as a Script.
+func effectErr(effect Effect) error {
+	if e, ok := effect.(interface{ Err() error }); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+var presets = map[string]func() Effect{
+	"rainbow":  func() Effect { return DefaultRainbow },
+	"pulse":    func() Effect { return DefaultPulse },
+	"twinkle":  func() Effect { return DefaultTwinkle },
+	"fire":     func() Effect { return DefaultFire },
+	"snowfall": func() Effect { return DefaultSnowfall },
+	"plasma":   func() Effect { return DefaultPlasma },
+	"spiral":   func() Effect { return DefaultSpiral },
+	"plane":    func() Effect { return DefaultPlane },
+	"sphere":   func() Effect { return DefaultSphere },
+	"helix":    func() Effect { return DefaultHelix },
+}
+
+// Preset returns the effect with the given name, such as "rainbow", with its
+// default parameters.
+func Preset(name string) (Effect, error) {
+	fn, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown effect %q", name)
+	}
+	return fn(), nil
+}
+
+// Presets returns the names of all preset effects in sorted order.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
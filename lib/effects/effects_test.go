@@ -0,0 +1,172 @@
+package effects
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// testTree is a triangle of LEDs, 10 rows high, in image coordinates.
+var testTree = func() []image.Point {
+	var pts []image.Point
+	for row := 0; row < 10; row++ {
+		for x := -row; x <= row; x++ {
+			pts = append(pts, image.Pt(100+x*5, 20+row*10))
+		}
+	}
+	return pts
+}()
+
+func TestLayout(t *testing.T) {
+	layout := NewLayout([]image.Point{{10, 100}, {20, 0}, {30, 100}})
+	assert.Equal(t, []Point{
+		{X: -0.1, Y: 0},
+		{X: 0, Y: 1},
+		{X: 0.1, Y: 0},
+	}, layout.Points)
+	assert.Equal(t, -0.1, layout.MinX)
+	assert.Equal(t, 0.1, layout.MaxX)
+
+	row := NewLayout([]image.Point{{0, 5}, {10, 5}})
+	assert.Equal(t, []Point{{X: -0.5}, {X: 0.5}}, row.Points)
+}
+
+func TestLayout3(t *testing.T) {
+	layout := NewLayout3([]ledpos.Point3{{X: 10, Y: 100}, {X: 20, Y: 0}, {X: 30, Y: 100, Z: 10}})
+	assert.Equal(t, []Point{
+		{X: -0.1, Y: 0},
+		{X: 0, Y: 1},
+		{X: 0.1, Y: 0},
+	}, layout.Points)
+	assert.Equal(t, []Point3{
+		{X: -0.1, Y: 0, Z: -0.05},
+		{X: 0, Y: 1, Z: -0.05},
+		{X: 0.1, Y: 0, Z: 0.05},
+	}, layout.Points3)
+
+	// Flat layouts are on the plane facing the camera.
+	flat := NewLayout([]image.Point{{10, 100}, {20, 0}, {30, 100}})
+	assert.Equal(t, Point3{X: 0.1}, flat.Point3(2))
+}
+
+func TestVolumetric(t *testing.T) {
+	// Two LEDs in the middle of the tree, one in front of the trunk and one
+	// behind it.
+	layout := &Layout{
+		Points:  []Point{{Y: 0.5}, {Y: 0.5}},
+		Points3: []Point3{{Y: 0.5, Z: 0.3}, {Y: 0.5, Z: -0.3}},
+	}
+	strip := make(leddraw.LEDStrip, 2)
+
+	// After a quarter turn, the ball is in front of the trunk.
+	sphere := Sphere{Color: white, Radius: 0.2, Orbit: 0.3, Height: 0.5, Speed: 0.25}
+	sphere.Render(strip, layout, time.Second)
+	assert.Equal(t, leddraw.LEDStrip{white, {}}, strip)
+
+	// The plane starts facing sideways, so it goes through both LEDs, and a
+	// quarter turn later it faces the front, so it misses both.
+	plane := Plane{Color: white, Width: 0.1, Speed: 0.25}
+	plane.Render(strip, layout, 0)
+	assert.Equal(t, leddraw.LEDStrip{white, white}, strip)
+	plane.Render(strip, layout, time.Second)
+	assert.Equal(t, leddraw.LEDStrip{{}, {}}, strip)
+
+	// A single strand starts in front of the trunk at the bottom, so it is
+	// half a turn around at the middle with one turn.
+	helix := Helix{Colors: []xcolor.RGB{white}, Turns: 1, Width: 0.2}
+	helix.Render(strip, layout, 0)
+	assert.Equal(t, leddraw.LEDStrip{{}, white}, strip)
+}
+
+func TestPresets(t *testing.T) {
+	layout := NewLayout(testTree)
+
+	for _, name := range Presets() {
+		t.Run(name, func(t *testing.T) {
+			effect, err := Preset(name)
+			assert.NoError(t, err)
+
+			frames, err := Animate(effect, layout, AnimateOpts{
+				Duration: 2 * time.Second,
+				Interval: 100 * time.Millisecond,
+				Loop:     true,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 20, len(frames))
+			assert.Equal(t, int32(19), frames[19].JumpBackAmount)
+
+			var lit, changed bool
+			for i, frame := range frames {
+				assert.Equal(t, len(testTree), len(frame.Image))
+				for j, c := range frame.Image {
+					if c.R != 0 || c.G != 0 || c.B != 0 {
+						lit = true
+					}
+					if i > 0 && c != frames[i-1].Image[j] {
+						changed = true
+					}
+				}
+			}
+			assert.True(t, lit, "effect never lights an LED")
+			assert.True(t, changed, "effect never changes")
+
+			// Effects are deterministic.
+			again := make(leddraw.LEDStrip, len(testTree))
+			effect.Render(again, layout, 500*time.Millisecond)
+			assert.Equal(t, frames[5].Image, again)
+		})
+	}
+
+	_, err := Preset("nope")
+	assert.EqualError(t, err, `unknown effect "nope"`)
+}
+
+func TestFire(t *testing.T) {
+	layout := NewLayout(testTree)
+	strip := make(leddraw.LEDStrip, len(testTree))
+
+	// The bottom of the tree burns brighter than the top on average.
+	var bottom, top int
+	for i := 0; i < 20; i++ {
+		DefaultFire.Render(strip, layout, time.Duration(i)*100*time.Millisecond)
+		for j, pt := range layout.Points {
+			c := strip[j]
+			v := int(c.R) + int(c.G) + int(c.B)
+			switch {
+			case pt.Y < 0.2:
+				bottom += v
+			case pt.Y > 0.8:
+				top += v
+			}
+		}
+	}
+	assert.True(t, bottom > top*4, "bottom %d, top %d", bottom, top)
+}
+
+func TestAnimateInvalid(t *testing.T) {
+	layout := NewLayout(testTree)
+
+	_, err := Animate(DefaultRainbow, layout, AnimateOpts{})
+	assert.Error(t, err)
+	_, err = Animate(DefaultRainbow, layout, AnimateOpts{Duration: time.Second, Interval: time.Microsecond})
+	assert.Error(t, err)
+}
+
+func BenchmarkPresets(b *testing.B) {
+	layout := NewLayout(testTree)
+	strip := make(leddraw.LEDStrip, len(testTree))
+
+	for _, name := range Presets() {
+		effect, _ := Preset(name)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				effect.Render(strip, layout, time.Duration(i)*time.Millisecond)
+			}
+		})
+	}
+}
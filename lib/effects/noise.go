@@ -0,0 +1,59 @@
+package effects
+
+import "math"
+
+// hash mixes the given values into a pseudo-random 64-bit value. It is the
+// finalizer of SplitMix64, applied to each value in turn.
+func hash(values ...uint64) uint64 {
+	var h uint64 = 0x9E3779B97F4A7C15
+	for _, v := range values {
+		h ^= v
+		h ^= h >> 30
+		h *= 0xBF58476D1CE4E5B9
+		h ^= h >> 27
+		h *= 0x94D049BB133111EB
+		h ^= h >> 31
+	}
+	return h
+}
+
+// hashFloat returns a pseudo-random value in [0, 1) for the given values.
+func hashFloat(values ...uint64) float64 {
+	return float64(hash(values...)>>11) / (1 << 53)
+}
+
+// noise2 returns smooth value noise in [0, 1) at (x, y). It is continuous and
+// varies about once per unit.
+func noise2(seed uint64, x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := smoothstep(x-x0), smoothstep(y-y0)
+
+	corner := func(dx, dy float64) float64 {
+		return hashFloat(seed, uint64(int64(x0+dx)), uint64(int64(y0+dy)))
+	}
+
+	top := lerp(corner(0, 0), corner(1, 0), fx)
+	bottom := lerp(corner(0, 1), corner(1, 1), fx)
+	return lerp(top, bottom, fy)
+}
+
+func smoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// fract returns the fractional part of x, which is always in [0, 1).
+func fract(x float64) float64 {
+	return x - math.Floor(x)
+}
+
+// epsilon is the smallest size that effects divide by, so that a zero size
+// is drawn as thin as possible instead of dividing by zero.
+const epsilon = 1e-9
+
+func clamp01(x float64) float64 {
+	return min(max(x, 0), 1)
+}
@@ -0,0 +1,297 @@
+package effects
+
+import (
+	"math"
+	"time"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+var (
+	white = xcolor.RGB{R: 0xFF, G: 0xFF, B: 0xFF}
+	red   = xcolor.RGB{R: 0xFF}
+	green = xcolor.RGB{G: 0xFF}
+	gold  = xcolor.RGB{R: 0xFF, G: 0xB0, B: 0x20}
+)
+
+// Rainbow sweeps the colors of the rainbow across the tree.
+type Rainbow struct {
+	// Scale is how many times the rainbow repeats across the tree.
+	Scale float64
+	// Speed is how many times per second the rainbow sweeps past an LED.
+	Speed float64
+	// Angle is the direction that the rainbow sweeps in, in radians. 0 sweeps
+	// up the tree and π/2 sweeps to the right.
+	Angle float64
+}
+
+// DefaultRainbow is a rainbow that sweeps up the tree every 2 seconds.
+var DefaultRainbow = Rainbow{Scale: 1, Speed: 0.5}
+
+// Render implements Effect.
+func (e Rainbow) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	sin, cos := math.Sincos(e.Angle)
+	for i, pt := range layout.Points {
+		d := pt.Y*cos + pt.X*sin
+		dst[i] = xcolor.HSV(d*e.Scale-t.Seconds()*e.Speed, 1, 1)
+	}
+}
+
+// Pulse sends rings of light out from a point.
+type Pulse struct {
+	// Center is where the rings start.
+	Center Point
+	// Color is the color of the rings.
+	Color xcolor.RGB
+	// Speed is how fast the rings grow, in tree heights per second.
+	Speed float64
+	// Width is how wide the rings are, in tree heights.
+	Width float64
+	// Period is the time between rings. Zero sends a single ring.
+	Period time.Duration
+}
+
+// DefaultPulse sends gold rings out from the middle of the tree every second.
+var DefaultPulse = Pulse{
+	Center: Point{X: 0, Y: 0.5},
+	Color:  gold,
+	Speed:  0.8,
+	Width:  0.1,
+	Period: time.Second,
+}
+
+// Render implements Effect.
+func (e Pulse) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	if e.Period > 0 {
+		t %= e.Period
+	}
+	radius := t.Seconds() * e.Speed
+	width := max(e.Width, epsilon)
+
+	for i, pt := range layout.Points {
+		d := math.Hypot(pt.X-e.Center.X, pt.Y-e.Center.Y)
+		level := 1 - math.Abs(d-radius)/width
+		dst[i] = xcolor.Scale(e.Color, clamp01(level))
+	}
+}
+
+// Twinkle makes random LEDs fade in and out, like stars.
+type Twinkle struct {
+	// Colors are the colors that the LEDs twinkle in. Each twinkle picks one
+	// at random. It defaults to white.
+	Colors []xcolor.RGB
+	// Density is the fraction of LEDs that twinkle at once, between 0 and 1.
+	Density float64
+	// Period is how long a single twinkle lasts.
+	Period time.Duration
+	// Seed picks a different set of twinkles.
+	Seed uint64
+}
+
+// DefaultTwinkle twinkles a quarter of the LEDs in warm colors.
+var DefaultTwinkle = Twinkle{
+	Colors:  []xcolor.RGB{white, gold},
+	Density: 0.25,
+	Period:  1500 * time.Millisecond,
+}
+
+// Render implements Effect.
+func (e Twinkle) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	colors := e.Colors
+	if len(colors) == 0 {
+		colors = []xcolor.RGB{white}
+	}
+
+	period := max(e.Period, time.Millisecond)
+
+	for i := range layout.Points {
+		// Each LED twinkles on its own schedule so that they don't all
+		// change at once.
+		phase := t.Seconds()/period.Seconds() + hashFloat(e.Seed, uint64(i))
+		cycle := uint64(int64(math.Floor(phase)))
+
+		if hashFloat(e.Seed, uint64(i), cycle, 1) >= e.Density {
+			dst[i] = xcolor.RGB{}
+			continue
+		}
+
+		color := colors[hash(e.Seed, uint64(i), cycle, 2)%uint64(len(colors))]
+		dst[i] = xcolor.Scale(color, math.Sin(math.Pi*fract(phase)))
+	}
+}
+
+// Fire makes flames rise from the bottom of the tree.
+type Fire struct {
+	// Height is how far up the tree the flames reach, in tree heights.
+	Height float64
+	// Speed is how fast the flames rise, in tree heights per second.
+	Speed float64
+	// Seed picks a different fire.
+	Seed uint64
+}
+
+// DefaultFire is a fire that reaches halfway up the tree.
+var DefaultFire = Fire{Height: 0.5, Speed: 0.6}
+
+// Render implements Effect.
+func (e Fire) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	const detail = 6 // noise cells per tree height
+	height := max(e.Height, epsilon)
+
+	for i, pt := range layout.Points {
+		// The noise scrolls up so that the flames rise, and flickers by
+		// also moving through a second noise field.
+		y := pt.Y - t.Seconds()*e.Speed
+		n := noise2(e.Seed, pt.X*detail, y*detail)
+		n = (n + noise2(e.Seed+1, pt.X*detail*2, y*detail*2-t.Seconds()*2)) / 2
+
+		heat := 1 - pt.Y/height
+		heat = clamp01(heat + (n-0.5)*0.8)
+		dst[i] = fireColor(heat)
+	}
+}
+
+// fireColor maps heat between 0 and 1 to a color that goes from black
+// through red and yellow to white.
+func fireColor(heat float64) xcolor.RGB {
+	switch {
+	case heat < 1.0/3:
+		return xcolor.Scale(red, heat*3)
+	case heat < 2.0/3:
+		return xcolor.RGB{R: 0xFF, G: uint8((heat - 1.0/3) * 3 * 0xFF)}
+	default:
+		return xcolor.RGB{R: 0xFF, G: 0xFF, B: uint8(min((heat-2.0/3)*3, 1) * 0xFF)}
+	}
+}
+
+// Snowfall makes snowflakes fall down the tree.
+type Snowfall struct {
+	// Color is the color of the snowflakes.
+	Color xcolor.RGB
+	// Flakes is how many snowflakes fall side by side across the tree.
+	Flakes int
+	// Speed is how fast the snowflakes fall, in tree heights per second.
+	// Each snowflake falls a bit faster or slower than this.
+	Speed float64
+	// Size is the length of a snowflake, in tree heights.
+	Size float64
+	// Seed picks different snowflakes.
+	Seed uint64
+}
+
+// DefaultSnowfall lets white snowflakes fall down the tree in about 3
+// seconds.
+var DefaultSnowfall = Snowfall{
+	Color:  white,
+	Flakes: 12,
+	Speed:  0.35,
+	Size:   0.08,
+}
+
+// Render implements Effect.
+func (e Snowfall) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	width := layout.MaxX - layout.MinX
+	flakes := max(e.Flakes, 1)
+	size := max(e.Size, epsilon)
+
+	for i, pt := range layout.Points {
+		lane := 0
+		if width > 0 {
+			lane = min(int((pt.X-layout.MinX)/width*float64(flakes)), flakes-1)
+		}
+
+		speed := e.Speed * (0.75 + 0.5*hashFloat(e.Seed, uint64(lane)))
+		offset := hashFloat(e.Seed, uint64(lane), 1)
+
+		// The flake falls from just above the top to just below the
+		// bottom, then starts over.
+		span := 1 + 2*size
+		flakeY := 1 + size - fract(t.Seconds()*speed/span+offset)*span
+
+		// Only light LEDs at and above the flake, so that it leaves a short
+		// trail.
+		level := 0.0
+		if d := pt.Y - flakeY; d >= 0 {
+			level = 1 - d/size
+		}
+		dst[i] = xcolor.Scale(e.Color, clamp01(level))
+	}
+}
+
+// Plasma is a smooth, flowing pattern of colors.
+type Plasma struct {
+	// Scale is how large the blobs of color are. Larger values make smaller
+	// blobs.
+	Scale float64
+	// Speed is how fast the pattern flows.
+	Speed float64
+}
+
+// DefaultPlasma is a slowly flowing plasma.
+var DefaultPlasma = Plasma{Scale: 3, Speed: 1}
+
+// Render implements Effect.
+func (e Plasma) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	ts := t.Seconds() * e.Speed
+	for i, pt := range layout.Points {
+		x := pt.X * e.Scale
+		y := pt.Y * e.Scale
+
+		v := math.Sin(x*2 + ts)
+		v += math.Sin(y*2 - ts*0.7)
+		v += math.Sin((x+y)*1.5 + ts*1.3)
+		v += math.Sin(math.Hypot(x+math.Sin(ts*0.3), y+math.Cos(ts*0.5))*2 - ts)
+
+		// v is between -4 and 4.
+		dst[i] = xcolor.HSV(v/8+ts*0.05, 1, 1)
+	}
+}
+
+// Spiral wraps stripes around the tree like a candy cane, and turns them.
+//
+// On a layout from NewLayout3, the side of the tree that an LED is on comes
+// from its 3D position. Otherwise, the tree is seen from the front, so it is
+// guessed from how far the LED is from the middle of the tree, assuming that
+// the tree is a cone that is widest at the bottom.
+type Spiral struct {
+	// Colors are the colors of the stripes, in order. It defaults to red and
+	// white.
+	Colors []xcolor.RGB
+	// Turns is how many times a stripe wraps around the tree from the bottom
+	// to the top.
+	Turns float64
+	// Speed is how many times per second the spiral turns.
+	Speed float64
+}
+
+// DefaultSpiral is a red, white and green spiral that turns every 4 seconds.
+var DefaultSpiral = Spiral{
+	Colors: []xcolor.RGB{red, white, green, white},
+	Turns:  2,
+	Speed:  0.25,
+}
+
+// Render implements Effect.
+func (e Spiral) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	colors := e.Colors
+	if len(colors) == 0 {
+		colors = []xcolor.RGB{red, white}
+	}
+
+	radius := max(-layout.MinX, layout.MaxX) // of the cone at the bottom
+
+	for i, pt := range layout.Points {
+		// Guess the angle around the tree axis, from -π/2 on the left to π/2
+		// on the right, unless it is known.
+		var angle float64
+		if layout.Points3 != nil {
+			angle = math.Atan2(pt.X, layout.Points3[i].Z)
+		} else if r := radius * (1 - pt.Y); r > 0 {
+			angle = math.Asin(max(-1, min(pt.X/r, 1)))
+		}
+
+		phase := angle/(2*math.Pi) + pt.Y*e.Turns - t.Seconds()*e.Speed
+		dst[i] = colors[int(fract(phase)*float64(len(colors)))%len(colors)]
+	}
+}
@@ -0,0 +1,277 @@
+package effects
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// ScriptOpts is a set of options for NewScript.
+type ScriptOpts struct {
+	// MaxSteps is the largest number of Starlark steps that loading the
+	// script, or rendering one frame, may take. It defaults to 1000000.
+	MaxSteps uint64
+	// Timeout is the longest time that loading the script, or rendering one
+	// frame, may take. It defaults to 100ms.
+	Timeout time.Duration
+	// Print, if not nil, is called with the output of print calls in the
+	// script. By default, the output is discarded.
+	Print func(msg string)
+}
+
+// Script is an effect that is written in Starlark, a dialect of Python. The
+// script must define a function that gives the color of each LED:
+//
+//	def led(i, x, y, t):
+//	    return hsv(x + t / 4, 1, y)
+//
+// where i is the index of the LED, x and y are its normalized position as in
+// Point, and t is the time in seconds. The color is a tuple of red, green and
+// blue between 0 and 1, a hex string such as "#ff0000", or None for black.
+//
+// Scripts can use the math module and these functions:
+//
+//	rgb(r, g, b)          the color as a tuple, for clarity
+//	hsv(h, s, v)          a color from its hue in turns, saturation and value
+//	noise(x, y, seed=0)   smooth noise between 0 and 1
+//	rand(*values)         a random number between 0 and 1 for the values
+//
+// Since scripts have no access to the clock or to true randomness, they
+// render the same frame for the same time like all effects.
+//
+// If the script fails to render a frame, because it has an error or because
+// it takes more than the allowed steps or time, it renders that frame and
+// all later ones black, and Err returns the error. A Script must not be
+// rendered from multiple goroutines at once.
+type Script struct {
+	name string
+	led  starlark.Callable
+	opts ScriptOpts
+	err  error
+}
+
+// NewScript loads the Starlark script src. The name is used in error
+// messages, and is usually the file name of the script.
+func NewScript(name string, src []byte, opts ScriptOpts) (*Script, error) {
+	if opts.MaxSteps == 0 {
+		opts.MaxSteps = 1000000
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 100 * time.Millisecond
+	}
+
+	s := &Script{name: name, opts: opts}
+
+	var globals starlark.StringDict
+	err := s.limit(func(thread *starlark.Thread) (err error) {
+		globals, err = starlark.ExecFile(thread, name, src, scriptBuiltins)
+		return err
+	})
+	if err != nil {
+		return nil, s.wrapErr(err)
+	}
+
+	led, ok := globals["led"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("script %q: missing function led(i, x, y, t)", name)
+	}
+	if fn, ok := led.(*starlark.Function); ok && fn.NumParams() != 4 && !fn.HasVarargs() {
+		return nil, fmt.Errorf("script %q: led takes %d parameters, want 4", name, fn.NumParams())
+	}
+	s.led = led
+
+	return s, nil
+}
+
+// Name returns the name of the script.
+func (s *Script) Name() string {
+	return s.name
+}
+
+// Err returns the error that stopped the script from rendering, if any.
+func (s *Script) Err() error {
+	return s.err
+}
+
+// Render implements Effect.
+func (s *Script) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	dst.Clear()
+	if s.err != nil {
+		return
+	}
+
+	err := s.limit(func(thread *starlark.Thread) error {
+		secs := starlark.Float(t.Seconds())
+		for i, pt := range layout.Points {
+			args := starlark.Tuple{
+				starlark.MakeInt(i),
+				starlark.Float(pt.X),
+				starlark.Float(pt.Y),
+				secs,
+			}
+			v, err := starlark.Call(thread, s.led, args, nil)
+			if err != nil {
+				return err
+			}
+			c, err := scriptColor(v)
+			if err != nil {
+				return fmt.Errorf("led(%d): %w", i, err)
+			}
+			dst[i] = c
+		}
+		return nil
+	})
+	if err != nil {
+		dst.Clear()
+		s.err = s.wrapErr(fmt.Errorf("at %v: %w", t, err))
+	}
+}
+
+// limit runs fn on a new thread that is cancelled once it runs out of steps
+// or time. A new thread is used each time so that a timeout that fires late
+// can't cancel the next call.
+func (s *Script) limit(fn func(*starlark.Thread) error) error {
+	thread := &starlark.Thread{
+		Name: s.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			if s.opts.Print != nil {
+				s.opts.Print(msg)
+			}
+		},
+	}
+	thread.SetMaxExecutionSteps(s.opts.MaxSteps)
+
+	timer := time.AfterFunc(s.opts.Timeout, func() {
+		thread.Cancel(fmt.Sprintf("took longer than %v", s.opts.Timeout))
+	})
+	defer timer.Stop()
+
+	return fn(thread)
+}
+
+func (s *Script) wrapErr(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) && len(evalErr.CallStack) > 0 {
+		pos := evalErr.CallStack[len(evalErr.CallStack)-1].Pos
+		return fmt.Errorf("script %q: %w (at %s)", s.name, err, pos)
+	}
+	return fmt.Errorf("script %q: %w", s.name, err)
+}
+
+// scriptColor converts the value returned by a script into a color.
+func scriptColor(v starlark.Value) (xcolor.RGB, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return xcolor.RGB{}, nil
+	case starlark.String:
+		return parseHexColor(string(v))
+	case starlark.Indexable:
+		if v.Len() != 3 {
+			return xcolor.RGB{}, fmt.Errorf("color has %d channels, want 3", v.Len())
+		}
+		var rgb [3]uint8
+		for i := range rgb {
+			f, ok := starlark.AsFloat(v.Index(i))
+			if !ok {
+				return xcolor.RGB{}, fmt.Errorf("color channel is a %s, want a number", v.Index(i).Type())
+			}
+			rgb[i] = uint8(clamp01(f)*0xFF + 0.5)
+		}
+		return xcolor.RGB{R: rgb[0], G: rgb[1], B: rgb[2]}, nil
+	default:
+		return xcolor.RGB{}, fmt.Errorf("color is a %s, want a tuple, string or None", v.Type())
+	}
+}
+
+func parseHexColor(s string) (xcolor.RGB, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return xcolor.RGB{}, fmt.Errorf("invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return xcolor.RGB{}, fmt.Errorf("invalid color %q", s)
+	}
+	return xcolor.RGB{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v)}, nil
+}
+
+var scriptBuiltins = starlark.StringDict{
+	"math":  math.Module,
+	"rgb":   starlark.NewBuiltin("rgb", scriptRGB),
+	"hsv":   starlark.NewBuiltin("hsv", scriptHSV),
+	"noise": starlark.NewBuiltin("noise", scriptNoise),
+	"rand":  starlark.NewBuiltin("rand", scriptRand),
+}
+
+// scriptNumber is an argument of a builtin that can be an int or a float.
+type scriptNumber float64
+
+var _ starlark.Unpacker = (*scriptNumber)(nil)
+
+func (n *scriptNumber) Unpack(v starlark.Value) error {
+	f, ok := starlark.AsFloat(v)
+	if !ok {
+		return fmt.Errorf("got %s, want number", v.Type())
+	}
+	*n = scriptNumber(f)
+	return nil
+}
+
+func scriptRGB(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var r, g, bl scriptNumber
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 3, &r, &g, &bl); err != nil {
+		return nil, err
+	}
+	return starlark.Tuple{starlark.Float(r), starlark.Float(g), starlark.Float(bl)}, nil
+}
+
+func scriptHSV(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var h, s, v scriptNumber
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 3, &h, &s, &v); err != nil {
+		return nil, err
+	}
+	c := xcolor.HSV(float64(h), clamp01(float64(s)), clamp01(float64(v)))
+	return starlark.Tuple{
+		starlark.Float(float64(c.R) / 0xFF),
+		starlark.Float(float64(c.G) / 0xFF),
+		starlark.Float(float64(c.B) / 0xFF),
+	}, nil
+}
+
+func scriptNoise(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y scriptNumber
+	var seed int
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x, "y", &y, "seed?", &seed); err != nil {
+		return nil, err
+	}
+	return starlark.Float(noise2(uint64(seed), float64(x), float64(y))), nil
+}
+
+func scriptRand(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(kwargs) > 0 {
+		return nil, fmt.Errorf("%s: unexpected keyword arguments", b.Name())
+	}
+	values := make([]uint64, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case starlark.Int:
+			n, ok := arg.Int64()
+			if !ok {
+				return nil, fmt.Errorf("%s: argument %d is too large", b.Name(), i+1)
+			}
+			values[i] = uint64(n)
+		case starlark.Float:
+			values[i] = uint64(int64(arg * 1e6))
+		default:
+			return nil, fmt.Errorf("%s: argument %d is a %s, want a number", b.Name(), i+1, arg.Type())
+		}
+	}
+	return starlark.Float(hashFloat(values...)), nil
+}
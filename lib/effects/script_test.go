@@ -0,0 +1,140 @@
+package effects
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+func TestScript(t *testing.T) {
+	layout := NewLayout([]image.Point{{0, 10}, {10, 0}, {20, 10}})
+
+	script, err := NewScript("test.star", []byte(`
+colors = ["#ff0000", rgb(0, 1, 0), hsv(2 / 3, 1, 1)]
+
+def led(i, x, y, t):
+    if t >= 1:
+        return None
+    if y > 0.5:
+        return rgb(1, 1, 1)
+    return colors[i]
+`), ScriptOpts{})
+	assert.NoError(t, err)
+
+	strip := make(leddraw.LEDStrip, 3)
+	script.Render(strip, layout, 0)
+	assert.Equal(t, leddraw.LEDStrip{
+		{R: 0xFF},
+		{R: 0xFF, G: 0xFF, B: 0xFF},
+		{B: 0xFF},
+	}, strip)
+
+	script.Render(strip, layout, time.Second)
+	assert.Equal(t, make(leddraw.LEDStrip, 3), strip)
+	assert.NoError(t, script.Err())
+
+	frames, err := Animate(script, layout, AnimateOpts{Duration: 2 * time.Second, Interval: 500 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(frames))
+	assert.Equal(t, xcolor.RGB{R: 0xFF}, frames[1].Image[0])
+}
+
+func TestScriptInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts ScriptOpts
+		err  string
+	}{
+		{"syntax", "def led(", ScriptOpts{}, "test.star:1:9"},
+		{"missing", "x = 1", ScriptOpts{}, `missing function led(i, x, y, t)`},
+		{"params", "def led(i):\n    return None", ScriptOpts{}, "led takes 1 parameters, want 4"},
+		{
+			"load steps",
+			"x = [i for i in range(10000)]",
+			ScriptOpts{MaxSteps: 1000, Timeout: time.Minute},
+			"too many steps",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewScript("test.star", []byte(test.src), test.opts)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestScriptLimits(t *testing.T) {
+	layout := NewLayout(testTree)
+
+	tests := []struct {
+		name string
+		src  string
+		opts ScriptOpts
+		err  string
+	}{
+		{
+			name: "color",
+			src:  "def led(i, x, y, t):\n    return 1",
+			err:  "led(0): color is a int, want a tuple, string or None",
+		},
+		{
+			name: "error",
+			src:  "def led(i, x, y, t):\n    return 1 // 0",
+			err:  `at 0s: floored division by zero (at test.star:2:14)`,
+		},
+		{
+			name: "steps",
+			src:  "def led(i, x, y, t):\n    return [j for j in range(10000)] and None",
+			opts: ScriptOpts{MaxSteps: 1000, Timeout: time.Minute},
+			err:  "too many steps",
+		},
+		{
+			name: "timeout",
+			src:  "def led(i, x, y, t):\n    return [j for j in range(100000)] and None",
+			opts: ScriptOpts{MaxSteps: 1 << 62, Timeout: time.Millisecond},
+			err:  "took longer than 1ms",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script, err := NewScript("test.star", []byte(test.src), test.opts)
+			assert.NoError(t, err)
+
+			_, err = Animate(script, layout, AnimateOpts{Duration: time.Second})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+			assert.Equal(t, err, script.Err())
+
+			// A failed script stays black.
+			strip := make(leddraw.LEDStrip, len(testTree))
+			strip[0] = xcolor.RGB{R: 1}
+			script.Render(strip, layout, 0)
+			assert.Equal(t, make(leddraw.LEDStrip, len(testTree)), strip)
+		})
+	}
+}
+
+func BenchmarkScript(b *testing.B) {
+	layout := NewLayout(testTree)
+	strip := make(leddraw.LEDStrip, len(testTree))
+
+	script, err := NewScript("rainbow.star", []byte(`
+def led(i, x, y, t):
+    return hsv(y + t / 4, 1, 1)
+`), ScriptOpts{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		script.Render(strip, layout, time.Duration(i)*time.Millisecond)
+	}
+}
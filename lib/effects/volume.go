@@ -0,0 +1,155 @@
+package effects
+
+import (
+	"math"
+	"time"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// The effects in this file are volumetric: they are shapes in 3D that light
+// up the LEDs inside them. They look best on layouts from NewLayout3, but
+// also draw on flat layouts, where they show the slice of the shape that goes
+// through the plane facing the camera.
+
+// Plane turns a plane of light around the trunk of the tree, like the beam of
+// a lighthouse.
+type Plane struct {
+	// Color is the color of the plane.
+	Color xcolor.RGB
+	// Width is how thick the plane is, in tree heights.
+	Width float64
+	// Speed is how many times per second the plane turns around the tree.
+	Speed float64
+	// Tilt is how far the plane leans away from upright, in radians.
+	Tilt float64
+}
+
+// DefaultPlane is a green plane that turns around the tree every 2 seconds.
+var DefaultPlane = Plane{
+	Color: green,
+	Width: 0.08,
+	Speed: 0.5,
+	Tilt:  math.Pi / 8,
+}
+
+// Render implements Effect.
+func (e Plane) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	width := max(e.Width, epsilon)
+
+	// The normal of the plane points sideways and turns around the trunk,
+	// and the tilt leans it up. The plane goes through the middle of the
+	// trunk.
+	sinA, cosA := math.Sincos(2 * math.Pi * t.Seconds() * e.Speed)
+	sinT, cosT := math.Sincos(e.Tilt)
+	normal := Point3{X: cosA * cosT, Y: sinT, Z: sinA * cosT}
+
+	for i := range layout.Points {
+		pt := layout.Point3(i)
+		d := pt.X*normal.X + (pt.Y-0.5)*normal.Y + pt.Z*normal.Z
+		level := 1 - math.Abs(d)/width
+		dst[i] = xcolor.Scale(e.Color, clamp01(level))
+	}
+}
+
+// Sphere is a ball of light that circles around the tree.
+type Sphere struct {
+	// Color is the color of the ball.
+	Color xcolor.RGB
+	// Radius is the radius of the ball, in tree heights. The edge of the
+	// ball fades out over the outer half of its radius.
+	Radius float64
+	// Orbit is how far the middle of the ball is from the trunk, in tree
+	// heights.
+	Orbit float64
+	// Height is how high up the tree the ball circles, from 0 at the bottom
+	// to 1 at the top.
+	Height float64
+	// Speed is how many times per second the ball circles the tree.
+	Speed float64
+}
+
+// DefaultSphere is a gold ball that circles the middle of the tree every 3
+// seconds.
+var DefaultSphere = Sphere{
+	Color:  gold,
+	Radius: 0.2,
+	Orbit:  0.2,
+	Height: 0.4,
+	Speed:  1.0 / 3,
+}
+
+// Render implements Effect.
+func (e Sphere) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	radius := max(e.Radius, epsilon)
+
+	sin, cos := math.Sincos(2 * math.Pi * t.Seconds() * e.Speed)
+	center := Point3{X: cos * e.Orbit, Y: e.Height, Z: sin * e.Orbit}
+
+	for i := range layout.Points {
+		pt := layout.Point3(i)
+		d := math.Sqrt(sq(pt.X-center.X) + sq(pt.Y-center.Y) + sq(pt.Z-center.Z))
+		level := 2 * (1 - d/radius)
+		dst[i] = xcolor.Scale(e.Color, clamp01(level))
+	}
+}
+
+// Helix winds strands of light around the trunk of the tree, like the rails
+// of a spiral staircase, and turns them.
+//
+// Unlike Spiral, it uses the angle of each LED around the trunk from a 3D
+// layout. On a flat layout, every LED is a quarter turn right or left of the
+// front of the tree.
+type Helix struct {
+	// Colors are the colors of the strands, in order. There is one strand
+	// for each color, spread evenly around the tree. It defaults to red.
+	Colors []xcolor.RGB
+	// Turns is how many times a strand wraps around the tree from the bottom
+	// to the top.
+	Turns float64
+	// Width is how wide a strand is, as a fraction of the gap between two
+	// strands.
+	Width float64
+	// Speed is how many times per second the helix turns.
+	Speed float64
+}
+
+// DefaultHelix is a red and green double helix that turns every 3 seconds.
+var DefaultHelix = Helix{
+	Colors: []xcolor.RGB{red, green},
+	Turns:  1.5,
+	Width:  0.3,
+	Speed:  1.0 / 3,
+}
+
+// Render implements Effect.
+func (e Helix) Render(dst leddraw.LEDStrip, layout *Layout, t time.Duration) {
+	colors := e.Colors
+	if len(colors) == 0 {
+		colors = []xcolor.RGB{red}
+	}
+	strands := float64(len(colors))
+	width := max(e.Width, epsilon)
+
+	for i := range layout.Points {
+		pt := layout.Point3(i)
+
+		// The angle around the trunk, from 0 in front of it to π/2 on the
+		// right, like in Spiral.
+		angle := math.Atan2(pt.X, pt.Z)
+
+		// phase goes from 0 to the number of strands around the tree, and
+		// each strand is at a whole number.
+		phase := (angle/(2*math.Pi) + pt.Y*e.Turns - t.Seconds()*e.Speed) * strands
+		strand := math.Round(phase)
+
+		level := 1 - math.Abs(phase-strand)*2/width
+		color := colors[int(fract(strand/strands)*strands+0.5)%len(colors)]
+		dst[i] = xcolor.Scale(color, clamp01(level))
+	}
+}
+
+func sq(x float64) float64 {
+	return x * x
+}
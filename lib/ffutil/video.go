@@ -0,0 +1,276 @@
+package ffutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// VideoOpts is a set of options for OpenVideo.
+type VideoOpts struct {
+	// Size is the size of the decoded frames. It is required.
+	Size image.Point
+	// Fit, if true, scales the video to fit within Size and pads it with
+	// black. Otherwise, the video is scaled to fill Size and cropped.
+	Fit bool
+	// Loop, if true, plays the video over and over, so that it never ends.
+	Loop bool
+	// Filters are additional ffmpeg filters that are applied to the video
+	// before it is scaled.
+	Filters []string
+}
+
+// Video decodes a video file into RGBA frames using ffmpeg.
+type Video struct {
+	cmd    *exec.Cmd
+	out    io.ReadCloser
+	r      *bufio.Reader
+	stderr bytes.Buffer
+	size   image.Point
+	fps    FrameRate
+}
+
+// FrameRate is the frame rate of a video as a fraction, such as 30000/1001
+// for 29.97 frames per second.
+type FrameRate struct {
+	Num, Den int64
+}
+
+// ParseFrameRate parses a frame rate as ffprobe prints it, such as
+// "30000/1001" or "25".
+func ParseFrameRate(s string) (FrameRate, error) {
+	numStr, denStr, ok := strings.Cut(s, "/")
+	if !ok {
+		denStr = "1"
+	}
+	num, err1 := strconv.ParseInt(numStr, 10, 64)
+	den, err2 := strconv.ParseInt(denStr, 10, 64)
+	if err1 != nil || err2 != nil || num <= 0 || den <= 0 {
+		return FrameRate{}, fmt.Errorf("invalid frame rate %q", s)
+	}
+	return FrameRate{num, den}, nil
+}
+
+// FrameTime returns the time at which frame n is shown, counting from 0.
+// Unlike multiplying n by the duration of a frame, it doesn't drift from the
+// video.
+func (r FrameRate) FrameTime(n int64) time.Duration {
+	// Split off the whole seconds so that long videos don't overflow.
+	secs := n * r.Den / r.Num
+	rem := n * r.Den % r.Num
+	return time.Duration(secs)*time.Second + time.Duration(rem*int64(time.Second)/r.Num)
+}
+
+// String formats the frame rate in frames per second.
+func (r FrameRate) String() string {
+	return strconv.FormatFloat(float64(r.Num)/float64(r.Den), 'f', -1, 64) + " fps"
+}
+
+// ProbeFrameRate returns the frame rate of the video file using ffprobe.
+func ProbeFrameRate(ctx context.Context, path string) (FrameRate, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx,
+		"ffprobe",
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-of", "default=noprint_wrappers=1",
+		"-show_entries", "stream=avg_frame_rate,r_frame_rate", path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return FrameRate{}, fmt.Errorf("ffprobe: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return parseProbedFrameRate(stdout.String())
+}
+
+// parseProbedFrameRate picks the frame rate out of the output of ffprobe. The
+// average frame rate is preferred, but it is 0/0 for some formats.
+func parseProbedFrameRate(out string) (FrameRate, error) {
+	rates := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok {
+			rates[k] = v
+		}
+	}
+
+	for _, key := range []string{"avg_frame_rate", "r_frame_rate"} {
+		if rate, err := ParseFrameRate(rates[key]); err == nil {
+			return rate, nil
+		}
+	}
+	return FrameRate{}, fmt.Errorf("video has no frame rate")
+}
+
+// OpenVideo starts decoding the video file at path. The video is decoded at
+// the frame rate of the file, which is probed using ffprobe. Video must be
+// closed once it's no longer needed.
+func OpenVideo(ctx context.Context, path string, opts VideoOpts) (*Video, error) {
+	if opts.Size.X <= 0 || opts.Size.Y <= 0 {
+		return nil, fmt.Errorf("invalid video size %v", opts.Size)
+	}
+
+	fps, err := ProbeFrameRate(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot probe %q: %w", path, err)
+	}
+
+	v := &Video{
+		size: opts.Size,
+		fps:  fps,
+	}
+
+	v.cmd = exec.CommandContext(ctx, "ffmpeg", videoArgs(path, opts)...)
+	v.cmd.Stderr = &v.stderr
+
+	v.out, err = v.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start ffmpeg: %w", err)
+	}
+
+	v.r = bufio.NewReaderSize(v.out, 4*opts.Size.X*opts.Size.Y)
+	return v, nil
+}
+
+func videoArgs(path string, opts VideoOpts) FFmpegArgs {
+	w, h := opts.Size.X, opts.Size.Y
+
+	filters := append([]string(nil), opts.Filters...)
+	if opts.Fit {
+		filters = append(filters,
+			fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease", w, h),
+			fmt.Sprintf("pad=%d:%d:(ow-iw)/2:(oh-ih)/2:color=black", w, h))
+	} else {
+		filters = append(filters,
+			fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=increase", w, h),
+			fmt.Sprintf("crop=%d:%d", w, h))
+	}
+
+	args := FFmpegArgs{"-hide_banner", "-loglevel", "error", "-nostdin"}
+	if opts.Loop {
+		args = append(args, "-stream_loop", "-1")
+	}
+	args = append(args,
+		"-i", path,
+		"-map", "0:v:0",
+		"-vf", strings.Join(filters, ","),
+		"-f", "rawvideo",
+		"-pix_fmt", "rgba",
+		"-",
+	)
+	return args
+}
+
+// FrameRate returns the frame rate of the video.
+func (v *Video) FrameRate() FrameRate {
+	return v.fps
+}
+
+// Bounds returns the bounds of the decoded frames.
+func (v *Video) Bounds() image.Rectangle {
+	return image.Rectangle{Max: v.size}
+}
+
+// Next decodes the next frame into dst, which must have the bounds of the
+// video. It returns io.EOF after the last frame.
+func (v *Video) Next(dst *image.RGBA) error {
+	if !dst.Rect.Eq(v.Bounds()) {
+		return fmt.Errorf("image bounds %v does not match video bounds %v", dst.Rect, v.Bounds())
+	}
+
+	for y := 0; y < v.size.Y; y++ {
+		row := dst.Pix[y*dst.Stride : y*dst.Stride+4*v.size.X]
+		if _, err := io.ReadFull(v.r, row); err != nil {
+			if errors.Is(err, io.EOF) && y == 0 {
+				return v.wait()
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+				if err := v.wait(); err != nil {
+					return err
+				}
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wait waits for ffmpeg to exit after its output has ended. It returns io.EOF
+// if ffmpeg succeeded.
+func (v *Video) wait() error {
+	if err := v.cmd.Wait(); err != nil {
+		return fmt.Errorf("ffmpeg: %w: %s", err, bytes.TrimSpace(v.stderr.Bytes()))
+	}
+	return io.EOF
+}
+
+// Close stops decoding the video.
+func (v *Video) Close() error {
+	if v.cmd.ProcessState != nil {
+		return nil // already exited
+	}
+	v.cmd.Process.Kill()
+	v.cmd.Wait()
+	return nil
+}
+
+// PlayVideo decodes the video file at path at the canvas size and adds its
+// frames to the canvas at the frame rate of the video, until the video ends
+// or ctx is canceled. Adding frames blocks while the player is full, so the
+// video is decoded only as fast as it plays. opts.Size is ignored.
+func PlayVideo(ctx context.Context, canvas *leddraw.LEDCanvasAnimated, path string, opts VideoOpts) error {
+	bounds := canvas.CanvasBounds()
+	opts.Size = bounds.Size()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	v, err := OpenVideo(ctx, path, opts)
+	if err != nil {
+		return err
+	}
+	defer v.Close()
+
+	// The canvas renders each frame as it is added, so one image is enough.
+	img := image.NewRGBA(bounds)
+
+	var prev animation.Milliseconds
+	for n := int64(0); ; n++ {
+		if err := v.Next(img); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("cannot decode frame %d: %w", n, err)
+		}
+
+		// Frames wait for the time since the previous frame. Rounding the
+		// time of each frame, rather than its duration, keeps the video in
+		// sync at frame rates that aren't whole milliseconds.
+		due := animation.DurationToMs(v.FrameRate().FrameTime(n))
+		frame := animation.Frame[*image.RGBA]{Image: img}
+		if n > 0 {
+			frame.DurationMs = due - prev
+		}
+		prev = due
+
+		if err := canvas.AddFrames(ctx, []animation.Frame[*image.RGBA]{frame}); err != nil {
+			return err
+		}
+	}
+}
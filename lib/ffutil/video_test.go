@@ -0,0 +1,49 @@
+package ffutil
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFrameRate(t *testing.T) {
+	rate, err := ParseFrameRate("30000/1001")
+	assert.NoError(t, err)
+	assert.Equal(t, FrameRate{30000, 1001}, rate)
+	assert.Equal(t, "29.97002997002997 fps", rate.String())
+
+	// 29.97 fps doesn't drift from the video over time.
+	assert.Equal(t, 1001*time.Second, rate.FrameTime(30000))
+	assert.Equal(t, 1001*100000*time.Second, rate.FrameTime(30000*100000))
+
+	rate, err = ParseFrameRate("25")
+	assert.NoError(t, err)
+	assert.Equal(t, 40*time.Millisecond, rate.FrameTime(1))
+
+	for _, s := range []string{"", "0/0", "30/0", "-1/1", "abc"} {
+		_, err := ParseFrameRate(s)
+		assert.Error(t, err, "%q", s)
+	}
+
+	rate, err = parseProbedFrameRate("r_frame_rate=50/1\navg_frame_rate=0/0\n")
+	assert.NoError(t, err)
+	assert.Equal(t, FrameRate{50, 1}, rate)
+
+	rate, err = parseProbedFrameRate("r_frame_rate=50/1\navg_frame_rate=25/1\n")
+	assert.NoError(t, err)
+	assert.Equal(t, FrameRate{25, 1}, rate)
+}
+
+func TestVideoArgs(t *testing.T) {
+	args := videoArgs("in.mp4", VideoOpts{Size: image.Pt(64, 32), Fit: true, Loop: true, Filters: []string{"hflip"}})
+	assert.Equal(t, "-hide_banner -loglevel error -nostdin -stream_loop -1 -i in.mp4 -map 0:v:0 "+
+		"-vf hflip,scale=64:32:force_original_aspect_ratio=decrease,pad=64:32:(ow-iw)/2:(oh-ih)/2:color=black "+
+		"-f rawvideo -pix_fmt rgba -", args.String())
+
+	args = videoArgs("in.mp4", VideoOpts{Size: image.Pt(64, 32)})
+	assert.Equal(t, "-hide_banner -loglevel error -nostdin -i in.mp4 -map 0:v:0 "+
+		"-vf scale=64:32:force_original_aspect_ratio=increase,crop=64:32 "+
+		"-f rawvideo -pix_fmt rgba -", args.String())
+}
@@ -0,0 +1,222 @@
+package ledanim
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// The binary format is little-endian:
+//
+//	header:
+//	  magic         [8]byte "LEDANIM\x00"
+//	  version       uint16
+//	  flags         uint16  (bit 0: position hash is present)
+//	  led count     uint32
+//	  frame count   uint32
+//	  position hash uint64  (zero if absent)
+//	frame, frame count times:
+//	  duration ms     uint32
+//	  duration beats  float64
+//	  jump back       int32
+//	  loop count      uint32
+//	  marker          uint16 length, then UTF-8 bytes
+//	  jump to marker  uint16 length, then UTF-8 bytes
+//	  colors          [led count][3]byte, as R, G, B
+
+var binaryMagic = [8]byte{'L', 'E', 'D', 'A', 'N', 'I', 'M', 0}
+
+const flagPositionHash = 1 << 0
+
+type binaryHeader struct {
+	Magic        [8]byte
+	Version      uint16
+	Flags        uint16
+	LEDCount     uint32
+	FrameCount   uint32
+	PositionHash uint64
+}
+
+type binaryFrame struct {
+	DurationMs     uint32
+	DurationBeats  float64
+	JumpBackAmount int32
+	LoopCount      uint32
+}
+
+func writeBinary(w io.Writer, f *File) error {
+	if f.LEDCount > math.MaxUint32 || len(f.Frames) > math.MaxUint32 {
+		return fmt.Errorf("animation is too large")
+	}
+
+	header := binaryHeader{
+		Magic:        binaryMagic,
+		Version:      Version,
+		LEDCount:     uint32(f.LEDCount),
+		FrameCount:   uint32(len(f.Frames)),
+		PositionHash: f.PositionHash,
+	}
+	if f.PositionHash != 0 {
+		header.Flags |= flagPositionHash
+	}
+	if err := binary.Write(w, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("cannot write header: %w", err)
+	}
+
+	colors := make([]byte, 3*f.LEDCount)
+	for i, frame := range f.Frames {
+		if err := writeBinaryFrame(w, frame, colors); err != nil {
+			return fmt.Errorf("cannot write frame %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func writeBinaryFrame(w io.Writer, frame animation.Frame[leddraw.LEDStrip], colors []byte) error {
+	err := binary.Write(w, binary.LittleEndian, &binaryFrame{
+		DurationMs:     uint32(frame.DurationMs),
+		DurationBeats:  frame.DurationBeats,
+		JumpBackAmount: frame.JumpBackAmount,
+		LoopCount:      frame.LoopCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeString(w, frame.Marker); err != nil {
+		return err
+	}
+	if err := writeString(w, frame.JumpToMarker); err != nil {
+		return err
+	}
+
+	for i, c := range frame.Image {
+		colors[3*i+0] = c.R
+		colors[3*i+1] = c.G
+		colors[3*i+2] = c.B
+	}
+	_, err = w.Write(colors)
+	return err
+}
+
+func writeString(w io.Writer, s string) error {
+	if len(s) > math.MaxUint16 {
+		return fmt.Errorf("string of %d bytes is too long", len(s))
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// maxBinaryPrealloc is the largest number of bytes of frames that readBinary
+// allocates up front, so that a bogus header can't make it allocate a lot of
+// memory before reading anything.
+const maxBinaryPrealloc = 16 << 20
+
+// maxBinaryPreallocFrames is the largest number of frames that readBinary
+// allocates up front. Frames without LEDs take no bytes of colors, so this
+// bounds them too.
+const maxBinaryPreallocFrames = 1 << 12
+
+func readBinary(r io.Reader) (*File, error) {
+	var header binaryHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", noEOF(err))
+	}
+	if header.Version != Version {
+		return nil, fmt.Errorf("unsupported version %d", header.Version)
+	}
+	if header.LEDCount > MaxLEDCount {
+		return nil, fmt.Errorf("too many LEDs (%d), max %d", header.LEDCount, MaxLEDCount)
+	}
+
+	f := &File{
+		LEDCount: int(header.LEDCount),
+	}
+	if header.Flags&flagPositionHash != 0 {
+		f.PositionHash = header.PositionHash
+	}
+
+	frameSize := 3 * int64(header.LEDCount)
+	prealloc := min(int64(header.FrameCount), maxBinaryPreallocFrames)
+	if frameSize > 0 {
+		prealloc = min(prealloc, maxBinaryPrealloc/frameSize)
+	}
+	f.Frames = make([]animation.Frame[leddraw.LEDStrip], 0, prealloc)
+
+	colors := make([]byte, frameSize)
+	for i := uint32(0); i < header.FrameCount; i++ {
+		frame, err := readBinaryFrame(r, colors)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read frame %d: %w", i, noEOF(err))
+		}
+		f.Frames = append(f.Frames, frame)
+	}
+
+	return f, nil
+}
+
+func readBinaryFrame(r io.Reader, colors []byte) (animation.Frame[leddraw.LEDStrip], error) {
+	var bf binaryFrame
+	if err := binary.Read(r, binary.LittleEndian, &bf); err != nil {
+		return animation.Frame[leddraw.LEDStrip]{}, err
+	}
+
+	marker, err := readString(r)
+	if err != nil {
+		return animation.Frame[leddraw.LEDStrip]{}, err
+	}
+	jumpToMarker, err := readString(r)
+	if err != nil {
+		return animation.Frame[leddraw.LEDStrip]{}, err
+	}
+
+	if _, err := io.ReadFull(r, colors); err != nil {
+		return animation.Frame[leddraw.LEDStrip]{}, err
+	}
+	strip := make(leddraw.LEDStrip, len(colors)/3)
+	for i := range strip {
+		strip[i].R = colors[3*i+0]
+		strip[i].G = colors[3*i+1]
+		strip[i].B = colors[3*i+2]
+	}
+
+	return animation.Frame[leddraw.LEDStrip]{
+		Image:          strip,
+		JumpBackAmount: bf.JumpBackAmount,
+		DurationMs:     animation.Milliseconds(bf.DurationMs),
+		DurationBeats:  bf.DurationBeats,
+		LoopCount:      bf.LoopCount,
+		Marker:         marker,
+		JumpToMarker:   jumpToMarker,
+	}, nil
+}
+
+func readString(r io.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// noEOF turns io.EOF into io.ErrUnexpectedEOF, since the file ended before
+// everything that the header promised was read.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package ledanim
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// jsonFile is the JSON encoding of a File. Colors are hex strings of 3 bytes
+// per LED, such as "ff0000" for a single red LED, and the position hash is a
+// hex string so that it survives JSON numbers being doubles.
+type jsonFile struct {
+	Version      int         `json:"version"`
+	LEDCount     int         `json:"led_count"`
+	PositionHash string      `json:"position_hash,omitempty"`
+	Frames       []jsonFrame `json:"frames"`
+}
+
+type jsonFrame struct {
+	DurationMs     animation.Milliseconds `json:"duration_ms,omitempty"`
+	DurationBeats  float64                `json:"duration_beats,omitempty"`
+	JumpBackAmount int32                  `json:"jump_back_amount,omitempty"`
+	LoopCount      uint32                 `json:"loop_count,omitempty"`
+	Marker         string                 `json:"marker,omitempty"`
+	JumpToMarker   string                 `json:"jump_to_marker,omitempty"`
+	LEDs           string                 `json:"leds"`
+}
+
+func writeJSON(w io.Writer, f *File) error {
+	jf := jsonFile{
+		Version:  Version,
+		LEDCount: f.LEDCount,
+		Frames:   make([]jsonFrame, len(f.Frames)),
+	}
+	if f.PositionHash != 0 {
+		jf.PositionHash = strconv.FormatUint(f.PositionHash, 16)
+	}
+
+	colors := make([]byte, 3*f.LEDCount)
+	for i, frame := range f.Frames {
+		for j, c := range frame.Image {
+			colors[3*j+0] = c.R
+			colors[3*j+1] = c.G
+			colors[3*j+2] = c.B
+		}
+		jf.Frames[i] = jsonFrame{
+			DurationMs:     frame.DurationMs,
+			DurationBeats:  frame.DurationBeats,
+			JumpBackAmount: frame.JumpBackAmount,
+			LoopCount:      frame.LoopCount,
+			Marker:         frame.Marker,
+			JumpToMarker:   frame.JumpToMarker,
+			LEDs:           hex.EncodeToString(colors),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jf)
+}
+
+func readJSON(r io.Reader) (*File, error) {
+	var jf jsonFile
+	if err := json.NewDecoder(r).Decode(&jf); err != nil {
+		return nil, fmt.Errorf("cannot decode JSON: %w", err)
+	}
+	if jf.Version != Version {
+		return nil, fmt.Errorf("unsupported version %d", jf.Version)
+	}
+
+	f := &File{
+		LEDCount: jf.LEDCount,
+		Frames:   make([]animation.Frame[leddraw.LEDStrip], len(jf.Frames)),
+	}
+	if jf.PositionHash != "" {
+		hash, err := strconv.ParseUint(jf.PositionHash, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position hash: %w", err)
+		}
+		f.PositionHash = hash
+	}
+
+	for i, frame := range jf.Frames {
+		colors, err := hex.DecodeString(frame.LEDs)
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: invalid LEDs: %w", i, err)
+		}
+		if len(colors)%3 != 0 {
+			return nil, fmt.Errorf("frame %d: LEDs are not a multiple of 3 bytes", i)
+		}
+
+		strip := make(leddraw.LEDStrip, len(colors)/3)
+		for j := range strip {
+			strip[j].R = colors[3*j+0]
+			strip[j].G = colors[3*j+1]
+			strip[j].B = colors[3*j+2]
+		}
+
+		f.Frames[i] = animation.Frame[leddraw.LEDStrip]{
+			Image:          strip,
+			JumpBackAmount: frame.JumpBackAmount,
+			DurationMs:     frame.DurationMs,
+			DurationBeats:  frame.DurationBeats,
+			LoopCount:      frame.LoopCount,
+			Marker:         frame.Marker,
+			JumpToMarker:   frame.JumpToMarker,
+		}
+	}
+
+	return f, nil
+}
@@ -0,0 +1,207 @@
+// Package ledanim reads and writes LED animation files. An animation file
+// holds a list of frames that are already rendered to the LEDs, along with
+// their timing and loop information, so that animations can be generated
+// offline and played back later by the daemon or the rpi tools.
+//
+// There are two encodings of the same data: a compact binary one and a JSON
+// one that is easier to produce from other languages. Read accepts both.
+package ledanim
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+// Version is the version of the file format that this package writes.
+const Version = 1
+
+// MaxLEDCount is the largest number of LEDs that an animation may have. It is
+// far more than any strip has, and it keeps a bogus file from making Read
+// allocate a lot of memory for each frame.
+const MaxLEDCount = 1 << 16
+
+// MaxDurationBeats is the longest DurationBeats that Read gives a frame. Longer
+// ones are cut down to it, so that the player never waits for a time that
+// doesn't fit in a time.Duration.
+const MaxDurationBeats = 1 << 16
+
+// File is an LED animation.
+type File struct {
+	// LEDCount is the number of LEDs in each frame.
+	LEDCount int
+	// PositionHash, if not zero, is the HashPositions of the LEDs that the
+	// animation was made for. Players can check it to catch animations that
+	// were made for a different tree.
+	PositionHash uint64
+	// Frames are the frames of the animation. Each one has LEDCount colors.
+	Frames []animation.Frame[leddraw.LEDStrip]
+}
+
+// Format is an encoding of an animation file.
+type Format uint8
+
+const (
+	// FormatBinary is the compact binary encoding.
+	FormatBinary Format = iota
+	// FormatJSON is the JSON encoding.
+	FormatJSON
+)
+
+// FormatFromPath returns the format for the extension of the given path:
+// FormatJSON for ".json" and FormatBinary for anything else, such as
+// ".ledanim".
+func FormatFromPath(path string) Format {
+	if filepath.Ext(path) == ".json" {
+		return FormatJSON
+	}
+	return FormatBinary
+}
+
+// ErrPositionMismatch is returned by CheckPositions if the animation was made
+// for different LED positions.
+var ErrPositionMismatch = errors.New("animation was made for different LED positions")
+
+// HashPositions hashes the positions of the LEDs. Moving all LEDs by the same
+// amount doesn't change the hash, so positions that were translated, such as
+// by leddraw.NewLEDCanvas, hash the same as the original ones.
+func HashPositions(ledPositions []image.Point) uint64 {
+	var origin image.Point
+	if len(ledPositions) > 0 {
+		origin = ledPositions[0]
+		for _, pt := range ledPositions[1:] {
+			origin.X = min(origin.X, pt.X)
+			origin.Y = min(origin.Y, pt.Y)
+		}
+	}
+
+	h := fnv.New64a()
+	var buf [16]byte
+	for _, pt := range ledPositions {
+		pt = pt.Sub(origin)
+		binary.LittleEndian.PutUint64(buf[0:], uint64(int64(pt.X)))
+		binary.LittleEndian.PutUint64(buf[8:], uint64(int64(pt.Y)))
+		h.Write(buf[:])
+	}
+
+	// Zero means that there is no hash, so never return it.
+	if sum := h.Sum64(); sum != 0 {
+		return sum
+	}
+	return 1
+}
+
+// CheckPositions returns ErrPositionMismatch if the animation has a position
+// hash and it doesn't match the given LED positions. It also checks the
+// number of LEDs.
+func (f *File) CheckPositions(ledPositions []image.Point) error {
+	if f.LEDCount != len(ledPositions) {
+		return fmt.Errorf("animation has %d LEDs, want %d", f.LEDCount, len(ledPositions))
+	}
+	if f.PositionHash != 0 && f.PositionHash != HashPositions(ledPositions) {
+		return ErrPositionMismatch
+	}
+	return nil
+}
+
+func (f *File) validate() error {
+	if f.LEDCount < 0 || f.LEDCount > MaxLEDCount {
+		return fmt.Errorf("invalid LED count %d", f.LEDCount)
+	}
+	for i, frame := range f.Frames {
+		if len(frame.Image) != f.LEDCount {
+			return fmt.Errorf("frame %d has %d LEDs, want %d", i, len(frame.Image), f.LEDCount)
+		}
+		if frame.JumpBackAmount < 0 || int(frame.JumpBackAmount) > i {
+			return fmt.Errorf("frame %d jumps back by %d frames", i, frame.JumpBackAmount)
+		}
+		if !(frame.DurationBeats >= 0) || math.IsInf(frame.DurationBeats, 1) {
+			return fmt.Errorf("frame %d lasts an invalid %v beats", i, frame.DurationBeats)
+		}
+	}
+	return nil
+}
+
+// Write writes the animation to w in the given format.
+func Write(w io.Writer, f *File, format Format) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	switch format {
+	case FormatBinary:
+		return writeBinary(w, f)
+	case FormatJSON:
+		return writeJSON(w, f)
+	default:
+		return fmt.Errorf("unknown format %d", format)
+	}
+}
+
+// Read reads an animation from r in either format.
+func Read(r io.Reader) (*File, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(len(binaryMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("cannot read animation: %w", err)
+	}
+
+	var f *File
+	if bytes.Equal(magic, binaryMagic[:]) {
+		f, err = readBinary(br)
+	} else {
+		f, err = readJSON(br)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid animation: %w", err)
+	}
+	for i := range f.Frames {
+		f.Frames[i].DurationBeats = min(f.Frames[i].DurationBeats, MaxDurationBeats)
+	}
+	return f, nil
+}
+
+// WriteFile writes the animation to the file at path, in the format given by
+// FormatFromPath.
+func WriteFile(path string, f *File) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", path, err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	if err := Write(w, f, FormatFromPath(path)); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// ReadFile reads the animation from the file at path.
+func ReadFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", path, err)
+	}
+	defer file.Close()
+
+	return Read(file)
+}
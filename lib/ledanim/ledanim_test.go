@@ -0,0 +1,183 @@
+package ledanim
+
+import (
+	"bytes"
+	encbinary "encoding/binary"
+	"image"
+	"io"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/leddraw"
+)
+
+var testPositions = []image.Point{{0, 0}, {10, 10}, {20, 0}}
+
+var testFile = &File{
+	LEDCount:     3,
+	PositionHash: HashPositions(testPositions),
+	Frames: []animation.Frame[leddraw.LEDStrip]{
+		{
+			Image:  leddraw.LEDStrip{{R: 0xFF}, {G: 0xFF}, {B: 0xFF}},
+			Marker: "start",
+		},
+		{
+			Image:      leddraw.LEDStrip{{R: 1, G: 2, B: 3}, {}, {R: 0xFF, G: 0xFF, B: 0xFF}},
+			DurationMs: 250,
+		},
+		{
+			Image:          leddraw.LEDStrip{{}, {}, {}},
+			DurationBeats:  0.5,
+			JumpBackAmount: 2,
+			LoopCount:      3,
+			JumpToMarker:   "start",
+		},
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatBinary, FormatJSON} {
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, testFile, format))
+
+		f, err := Read(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, testFile, f)
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	assert.NoError(t, WriteFile(path, testFile))
+
+	f, err := ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, testFile, f)
+}
+
+func TestJSON(t *testing.T) {
+	f, err := Read(strings.NewReader(`{
+		"version": 1,
+		"led_count": 2,
+		"frames": [
+			{"duration_ms": 100, "leds": "ff000000ff00"},
+			{"duration_ms": 100, "jump_back_amount": 1, "leds": "0000ff000000"}
+		]
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &File{
+		LEDCount: 2,
+		Frames: []animation.Frame[leddraw.LEDStrip]{
+			{Image: leddraw.LEDStrip{{R: 0xFF}, {G: 0xFF}}, DurationMs: 100},
+			{Image: leddraw.LEDStrip{{B: 0xFF}, xcolor.RGB{}}, DurationMs: 100, JumpBackAmount: 1},
+		},
+	}, f)
+}
+
+func TestReadInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, testFile, FormatBinary))
+	binary := buf.Bytes()
+
+	// withBeats is the test file with the beats of its last frame replaced,
+	// written without being checked.
+	withBeats := func(beats float64) []byte {
+		f := *testFile
+		f.Frames = slices.Clone(f.Frames)
+		f.Frames[2].DurationBeats = beats
+
+		var buf bytes.Buffer
+		if err := writeBinary(&buf, &f); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	// header is a binary header that claims ledCount LEDs and as many frames
+	// as there can be, without any frames after it.
+	header := func(ledCount uint32) []byte {
+		var buf bytes.Buffer
+		err := encbinary.Write(&buf, encbinary.LittleEndian, &binaryHeader{
+			Magic:      binaryMagic,
+			Version:    Version,
+			LEDCount:   ledCount,
+			FrameCount: math.MaxUint32,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{"empty", nil, "cannot decode JSON"},
+		{"truncated", binary[:len(binary)-1], io.ErrUnexpectedEOF.Error()},
+		{"header only", binary[:16], io.ErrUnexpectedEOF.Error()},
+		{"version", append([]byte("LEDANIM\x00\x02\x00"), binary[10:]...), "unsupported version 2"},
+		{"json version", []byte(`{"version": 2}`), "unsupported version 2"},
+		{"json leds", []byte(`{"version": 1, "led_count": 1, "frames": [{"leds": "ff00"}]}`), "not a multiple of 3 bytes"},
+		{"json led count", []byte(`{"version": 1, "led_count": 2, "frames": [{"leds": "ff0000"}]}`), "frame 0 has 1 LEDs, want 2"},
+		{"json jump", []byte(`{"version": 1, "led_count": 0, "frames": [{"jump_back_amount": 1, "leds": ""}]}`), "frame 0 jumps back by 1 frames"},
+		{"huge led count", header(math.MaxUint32), "too many LEDs (4294967295)"},
+		{"huge frame count", header(MaxLEDCount), io.ErrUnexpectedEOF.Error()},
+		{"huge frame count without leds", header(0), io.ErrUnexpectedEOF.Error()},
+		{"json led count too large", []byte(`{"version": 1, "led_count": 65537, "frames": []}`), "invalid LED count 65537"},
+		{"NaN beats", withBeats(math.NaN()), "frame 2 lasts an invalid NaN beats"},
+		{"infinite beats", withBeats(math.Inf(1)), "frame 2 lasts an invalid +Inf beats"},
+		{"negative infinite beats", withBeats(math.Inf(-1)), "frame 2 lasts an invalid -Inf beats"},
+		{"negative beats", withBeats(-1), "frame 2 lasts an invalid -1 beats"},
+		{"json negative beats", []byte(`{"version": 1, "led_count": 0, "frames": [{"duration_beats": -0.5, "leds": ""}]}`), "frame 0 lasts an invalid -0.5 beats"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(test.input))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestReadLongBeats(t *testing.T) {
+	f := *testFile
+	f.Frames = slices.Clone(f.Frames)
+	f.Frames[2].DurationBeats = 1e300
+
+	for _, format := range []Format{FormatBinary, FormatJSON} {
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, &f, format))
+
+		got, err := Read(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(MaxDurationBeats), got.Frames[2].DurationBeats)
+		assert.Equal(t, 0.5, testFile.Frames[2].DurationBeats)
+	}
+}
+
+func TestCheckPositions(t *testing.T) {
+	translated := make([]image.Point, len(testPositions))
+	for i, pt := range testPositions {
+		translated[i] = pt.Add(image.Pt(5, -5))
+	}
+	assert.NoError(t, testFile.CheckPositions(testPositions))
+	assert.NoError(t, testFile.CheckPositions(translated))
+
+	moved := []image.Point{{0, 0}, {10, 11}, {20, 0}}
+	assert.IsError(t, testFile.CheckPositions(moved), ErrPositionMismatch)
+
+	err := testFile.CheckPositions(testPositions[:2])
+	assert.EqualError(t, err, "animation has 3 LEDs, want 2")
+
+	unhashed := &File{LEDCount: 3}
+	assert.NoError(t, unhashed.CheckPositions(moved))
+}
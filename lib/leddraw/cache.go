@@ -0,0 +1,312 @@
+package leddraw
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// The cache format is little-endian:
+//
+//	header:
+//	  magic        [8]byte "LEDCANV\x00"
+//	  version      uint16
+//	  flags        uint16  (bit 0: the pixels are weighed by a Kernel)
+//	  led count    uint32
+//	  pixel count  uint32
+//	  hash         uint64  (of the positions and options, see cacheHash)
+//	  led rect     [4]int32, as min X, min Y, max X, max Y
+//	  canvas rect  [4]int32
+//	  transform    [4]float64, as A, B, T.X, T.Y of the ledpos.Similarity
+//	led, led count times:
+//	  exact position       [2]float64
+//	  position             [2]int32
+//	  normalized position  [2]float64
+//	  pixels end           uint32
+//	pixel, pixel count times:
+//	  offset     int32
+//	  intensity  float32
+
+var cacheMagic = [8]byte{'L', 'E', 'D', 'C', 'A', 'N', 'V', 0}
+
+// cacheVersion is the version of the cache format. It is also hashed into the
+// name of the cache files, so bumping it makes NewLEDCanvas ignore old ones.
+const cacheVersion = 2
+
+const cacheFlagResample = 1 << 0
+
+type cacheHeader struct {
+	Magic      [8]byte
+	Version    uint16
+	Flags      uint16
+	LEDCount   uint32
+	PixelCount uint32
+	Hash       uint64
+	LEDRect    [4]int32
+	CanvasRect [4]int32
+	Transform  [4]float64
+}
+
+type cacheLED struct {
+	Exact      [2]float64
+	Position   [2]int32
+	Normalized [2]float64
+	End        uint32
+}
+
+type cachePixel struct {
+	Offset    int32
+	Intensity float32
+}
+
+// maxCachePrealloc is the largest number of bytes of pixels that
+// ReadLEDCanvasCache allocates up front, so that a bogus header can't make it
+// allocate a lot of memory before reading anything.
+const maxCachePrealloc = 16 << 20
+
+// cacheHash hashes the LED positions and the options that change the pixels
+// of the LEDs. The positions are hashed as they are, so that the cache is
+// only used for the exact same positions.
+func cacheHash(exact []ledpos.Point, opts LEDCanvasOpts) uint64 {
+	h := fnv.New64a()
+	write := func(v any) { binary.Write(h, binary.LittleEndian, v) }
+
+	write(uint16(cacheVersion))
+	write(uint32(len(exact)))
+	for _, pt := range exact {
+		write([2]float64{pt.X, pt.Y})
+	}
+	write(opts.PPI)
+	write([4]int64{int64(opts.Size.X), int64(opts.Size.Y), int64(opts.Padding), int64(opts.Orientation)})
+	write([2]bool{opts.Intensity != nil, opts.Kernel != nil})
+	io.WriteString(h, opts.CacheKey)
+
+	return h.Sum64()
+}
+
+// cachePath returns the path of the cache file for the given hash.
+func cachePath(dir string, hash uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("ledcanvas-%016x.bin", hash))
+}
+
+// cachedLEDCanvas loads the canvas for the exact LED positions from
+// opts.CacheDir. If it isn't there, or it can't be read, the canvas is created
+// and written there for next time.
+func cachedLEDCanvas(exact []ledpos.Point, opts LEDCanvasOpts) (*LEDCanvas, error) {
+	hash := cacheHash(exact, opts)
+	path := cachePath(opts.CacheDir, hash)
+
+	if c, err := readCacheFile(path, opts); err == nil {
+		if c.cacheHash == hash {
+			return c, nil
+		}
+		c.Close()
+	}
+
+	c, err := newLEDCanvas(exact, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCacheFile(path, c); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("cannot cache LED canvas: %w", err)
+	}
+
+	return c, nil
+}
+
+func readCacheFile(path string, opts LEDCanvasOpts) (*LEDCanvas, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadLEDCanvasCache(bufio.NewReader(f), opts)
+}
+
+// writeCacheFile writes the cache of c to path. It writes to a temporary file
+// first, so that a canvas that is starting up at the same time never reads
+// half a file.
+func writeCacheFile(path string, c *LEDCanvas) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".ledcanvas-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := c.WriteCache(w); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// WriteCache writes the precomputed pixels of the LEDs to w, which
+// ReadLEDCanvasCache reads back. This lets a canvas be created once, such as
+// on a faster computer, and loaded instantly later.
+func (c *LEDCanvas) WriteCache(w io.Writer) error {
+	if len(c.ledPixels) > math.MaxUint32 {
+		return fmt.Errorf("too many pixels (%d)", len(c.ledPixels))
+	}
+
+	header := cacheHeader{
+		Magic:      cacheMagic,
+		Version:    cacheVersion,
+		LEDCount:   uint32(len(c.ledData)),
+		PixelCount: uint32(len(c.ledPixels)),
+		Hash:       c.cacheHash,
+		LEDRect:    rectToCache(c.ledRect),
+		CanvasRect: rectToCache(c.canvasRect),
+		Transform:  [4]float64{c.transform.A, c.transform.B, c.transform.T.X, c.transform.T.Y},
+	}
+	if c.resample {
+		header.Flags |= cacheFlagResample
+	}
+	if err := binary.Write(w, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("cannot write header: %w", err)
+	}
+
+	leds := make([]cacheLED, len(c.ledData))
+	var end int
+	for i, data := range c.ledData {
+		end += len(data.pixels)
+		leds[i] = cacheLED{
+			Exact:      [2]float64{c.exact[i].X, c.exact[i].Y},
+			Position:   [2]int32{int32(c.positions[i].X), int32(c.positions[i].Y)},
+			Normalized: [2]float64{c.normPos[i].X, c.normPos[i].Y},
+			End:        uint32(end),
+		}
+	}
+	if err := binary.Write(w, binary.LittleEndian, leds); err != nil {
+		return fmt.Errorf("cannot write LEDs: %w", err)
+	}
+
+	pixels := make([]cachePixel, len(c.ledPixels))
+	for i, pixel := range c.ledPixels {
+		pixels[i] = cachePixel{Offset: pixel.offset, Intensity: pixel.intensity}
+	}
+	if err := binary.Write(w, binary.LittleEndian, pixels); err != nil {
+		return fmt.Errorf("cannot write pixels: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLEDCanvasCache creates an LEDCanvas from a cache that WriteCache wrote.
+// The pixels of the LEDs are read from the cache, so only Average and Workers
+// of opts are used.
+func ReadLEDCanvasCache(r io.Reader, opts LEDCanvasOpts) (*LEDCanvas, error) {
+	var header cacheHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", noEOF(err))
+	}
+	if header.Magic != cacheMagic {
+		return nil, errors.New("not an LED canvas cache")
+	}
+	if header.Version != cacheVersion {
+		return nil, fmt.Errorf("unsupported version %d", header.Version)
+	}
+	if header.LEDCount > math.MaxInt32 {
+		return nil, fmt.Errorf("too many LEDs (%d)", header.LEDCount)
+	}
+
+	canvasRect := rectFromCache(header.CanvasRect)
+	if canvasRect.Min != (image.Point{}) || canvasRect.Dx() < 0 || canvasRect.Dy() < 0 ||
+		int64(canvasRect.Dx())*int64(canvasRect.Dy()) > math.MaxInt32/4 {
+		return nil, fmt.Errorf("invalid canvas %v", canvasRect)
+	}
+	// offsetEnd is the offset after the last pixel of the canvas.
+	offsetEnd := 4 * int64(canvasRect.Dx()) * int64(canvasRect.Dy())
+
+	n := int(header.LEDCount)
+	c := &LEDCanvas{
+		ledRect:    rectFromCache(header.LEDRect),
+		canvasRect: canvasRect,
+		transform: ledpos.Similarity{
+			A: header.Transform[0],
+			B: header.Transform[1],
+			T: ledpos.Pt(header.Transform[2], header.Transform[3]),
+		},
+		resample:  header.Flags&cacheFlagResample != 0,
+		cacheHash: header.Hash,
+	}
+
+	var ends []int
+	for i := 0; i < n; i++ {
+		var led cacheLED
+		if err := binary.Read(r, binary.LittleEndian, &led); err != nil {
+			return nil, fmt.Errorf("cannot read LED %d: %w", i, noEOF(err))
+		}
+
+		end := int(led.End)
+		if end > int(header.PixelCount) || (i > 0 && end < ends[i-1]) {
+			return nil, fmt.Errorf("LED %d has invalid pixels end %d", i, end)
+		}
+
+		ends = append(ends, end)
+		c.exact = append(c.exact, ledpos.Pt(led.Exact[0], led.Exact[1]))
+		c.positions = append(c.positions, image.Pt(int(led.Position[0]), int(led.Position[1])))
+		c.normPos = append(c.normPos, Vec2{X: led.Normalized[0], Y: led.Normalized[1]})
+	}
+	if n > 0 && ends[n-1] != int(header.PixelCount) {
+		return nil, fmt.Errorf("LEDs have %d pixels, but the header says %d", ends[n-1], header.PixelCount)
+	}
+
+	pixels := make([]ledPixel, 0, min(int(header.PixelCount), maxCachePrealloc/8))
+	for i := uint32(0); i < header.PixelCount; i++ {
+		var pixel cachePixel
+		if err := binary.Read(r, binary.LittleEndian, &pixel); err != nil {
+			return nil, fmt.Errorf("cannot read pixel %d: %w", i, noEOF(err))
+		}
+		if pixel.Offset < 0 || pixel.Offset%4 != 0 || int64(pixel.Offset) >= offsetEnd {
+			return nil, fmt.Errorf("pixel %d has invalid offset %d", i, pixel.Offset)
+		}
+		pixels = append(pixels, ledPixel{offset: pixel.Offset, intensity: pixel.Intensity})
+	}
+
+	c.leds = make(LEDStrip, n)
+	c.setPixels(pixels, ends)
+	c.start(opts)
+
+	return c, nil
+}
+
+func rectToCache(r image.Rectangle) [4]int32 {
+	return [4]int32{int32(r.Min.X), int32(r.Min.Y), int32(r.Max.X), int32(r.Max.Y)}
+}
+
+func rectFromCache(r [4]int32) image.Rectangle {
+	return image.Rect(int(r[0]), int(r[1]), int(r[2]), int(r[3]))
+}
+
+// noEOF turns io.EOF into io.ErrUnexpectedEOF, since the cache ended before
+// everything that the header promised was read.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
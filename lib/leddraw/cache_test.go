@@ -0,0 +1,102 @@
+package leddraw
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestLEDCanvasCache(t *testing.T) {
+	for _, name := range datasets {
+		pts := loadDataset(t, name)
+
+		for _, opts := range []LEDCanvasOpts{
+			{PPI: 72},
+			{PPI: 72, Kernel: GaussianKernel},
+			{Size: image.Pt(64, 48), Padding: 4, Orientation: RotatedRight},
+		} {
+			c, err := NewLEDCanvas(pts, opts)
+			assert.NoError(t, err)
+
+			var buf bytes.Buffer
+			assert.NoError(t, c.WriteCache(&buf))
+
+			loaded, err := ReadLEDCanvasCache(bytes.NewReader(buf.Bytes()), LEDCanvasOpts{})
+			assert.NoError(t, err)
+			assert.Equal(t, c.CanvasBounds(), loaded.CanvasBounds())
+			assert.Equal(t, c.LEDBounds(), loaded.LEDBounds())
+			assert.Equal(t, c.LEDPositions(), loaded.LEDPositions())
+			assert.Equal(t, c.ExactLEDPositions(), loaded.ExactLEDPositions())
+			assert.Equal(t, c.NormalizedLEDPositions(), loaded.NormalizedLEDPositions())
+			assert.Equal(t, c.Transform(), loaded.Transform())
+
+			img := randomImage(c.CanvasBounds())
+			assert.NoError(t, c.Render(img))
+			assert.NoError(t, loaded.Render(img))
+			assert.Equal(t, c.LEDs(), loaded.LEDs(), "%s with %+v", name, opts)
+
+			// A cache that is cut short is an error.
+			_, err = ReadLEDCanvasCache(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), LEDCanvasOpts{})
+			assert.IsError(t, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestLEDCanvasCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	pts := loadDataset(t, "acmtree")
+	opts := LEDCanvasOpts{PPI: 72, CacheDir: dir}
+
+	cacheFiles := func() []string {
+		files, err := filepath.Glob(filepath.Join(dir, "*"))
+		assert.NoError(t, err)
+		return files
+	}
+
+	c, err := NewLEDCanvas(pts, opts)
+	assert.NoError(t, err)
+	files := cacheFiles()
+	assert.Equal(t, 1, len(files))
+
+	loaded, err := NewLEDCanvas(pts, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, c.ledPixels, loaded.ledPixels)
+	assert.Equal(t, files, cacheFiles())
+
+	t.Run("corrupt", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(files[0], []byte("LEDCANV"), 0644))
+
+		rebuilt, err := NewLEDCanvas(pts, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.ledPixels, rebuilt.ledPixels)
+
+		_, err = readCacheFile(files[0], opts)
+		assert.NoError(t, err)
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		for _, opts := range []LEDCanvasOpts{
+			{PPI: 64, CacheDir: dir},
+			{PPI: 72, CacheDir: dir, CacheKey: "other"},
+			{PPI: 72, CacheDir: dir, Kernel: BilinearKernel},
+			{PPI: 72, CacheDir: dir, Orientation: UpsideDown},
+		} {
+			_, err := NewLEDCanvas(pts, opts)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 5, len(cacheFiles()))
+	})
+
+	t.Run("different positions", func(t *testing.T) {
+		moved := append([]image.Point(nil), pts...)
+		moved[0].X++
+		_, err := NewLEDCanvas(moved, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, len(cacheFiles()))
+	})
+}
@@ -0,0 +1,302 @@
+package leddraw
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+// Compositor combines layers of LED strips into a single LED strip. Layers
+// are stacked in the order that they were added, so the first layer is at the
+// bottom. Each time a layer changes, the combined strip is sent to C.
+type Compositor struct {
+	// C receives the combined LED strip. The strip is only valid until the
+	// next one is received.
+	C <-chan LEDStrip
+
+	ch       chan LEDStrip
+	updateCh chan struct{}
+	size     int
+
+	mu     sync.Mutex
+	layers []*Layer
+}
+
+// Layer is a layer of a Compositor.
+type Layer struct {
+	c    *Compositor
+	name string
+
+	// Guarded by c.mu.
+	strip   LEDStrip // nil until the layer is first set
+	opacity float64
+	blend   xcolor.BlendMode
+	region  *Region
+}
+
+// LayerOpts is a set of options for adding a new layer.
+type LayerOpts struct {
+	// Opacity is the opacity of the layer, between 0 and 1. It defaults to 1.
+	// Use Layer.SetOpacity to hide a layer.
+	Opacity float64
+	// Blend is how the layer is blended onto the layers below it. It defaults
+	// to xcolor.BlendNormal.
+	Blend xcolor.BlendMode
+	// Region, if not nil, limits the layer to the LEDs in the region. The
+	// layer leaves all other LEDs as the layers below it left them.
+	Region *Region
+}
+
+// NewCompositor creates a new Compositor for a strip of the given number of
+// LEDs.
+func NewCompositor(size int) *Compositor {
+	ch := make(chan LEDStrip)
+	return &Compositor{
+		C:        ch,
+		ch:       ch,
+		updateCh: make(chan struct{}, 1),
+		size:     size,
+	}
+}
+
+// AddLayer adds a new layer on top of all other layers. Layer names must be
+// unique. The layer is empty, and does not show, until it is first set.
+func (c *Compositor) AddLayer(name string, opts LayerOpts) (*Layer, error) {
+	if opts.Opacity == 0 {
+		opts.Opacity = 1
+	}
+	if err := validateOpacity(opts.Opacity); err != nil {
+		return nil, err
+	}
+	if !opts.Blend.IsValid() {
+		return nil, fmt.Errorf("invalid blend mode %v", opts.Blend)
+	}
+	if opts.Region != nil && opts.Region.Size() != c.size {
+		return nil, fmt.Errorf(
+			"region %q is for %d LEDs, want %d",
+			opts.Region.Name(), opts.Region.Size(), c.size)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, l := range c.layers {
+		if l.name == name {
+			return nil, fmt.Errorf("layer %q already exists", name)
+		}
+	}
+
+	l := &Layer{
+		c:       c,
+		name:    name,
+		opacity: opts.Opacity,
+		blend:   opts.Blend,
+		region:  opts.Region,
+	}
+	c.layers = append(c.layers, l)
+	return l, nil
+}
+
+// Layer returns the layer with the given name, or nil if there is none.
+func (c *Compositor) Layer(name string) *Layer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, l := range c.layers {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Layers returns all layers from the bottom to the top.
+func (c *Compositor) Layers() []*Layer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	layers := make([]*Layer, len(c.layers))
+	copy(layers, c.layers)
+	return layers
+}
+
+// Composite combines all layers into dst and returns it. dst is reused if it
+// is large enough. Layers are blended onto black.
+func (c *Compositor) Composite(dst LEDStrip) LEDStrip {
+	if cap(dst) < c.size {
+		dst = make(LEDStrip, c.size)
+	}
+	dst = dst[:c.size]
+	dst.Clear()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, l := range c.layers {
+		if l.strip == nil || l.opacity == 0 {
+			continue
+		}
+		opaque := l.blend == xcolor.BlendNormal && l.opacity == 1
+		switch {
+		case l.region != nil && opaque:
+			l.region.Mask(dst, l.strip)
+		case l.region != nil:
+			for _, i := range l.region.LEDs() {
+				dst[i] = xcolor.Blend(l.blend, dst[i], l.strip[i], l.opacity)
+			}
+		case opaque:
+			copy(dst, l.strip)
+		default:
+			for i, top := range l.strip {
+				dst[i] = xcolor.Blend(l.blend, dst[i], top, l.opacity)
+			}
+		}
+	}
+
+	return dst
+}
+
+// Run sends the combined strip to C each time a layer changes, until the
+// context is canceled. Changes that happen while the receiver is busy are
+// combined into one.
+func (c *Compositor) Run(ctx context.Context) error {
+	// Double buffer the output, since the receiver may still be using the
+	// last strip while we combine the next one.
+	var out [2]LEDStrip
+	var outIx int
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.updateCh:
+		}
+
+		out[outIx] = c.Composite(out[outIx])
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c.ch <- out[outIx]:
+			outIx = (outIx + 1) % len(out)
+		}
+	}
+}
+
+func (c *Compositor) update() {
+	select {
+	case c.updateCh <- struct{}{}:
+	default:
+		// An update is already pending.
+	}
+}
+
+// Name returns the name of the layer.
+func (l *Layer) Name() string {
+	return l.name
+}
+
+// Region returns the region that the layer is limited to, or nil if it
+// covers all LEDs.
+func (l *Layer) Region() *Region {
+	return l.region
+}
+
+// Set sets the LEDs of the layer to a copy of strip, which must have as many
+// LEDs as the compositor.
+func (l *Layer) Set(strip LEDStrip) error {
+	if len(strip) != l.c.size {
+		return fmt.Errorf("layer %q: got %d LEDs, want %d", l.name, len(strip), l.c.size)
+	}
+
+	l.c.mu.Lock()
+	l.strip = CopyLEDStrip(l.strip, strip)
+	l.c.mu.Unlock()
+
+	l.c.update()
+	return nil
+}
+
+// Clear empties the layer so that it no longer shows.
+func (l *Layer) Clear() {
+	l.c.mu.Lock()
+	l.strip = nil
+	l.c.mu.Unlock()
+
+	l.c.update()
+}
+
+// Follow sets the layer to each frame received from ch, such as the C channel
+// of an animation.Player or LEDCanvasAnimated, until ch is closed or the
+// context is canceled.
+func (l *Layer) Follow(ctx context.Context, ch <-chan animation.Frame[LEDStrip]) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case frame, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := l.Set(frame.Image); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Opacity returns the opacity of the layer.
+func (l *Layer) Opacity() float64 {
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	return l.opacity
+}
+
+// SetOpacity sets the opacity of the layer, between 0 and 1. 0 hides the
+// layer.
+func (l *Layer) SetOpacity(opacity float64) error {
+	if err := validateOpacity(opacity); err != nil {
+		return err
+	}
+
+	l.c.mu.Lock()
+	l.opacity = opacity
+	l.c.mu.Unlock()
+
+	l.c.update()
+	return nil
+}
+
+// Blend returns the blend mode of the layer.
+func (l *Layer) Blend() xcolor.BlendMode {
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	return l.blend
+}
+
+// SetBlend sets how the layer is blended onto the layers below it.
+func (l *Layer) SetBlend(mode xcolor.BlendMode) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid blend mode %v", mode)
+	}
+
+	l.c.mu.Lock()
+	l.blend = mode
+	l.c.mu.Unlock()
+
+	l.c.update()
+	return nil
+}
+
+func validateOpacity(opacity float64) error {
+	if math.IsNaN(opacity) || opacity < 0 || opacity > 1 {
+		return fmt.Errorf("opacity %v is not between 0 and 1", opacity)
+	}
+	return nil
+}
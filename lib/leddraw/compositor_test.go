@@ -0,0 +1,127 @@
+package leddraw
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+func TestCompositor(t *testing.T) {
+	red := xcolor.RGB{R: 0xFF}
+	grey := xcolor.RGB{R: 0x80, G: 0x80, B: 0x80}
+	blue := xcolor.RGB{B: 0xFF}
+
+	tests := []struct {
+		name  string
+		blend xcolor.BlendMode
+		alpha float64
+		want  xcolor.RGB
+	}{
+		{"normal", xcolor.BlendNormal, 1, grey},
+		{"normal_half", xcolor.BlendNormal, 0.5, xcolor.RGB{R: 0xC0, G: 0x40, B: 0x40}},
+		{"add", xcolor.BlendAdd, 1, xcolor.RGB{R: 0xFF, G: 0x80, B: 0x80}},
+		{"multiply", xcolor.BlendMultiply, 1, xcolor.RGB{R: 0x80}},
+		{"screen", xcolor.BlendScreen, 1, xcolor.RGB{R: 0xFF, G: 0x80, B: 0x80}},
+		{"hidden", xcolor.BlendNormal, 0, red},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewCompositor(2)
+
+			bottom, err := c.AddLayer("bottom", LayerOpts{})
+			assert.NoError(t, err)
+			top, err := c.AddLayer("top", LayerOpts{Blend: test.blend})
+			assert.NoError(t, err)
+			assert.NoError(t, top.SetOpacity(test.alpha))
+
+			assert.NoError(t, bottom.Set(LEDStrip{red, red}))
+			assert.NoError(t, top.Set(LEDStrip{grey, grey}))
+
+			got := c.Composite(nil)
+			assert.Equal(t, LEDStrip{test.want, test.want}, got)
+		})
+	}
+
+	t.Run("order", func(t *testing.T) {
+		c := NewCompositor(1)
+
+		bottom, err := c.AddLayer("bottom", LayerOpts{})
+		assert.NoError(t, err)
+		_, err = c.AddLayer("empty", LayerOpts{})
+		assert.NoError(t, err)
+		top, err := c.AddLayer("top", LayerOpts{})
+		assert.NoError(t, err)
+
+		_, err = c.AddLayer("top", LayerOpts{})
+		assert.EqualError(t, err, `layer "top" already exists`)
+
+		assert.Equal(t, LEDStrip{{}}, c.Composite(nil))
+
+		assert.NoError(t, bottom.Set(LEDStrip{red}))
+		assert.Equal(t, LEDStrip{red}, c.Composite(nil))
+
+		assert.NoError(t, top.Set(LEDStrip{blue}))
+		assert.Equal(t, LEDStrip{blue}, c.Composite(nil))
+
+		top.Clear()
+		assert.Equal(t, LEDStrip{red}, c.Composite(nil))
+
+		assert.Equal(t, top, c.Layer("top"))
+		assert.Zero(t, c.Layer("missing"))
+		assert.Equal(t, 3, len(c.Layers()))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c := NewCompositor(1)
+
+		_, err := c.AddLayer("a", LayerOpts{Opacity: 2})
+		assert.Error(t, err)
+		_, err = c.AddLayer("a", LayerOpts{Blend: 100})
+		assert.Error(t, err)
+
+		l, err := c.AddLayer("a", LayerOpts{})
+		assert.NoError(t, err)
+		assert.EqualError(t, l.Set(LEDStrip{red, red}), `layer "a": got 2 LEDs, want 1`)
+	})
+}
+
+func TestCompositorRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	c := NewCompositor(1)
+	go c.Run(ctx)
+
+	bg, err := c.AddLayer("background", LayerOpts{})
+	assert.NoError(t, err)
+	overlay, err := c.AddLayer("overlay", LayerOpts{Blend: xcolor.BlendAdd})
+	assert.NoError(t, err)
+
+	receive := func() LEDStrip {
+		t.Helper()
+		select {
+		case strip := <-c.C:
+			return strip
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for strip")
+			return nil
+		}
+	}
+
+	assert.NoError(t, bg.Set(LEDStrip{{R: 0x10}}))
+	assert.Equal(t, LEDStrip{{R: 0x10}}, receive())
+
+	frames := make(chan animation.Frame[LEDStrip])
+	go overlay.Follow(ctx, frames)
+
+	frames <- animation.Frame[LEDStrip]{Image: LEDStrip{{G: 0x20}}}
+	assert.Equal(t, LEDStrip{{R: 0x10, G: 0x20}}, receive())
+
+	assert.NoError(t, overlay.SetBlend(xcolor.BlendMultiply))
+	assert.Equal(t, LEDStrip{{}}, receive())
+}
@@ -0,0 +1,129 @@
+package leddraw
+
+import (
+	"image"
+	"math"
+)
+
+// Kernel is a resampling kernel. Unlike an IntensityFunc, whose intensities
+// are multiplied into the colors of the pixels before they are averaged, the
+// weights of a kernel are normalized so that the weights of each LED add up to
+// 1, and the color of the LED is the weighted sum of the colors of its pixels.
+type Kernel struct {
+	// Support is how far the kernel reaches from the LED along either axis,
+	// in canvas pixels. Weight is zero for pixels beyond it.
+	Support float64
+	// Weight returns the weight of a pixel whose center is dx and dy canvas
+	// pixels away from the LED. The weight may be negative, such as for the
+	// lobes of a Lanczos kernel.
+	Weight func(dx, dy float64) float64
+}
+
+// KernelFunc creates a Kernel for LEDs that are spacing canvas pixels apart.
+// The spacing is the smallest distance between two LEDs.
+type KernelFunc func(spacing float64) Kernel
+
+// GaussianKernel is a KernelFunc for a Gaussian blur whose standard deviation
+// is half of the spacing between LEDs.
+func GaussianKernel(spacing float64) Kernel {
+	sigma := spacing / 2
+	return Kernel{
+		Support: 3 * sigma,
+		Weight: func(dx, dy float64) float64 {
+			return math.Exp(-(dx*dx + dy*dy) / (2 * sigma * sigma))
+		},
+	}
+}
+
+// NewLanczosKernel creates a KernelFunc for a Lanczos kernel with a lobes,
+// which is stretched so that its lobes are as wide as the spacing between
+// LEDs. a is usually 2 or 3.
+func NewLanczosKernel(a int) KernelFunc {
+	return func(spacing float64) Kernel {
+		return Kernel{
+			Support: float64(a) * spacing,
+			Weight: func(dx, dy float64) float64 {
+				return lanczos(dx/spacing, a) * lanczos(dy/spacing, a)
+			},
+		}
+	}
+}
+
+func lanczos(x float64, a int) float64 {
+	switch {
+	case x == 0:
+		return 1
+	case math.Abs(x) >= float64(a):
+		return 0
+	}
+	px := math.Pi * x
+	return float64(a) * math.Sin(px) * math.Sin(px/float64(a)) / (px * px)
+}
+
+// BoxKernel is a KernelFunc that averages the square area around each LED
+// that is as wide as the spacing between LEDs. Pixels on the edge of the area
+// are weighted by how much of them it covers.
+func BoxKernel(spacing float64) Kernel {
+	r := spacing / 2
+	return Kernel{
+		Support: r + 0.5,
+		Weight: func(dx, dy float64) float64 {
+			return overlap(dx, r) * overlap(dy, r)
+		},
+	}
+}
+
+// overlap returns how much of the pixel whose center is at d overlaps the
+// range [-r, r].
+func overlap(d, r float64) float64 {
+	return max(0, min(d+0.5, r)-max(d-0.5, -r))
+}
+
+// BilinearKernel is a KernelFunc that samples the image at the exact position
+// of each LED by interpolating between the 4 nearest pixels. It ignores the
+// spacing between LEDs.
+func BilinearKernel(spacing float64) Kernel {
+	return Kernel{
+		Support: 1,
+		Weight: func(dx, dy float64) float64 {
+			return max(0, 1-math.Abs(dx)) * max(0, 1-math.Abs(dy))
+		},
+	}
+}
+
+// kernelPixels returns the pixels in canvasRect around the LED at pos, in
+// canvas pixels, with their weights normalized to add up to 1. It returns nil
+// if the weights add up to zero or less.
+func kernelPixels(canvasRect image.Rectangle, pos Vec2, kernel Kernel) []pointIntensity {
+	area := image.Rect(
+		int(math.Floor(pos.X-kernel.Support)),
+		int(math.Floor(pos.Y-kernel.Support)),
+		int(math.Ceil(pos.X+kernel.Support))+1,
+		int(math.Ceil(pos.Y+kernel.Support))+1,
+	).Intersect(canvasRect)
+
+	var points []pointIntensity
+	var sum float64
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			w := kernel.Weight(float64(x)+0.5-pos.X, float64(y)+0.5-pos.Y)
+			if w == 0 {
+				continue
+			}
+			points = append(points, pointIntensity{
+				Point:     image.Pt(x, y),
+				Intensity: w,
+			})
+			sum += w
+		}
+	}
+
+	if sum <= 0 {
+		return nil
+	}
+	for i := range points {
+		points[i].Intensity /= sum
+	}
+	return points
+}
@@ -0,0 +1,122 @@
+package leddraw
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+var testKernels = []struct {
+	name   string
+	kernel KernelFunc
+}{
+	{"gaussian", GaussianKernel},
+	{"lanczos2", NewLanczosKernel(2)},
+	{"lanczos3", NewLanczosKernel(3)},
+	{"box", BoxKernel},
+	{"bilinear", BilinearKernel},
+}
+
+func TestKernelWeights(t *testing.T) {
+	assert.Equal(t, 1.0, lanczos(0, 3))
+	assert.True(t, math.Abs(lanczos(1, 3)) < 1e-15)
+	assert.Equal(t, 0.0, lanczos(3, 3))
+	assert.True(t, lanczos(1.5, 3) < 0)
+
+	box := BoxKernel(3)
+	assert.Equal(t, 2.0, box.Support)
+	assert.Equal(t, 1.0, box.Weight(0, 0))
+	assert.Equal(t, 0.5, box.Weight(1.5, 0))
+	assert.Equal(t, 0.25, box.Weight(1.5, -1.5))
+	assert.Equal(t, 0.0, box.Weight(2, 0))
+
+	bilinear := BilinearKernel(100)
+	assert.Equal(t, 1.0, bilinear.Weight(0, 0))
+	assert.Equal(t, 0.25, bilinear.Weight(0.5, 0.5))
+	assert.Equal(t, 0.0, bilinear.Weight(1, 0))
+}
+
+func TestKernelUniform(t *testing.T) {
+	want := xcolor.RGB{R: 200, G: 100, B: 50}
+
+	for _, test := range testKernels {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := NewLEDCanvas(loadDataset(t, "fake"), LEDCanvasOpts{PPI: 72, Kernel: test.kernel})
+			assert.NoError(t, err)
+
+			for i, data := range c.ledData {
+				assert.True(t, len(data.pixels) > 0, "LED %d has no pixels", i)
+
+				var sum float64
+				for _, pixel := range data.pixels {
+					sum += float64(pixel.intensity)
+				}
+				assert.True(t, math.Abs(sum-1) < 1e-4, "LED %d has weights adding up to %v", i, sum)
+			}
+
+			// A uniform image resamples to its color, as the weights are
+			// normalized.
+			img := image.NewRGBA(c.CanvasBounds())
+			draw.Draw(img, img.Rect, image.NewUniform(color.RGBA{200, 100, 50, 255}), image.Point{}, draw.Src)
+			assert.NoError(t, c.Render(img))
+			for i, led := range c.LEDs() {
+				assert.Equal(t, want, led, "LED %d", i)
+			}
+		})
+	}
+}
+
+func TestBilinearKernel(t *testing.T) {
+	// The canvas is 20x20 with 2 pixels per unit, so the LED at (5, 5) is on
+	// the corner between pixels (9, 9) and (10, 10).
+	pts := []image.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}, {5, 5}}
+	c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 20, Kernel: BilinearKernel})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), c.CanvasBounds())
+
+	img := image.NewRGBA(c.CanvasBounds())
+	img.SetRGBA(9, 9, color.RGBA{R: 0})
+	img.SetRGBA(10, 9, color.RGBA{R: 40})
+	img.SetRGBA(9, 10, color.RGBA{R: 80})
+	img.SetRGBA(10, 10, color.RGBA{R: 120})
+
+	assert.NoError(t, c.Render(img))
+	assert.Equal(t, xcolor.RGB{R: 60}, c.LEDs()[4])
+}
+
+func TestKernelRenderAllocs(t *testing.T) {
+	c, err := NewLEDCanvas(loadDataset(t, "fake"), LEDCanvasOpts{PPI: 72, Kernel: GaussianKernel})
+	assert.NoError(t, err)
+
+	img := randomImage(c.CanvasBounds())
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := c.Render(img); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkKernelRender(b *testing.B) {
+	for _, test := range testKernels {
+		b.Run(test.name, func(b *testing.B) {
+			c, err := NewLEDCanvas(loadDataset(b, "fake"), LEDCanvasOpts{PPI: 72, Kernel: test.kernel})
+			assert.NoError(b, err)
+
+			img := randomImage(c.CanvasBounds())
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := c.Render(img); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
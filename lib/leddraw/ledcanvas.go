@@ -3,22 +3,50 @@ package leddraw
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"math"
+	"runtime"
+	"slices"
+	"time"
 
-	"libdb.so/acm-christmas/internal/intmath"
 	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/ledpos"
 )
 
 // LEDCanvas is a canvas of LED points.
 type LEDCanvas struct {
-	leds    LEDStrip
-	ledData []ledData
-	ledRect image.Rectangle
-
-	// pixelData is a precalculated array of pixels. A pixel is deemed relevant
-	// if it is within the radius of any LED. The radius is a custom parameter.
-	pixelMap   map[image.Point]pixelData
+	leds      LEDStrip
+	ledData   []ledData
+	ledRect   image.Rectangle
+	positions []image.Point
+	exact     []ledpos.Point
+	normPos   []Vec2
+
+	// ledPixels holds the pixels of all LEDs, which ledData slices into. Each
+	// LED's pixels are contiguous and sorted by offset, so rendering walks
+	// the image forwards.
+	ledPixels []ledPixel
+	// averages is the buffer that the pixels of an LED are averaged in. It
+	// fits the LED with the most pixels.
+	averages   []xcolor.AveragingPoint
 	canvasRect image.Rectangle
+	// scratch is the canvas that RenderImage copies the pixels that the LEDs
+	// use into, which are at usedPixels. palette is the palette of the last
+	// paletted image. They are created by the first RenderImage.
+	scratch    *image.RGBA
+	usedPixels []int32
+	palette    []color.RGBA
+	// pool renders the LEDs in parallel. It is nil if the canvas renders on
+	// the calling goroutine.
+	pool *renderPool
+	// transform moves the LED positions that the canvas was created from onto
+	// the canvas.
+	transform ledpos.Similarity
+	// resample is true if the pixels were weighed by a Kernel.
+	resample bool
+	// cacheHash is the cacheHash of the positions and options that the
+	// canvas was created from.
+	cacheHash uint64
 
 	opts LEDCanvasOpts
 }
@@ -26,11 +54,8 @@ type LEDCanvas struct {
 // ledData is the data of an LED. It is specifically crafted to be as small as
 // possible.
 type ledData struct {
-	// neighborPixels is a list of pixels that are within the radius of the LED.
-	neighborPixels []pointIntensity
-	// neighborAverages is a list of colors of the pixels that are within the
-	// radius of the LED. It has the threshold already applied to it.
-	neighborAverages []xcolor.AveragingPoint
+	// pixels is a list of pixels that are within the radius of the LED.
+	pixels []ledPixel
 }
 
 type pointIntensity struct {
@@ -38,19 +63,15 @@ type pointIntensity struct {
 	Intensity float64
 }
 
-type pixelData struct {
-	neighborLEDs []neighborLED
-}
-
-type neighborLED struct {
-	// index is the index of the nearest LED to the pixel.
-	index int32
+// ledPixel is a pixel that is within the radius of an LED.
+type ledPixel struct {
+	// offset is the offset of the pixel into the Pix slice of an image whose
+	// stride is 4 times the canvas width.
+	offset int32
 	// intensity is multiplied by the color of the pixel to get the color of the
 	// LED. LEDs that have multiple pixels within their radius will have their
-	// color averaged.
-	//
-	// TODO: instead of averaging, use the pixel that is closest to the LED.
-	// Perhaps consider more complex algorithms, like Lanczos resampling.
+	// color averaged. With a Kernel, it is the normalized weight of the pixel
+	// instead, and the colors are summed.
 	intensity float32
 }
 
@@ -70,78 +91,286 @@ type LEDCanvasOpts struct {
 	// Average is the averaging function used to average the colors of the
 	// pixels that are within the radius of an LED.
 	Average xcolor.AveragingFunc
+	// Kernel, if not nil, resamples the image with a kernel instead, such as
+	// GaussianKernel or BilinearKernel. Intensity and Average are then
+	// ignored.
+	Kernel KernelFunc
 	// PPI is the number of pixels per inch of the final LED canvas. The higher
-	// the PPI, the higher the resolution of the final LED canvas.
+	// the PPI, the higher the resolution of the final LED canvas. The LEDs are
+	// PPI pixels tall on the canvas, and it defaults to 128.
 	PPI float64
+	// Size, if not zero, is the size of the canvas in pixels, and PPI is then
+	// ignored. The LEDs are scaled to fit inside it without being stretched,
+	// and centered, so the canvas may have a different aspect ratio than the
+	// LEDs, such as that of a video.
+	Size image.Point
+	// Padding is the number of pixels of canvas around the LEDs on each side,
+	// so that the pixels around the LEDs at the edges aren't cut off. It is
+	// taken from the inside of Size, or added to the canvas otherwise.
+	Padding int
+	// Orientation is how the LEDs are turned on the canvas. The LEDs are
+	// upright by default.
+	Orientation Orientation
+	// Workers is the number of goroutines that Render splits the LEDs across.
+	// Zero or one renders on the calling goroutine, and a negative number uses
+	// GOMAXPROCS. Every LED is averaged the same way regardless, so the
+	// results don't change. Average must be safe for concurrent use if there
+	// is more than one worker, which the averaging functions in xcolor are.
+	//
+	// Handing each render to the workers costs a few microseconds, so this
+	// only pays off for canvases with many pixels per LED. The workers run
+	// until Close is called.
+	Workers int
+	// CacheDir, if not empty, is the directory that NewLEDCanvas caches the
+	// pixels of each LED in, in a file named after a hash of the LED positions
+	// and these options. Finding the pixels takes a while for many LEDs, so
+	// this lets a canvas for the same LEDs start up instantly next time.
+	CacheDir string
+	// CacheKey is also hashed into the name of the cache file. Intensity and
+	// Kernel are functions, which can't be hashed, so CacheKey must differ
+	// between canvases that use different ones, such as by naming them and
+	// their parameters.
+	CacheKey string
+}
+
+// Orientation is how the LEDs are turned on a canvas.
+type Orientation uint8
+
+const (
+	// Upright draws the LEDs on the canvas as they are.
+	Upright Orientation = iota
+	// RotatedRight turns the LEDs a quarter turn clockwise, so that the top of
+	// the tree is on the right of the canvas.
+	RotatedRight
+	// UpsideDown turns the LEDs half a turn.
+	UpsideDown
+	// RotatedLeft turns the LEDs a quarter turn counterclockwise, so that the
+	// top of the tree is on the left of the canvas.
+	RotatedLeft
+)
+
+// rotation returns the cosine and sine of the angle that o turns the LEDs by,
+// clockwise, and false if o isn't a valid orientation.
+func (o Orientation) rotation() (cos, sin float64, ok bool) {
+	switch o {
+	case Upright:
+		return 1, 0, true
+	case RotatedRight:
+		return 0, 1, true
+	case UpsideDown:
+		return -1, 0, true
+	case RotatedLeft:
+		return 0, -1, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// fitScale returns the largest scale that fits size inside bounds. It is 1 if
+// size is zero.
+func fitScale(size, bounds ledpos.Point) float64 {
+	scale := math.Inf(1)
+	if size.X > 0 {
+		scale = bounds.X / size.X
+	}
+	if size.Y > 0 {
+		scale = min(scale, bounds.Y/size.Y)
+	}
+	if math.IsInf(scale, 1) {
+		return 1
+	}
+	return scale
 }
 
 // NewLEDCanvas creates a new LEDCanvas from the given LED positions.
 //
 // ledPositions is a slice of points, where each point represents the position
-// of an LED.
-func NewLEDCanvas(ledPositions []image.Point, opts LEDCanvasOpts) (*LEDCanvas, error) {
+// of an LED. The positions may be image.Points or exact ledpos.Points, which
+// the pixels of the canvas are measured from.
+func NewLEDCanvas[P ledpos.Position](ledPositions []P, opts LEDCanvasOpts) (*LEDCanvas, error) {
 	const maxLEDs = math.MaxInt32
 	if len(ledPositions) > maxLEDs {
 		return nil, fmt.Errorf("too many LEDs (%d), max %d", len(ledPositions), maxLEDs)
 	}
 
-	var ledRect image.Rectangle
-	for _, ledPos := range ledPositions {
-		ledRect.Min.X = intmath.Min(ledRect.Min.X, ledPos.X)
-		ledRect.Min.Y = intmath.Min(ledRect.Min.Y, ledPos.Y)
-		ledRect.Max.X = intmath.Max(ledRect.Max.X, ledPos.X)
-		ledRect.Max.Y = intmath.Max(ledRect.Max.Y, ledPos.Y)
-	}
-
-	// Translate the LED positions so that the top left LED is at (0, 0).
-	for i, ledPos := range ledPositions {
-		ledPositions[i] = ledPos.Sub(ledRect.Min)
+	exact := ledpos.Points(ledPositions)
+	if opts.CacheDir != "" {
+		return cachedLEDCanvas(exact, opts)
 	}
+	return newLEDCanvas(exact, opts)
+}
 
-	aspectRatio := float64(ledRect.Dx()) / float64(ledRect.Dy())
-	canvasRect := image.Rect(0, 0, int(opts.PPI*aspectRatio), int(opts.PPI))
+// newLEDCanvas creates a new LEDCanvas from the exact LED positions, which it
+// takes ownership of.
+func newLEDCanvas(exact []ledpos.Point, opts LEDCanvasOpts) (*LEDCanvas, error) {
+	hash := cacheHash(exact, opts)
 
 	if opts.PPI == 0 {
 		opts.PPI = 128
 	}
-	if opts.Intensity == nil {
-		minDist := FindMinDistance(ledPositions)
-		canvasScale := float64(canvasRect.Dx()) / float64(ledRect.Dx())
-		opts.Intensity = NewStepIntensity(minDist.Distance / 2 * canvasScale)
+	cos, sin, ok := opts.Orientation.rotation()
+	if !ok {
+		return nil, fmt.Errorf("invalid orientation %d", opts.Orientation)
 	}
-	if opts.Average == nil {
-		opts.Average = xcolor.NewSquaredAveraging()
+	if opts.Padding < 0 {
+		return nil, fmt.Errorf("invalid padding %d", opts.Padding)
+	}
+
+	ledMin, ledMax := ledpos.Bounds(exact)
+	ledRect := image.Rect(
+		int(math.Floor(ledMin.X)), int(math.Floor(ledMin.Y)),
+		int(math.Ceil(ledMax.X)), int(math.Ceil(ledMax.Y)))
+	ledSize := ledMax.Sub(ledMin)
+
+	// turned is the size of the LEDs once they are turned onto the canvas.
+	turned := ledSize
+	if sin != 0 {
+		turned = ledpos.Pt(ledSize.Y, ledSize.X)
+	}
+
+	// inner is the size of the space inside the padding that the LEDs are
+	// scaled to fit, and canvasScale is the number of pixels per unit of the
+	// LED positions.
+	var inner ledpos.Point
+	var canvasScale float64
+	var canvasRect image.Rectangle
+	pad := opts.Padding
+	if opts.Size != (image.Point{}) {
+		if opts.Size.X <= 2*pad || opts.Size.Y <= 2*pad {
+			return nil, fmt.Errorf("canvas size %v leaves no room inside padding %d", opts.Size, pad)
+		}
+		canvasRect = image.Rectangle{Max: opts.Size}
+		inner = ledpos.Pt(float64(opts.Size.X-2*pad), float64(opts.Size.Y-2*pad))
+		canvasScale = fitScale(turned, inner)
+	} else {
+		switch {
+		case turned.Y > 0:
+			canvasScale = opts.PPI / turned.Y
+		case turned.X > 0:
+			canvasScale = opts.PPI / turned.X
+		default:
+			canvasScale = 1
+		}
+		// Round the canvas up, so that the LEDs on the bottom and right
+		// edges are on it.
+		inner = turned.Mul(canvasScale)
+		canvasRect = image.Rect(0, 0, int(math.Ceil(inner.X))+2*pad, int(math.Ceil(inner.Y))+2*pad)
+	}
+	if canvasRect.Dx()*canvasRect.Dy() > math.MaxInt32/4 {
+		return nil, fmt.Errorf("canvas %v is too large", canvasRect)
 	}
 
-	pixelMap := make(map[image.Point]pixelData, int(opts.PPI)*len(ledPositions))
-	leds := make([]ledData, len(ledPositions))
+	// Turn and scale the LEDs around their middle, and put it in the middle
+	// of the space inside the padding.
+	transform := ledpos.Similarity{A: cos * canvasScale, B: sin * canvasScale}
+	center := ledpos.Pt(float64(pad)+inner.X/2, float64(pad)+inner.Y/2)
+	transform.T = center.Sub(transform.Apply(ledMin.Add(ledMax).Mul(0.5)))
 
-	for i, led := range ledPositions {
-		nearestPixels := allPixelsWithIntensity(canvasRect, ledRect, led, opts.Intensity, 0.01)
+	var spacing float64
+	if opts.Intensity == nil || opts.Kernel != nil {
+		_, _, d := ledpos.MinDistance(exact)
+		spacing = d * canvasScale
+	}
+	if opts.Intensity == nil {
+		opts.Intensity = NewStepIntensity(spacing / 2)
+	}
+
+	var ledPixels []ledPixel
+	ends := make([]int, len(exact))
 
-		leds[i] = ledData{
-			neighborPixels:   nearestPixels,
-			neighborAverages: make([]xcolor.AveragingPoint, 0, len(nearestPixels)),
+	var kernel Kernel
+	if opts.Kernel != nil {
+		kernel = opts.Kernel(max(spacing, 1))
+	}
+
+	for i, led := range exact {
+		onCanvas := transform.Apply(led)
+		pos := Vec2{X: onCanvas.X, Y: onCanvas.Y}
+
+		var nearestPixels []pointIntensity
+		if opts.Kernel != nil {
+			nearestPixels = kernelPixels(canvasRect, pos, kernel)
+		} else {
+			nearestPixels = allPixelsWithIntensity(canvasRect, pos, opts.Intensity, 0.01)
 		}
 
+		start := len(ledPixels)
 		for _, pixel := range nearestPixels {
-			data := pixelMap[pixel.Point]
-			data.neighborLEDs = append(data.neighborLEDs, neighborLED{
-				index:     int32(i),
+			// The search may go past the edges of the canvas, but those
+			// pixels are never rendered.
+			if !pixel.Point.In(canvasRect) {
+				continue
+			}
+			ledPixels = append(ledPixels, ledPixel{
+				offset:    int32(4 * ptIx(canvasRect, pixel.X, pixel.Y)),
 				intensity: float32(pixel.Intensity),
 			})
-			pixelMap[pixel.Point] = data
 		}
+
+		pixels := ledPixels[start:]
+		slices.SortFunc(pixels, func(a, b ledPixel) int { return int(a.offset - b.offset) })
+
+		ends[i] = len(ledPixels)
 	}
 
-	return &LEDCanvas{
-		leds:       make(LEDStrip, len(ledPositions)),
-		ledData:    leds,
+	// Translate the LED positions so that the top left LED is at (0, 0). The
+	// integer positions are rounded first, so that they are the same as if
+	// they had been given as image.Points.
+	positions := ledpos.ImagePoints(exact)
+	for i := range exact {
+		exact[i] = exact[i].Sub(ledMin)
+		positions[i] = positions[i].Sub(ledRect.Min)
+	}
+
+	c := &LEDCanvas{
+		leds:       make(LEDStrip, len(exact)),
 		ledRect:    ledRect,
-		pixelMap:   pixelMap,
+		positions:  positions,
+		exact:      exact,
+		normPos:    normalizeExact(exact, ledSize),
 		canvasRect: canvasRect,
-		opts:       opts,
-	}, nil
+		transform:  transform,
+		resample:   opts.Kernel != nil,
+		cacheHash:  hash,
+	}
+	c.setPixels(ledPixels, ends)
+	c.start(opts)
+
+	return c, nil
+}
+
+// setPixels sets the pixels of the LEDs, where the pixels of LED i end at
+// ends[i].
+func (c *LEDCanvas) setPixels(ledPixels []ledPixel, ends []int) {
+	var maxPixels int
+	c.ledData = make([]ledData, len(ends))
+	for i := range c.ledData {
+		start := 0
+		if i > 0 {
+			start = ends[i-1]
+		}
+		c.ledData[i] = ledData{pixels: ledPixels[start:ends[i]:ends[i]]}
+		maxPixels = max(maxPixels, ends[i]-start)
+	}
+
+	c.ledPixels = ledPixels
+	c.averages = make([]xcolor.AveragingPoint, 0, maxPixels)
+}
+
+// start finishes setting up a canvas whose pixels are set.
+func (c *LEDCanvas) start(opts LEDCanvasOpts) {
+	if opts.Average == nil {
+		opts.Average = xcolor.NewSquaredAveraging()
+	}
+	c.opts = opts
+
+	workers := opts.Workers
+	if workers < 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers = min(workers, len(c.ledData)); workers > 1 {
+		c.pool = newRenderPool(c, workers)
+	}
 }
 
 func ptIx(r image.Rectangle, x, y int) int {
@@ -159,11 +388,46 @@ func (c *LEDCanvas) LEDBounds() image.Rectangle {
 	return c.ledRect
 }
 
+// LEDPositions returns the positions of the LEDs on the LED canvas. The
+// returned slice must not be modified.
+func (c *LEDCanvas) LEDPositions() []image.Point {
+	return c.positions
+}
+
+// Transform returns the transform from the LED positions, as they were given
+// to NewLEDCanvas, to their positions on the canvas in pixels. Its Invert
+// moves points on the canvas back to where they are among the LEDs.
+func (c *LEDCanvas) Transform() ledpos.Similarity {
+	return c.transform
+}
+
+// ExactLEDPositions returns the exact positions of the LEDs on the LED canvas,
+// which LEDPositions rounds. The returned slice must not be modified.
+func (c *LEDCanvas) ExactLEDPositions() []ledpos.Point {
+	return c.exact
+}
+
+// NormalizedLEDPositions returns the positions of the LEDs normalized to the
+// LED bounds, as described in ShaderFunc. The returned slice must not be
+// modified.
+func (c *LEDCanvas) NormalizedLEDPositions() []Vec2 {
+	return c.normPos
+}
+
 // Stride returns the stride of the LED canvas.
 func (c *LEDCanvas) Stride() int {
 	return c.canvasRect.Dx()
 }
 
+// Close stops the workers of the LED canvas, if it has any. The canvas keeps
+// working after Close, but it renders on the calling goroutine.
+func (c *LEDCanvas) Close() {
+	if c.pool != nil {
+		c.pool.stop()
+		c.pool = nil
+	}
+}
+
 // LEDs returns the internal buffer of the LED canvas.
 func (c *LEDCanvas) LEDs() LEDStrip {
 	return c.leds
@@ -174,96 +438,177 @@ func (c *LEDCanvas) Clear() {
 	c.leds.Clear()
 }
 
-// Render renders the given image to the LED canvas. The alpha channel of the
-// image is ignored.
+// Render renders the given image to the LED canvas. The image is blended over
+// black using its alpha channel, which is the same as ignoring the alpha
+// channel of an image that is premultiplied, as *image.RGBA is.
 func (c *LEDCanvas) Render(src *image.RGBA) error {
+	return c.RenderOver(src, nil)
+}
+
+// RenderOver renders the given image to the LED canvas, blending it over the
+// LEDs in below using its alpha channel. Each LED is as opaque as the pixels
+// that it is rendered from are on average, so an image that is fully
+// transparent around an LED leaves the color from below, and an opaque one
+// renders the same as Render.
+//
+// below may be the LEDs of the canvas itself, which overlays the image on the
+// last render, or a strip filled with a background color. If below is nil,
+// the image is blended over black.
+func (c *LEDCanvas) RenderOver(src *image.RGBA, below LEDStrip) error {
 	if !src.Rect.Eq(c.canvasRect) {
 		return fmt.Errorf(
 			"image bounds %v does not match canvas bounds %v",
 			src.Rect, c.canvasRect)
 	}
+	if below != nil && len(below) != len(c.leds) {
+		return fmt.Errorf("cannot render over %d LEDs, want %d", len(below), len(c.leds))
+	}
 
-	c.Clear()
-
-	// There are two main ways to render this image:
-	//
-	// 1. Iterate over all pixels in the image, and for each pixel, check if it
-	//    has corresponding LEDs. If it does, calculate the intensity of the
-	//    pixel and add it to the LED.
-	// 2. Iterate over known pixels that have corresponding LEDs, and for each
-	//    pixel, calculate the intensity of the pixel and add it to the LED.
-
-	// TODO: benchmark
-	c.render(src)
+	if c.pool != nil {
+		c.pool.render(src, below)
+	} else {
+		c.renderLEDs(src, below, 0, len(c.ledData), c.averages)
+	}
 
 	return nil
 }
 
-func (c *LEDCanvas) render(src *image.RGBA) {
-	y1 := src.Rect.Min.Y
-	y2 := src.Rect.Max.Y
-	x1 := src.Rect.Min.X
-	x2 := src.Rect.Max.X
+// Shade sets the LEDs of the canvas to the colors that fn gives at time t,
+// without going through an image. It returns the LEDs of the canvas, like
+// LEDs. See Shade for details.
+func (c *LEDCanvas) Shade(t time.Duration, fn ShaderFunc) LEDStrip {
+	Shade(c.leds, c.normPos, t, fn)
+	return c.leds
+}
+
+// renderLEDs renders the LEDs in [start, end) from src over the LEDs in below
+// by visiting only the pixels within their radius. below may be nil. averages
+// is used to average the pixels of each LED, and it must fit as many pixels as
+// any of these LEDs have.
+func (c *LEDCanvas) renderLEDs(src *image.RGBA, below LEDStrip, start, end int, averages []xcolor.AveragingPoint) {
+	width := c.canvasRect.Dx()
+	// padded is true if the image has padding after each row, such as a
+	// SubImage, so that offsets don't apply to it as they are.
+	padded := src.Stride != 4*width
+
+	for i := start; i < end; i++ {
+		data := c.ledData[i]
+		if len(data.pixels) == 0 {
+			if below != nil {
+				c.leds[i] = below[i]
+			} else {
+				c.leds[i] = xcolor.RGB{}
+			}
+			continue
+		}
 
-	for y := y1; y < y2; y++ {
-		x := x1
-		p := src.PixOffset(x, y)
-		for x < x2 {
-			data, ok := c.pixelMap[image.Point{X: x, Y: y}]
-			if ok {
+		var led xcolor.RGB
+		if c.resample {
+			led = resample(src, data.pixels, width, padded)
+		} else {
+			points := averages[:0]
+			for _, pixel := range data.pixels {
+				p := pixOffset(src, pixel, width, padded)
 				color := xcolor.RGB{
 					R: src.Pix[p+0],
 					G: src.Pix[p+1],
 					B: src.Pix[p+2],
 				}
-
-				// Calculate the intensity of the pixel.
-				for _, led := range data.neighborLEDs {
-					data := &c.ledData[led.index]
-					data.neighborAverages = append(data.neighborAverages, xcolor.AveragingPoint{
-						Color:     applyIntensity(color, led.intensity),
-						Intensity: led.intensity,
-					})
-				}
+				points = append(points, xcolor.AveragingPoint{
+					Color:     applyIntensity(color, pixel.intensity),
+					Intensity: pixel.intensity,
+				})
 			}
+			led = c.opts.Average(points)
+		}
 
-			x += 1
-			p += 4
+		if below != nil {
+			led = over(led, alpha(src, data.pixels, width, padded), below[i])
 		}
+		c.leds[i] = led
 	}
+}
 
-	// Reset the neighbor colors.
-	for i, data := range c.ledData {
-		if len(data.neighborAverages) == 0 {
-			c.leds[i] = xcolor.RGB{}
-		} else {
-			c.leds[i] = c.opts.Average(data.neighborAverages)
-		}
-		// clear for next render
-		c.ledData[i].neighborAverages = c.ledData[i].neighborAverages[:0]
+// alpha returns the alpha of the pixels in src, weighted by their intensity,
+// from 0 to 0xFF.
+func alpha(src *image.RGBA, pixels []ledPixel, width int, padded bool) float32 {
+	var a, sum float32
+	for _, pixel := range pixels {
+		p := pixOffset(src, pixel, width, padded)
+		a += float32(src.Pix[p+3]) * pixel.intensity
+		sum += pixel.intensity
+	}
+	if sum <= 0 {
+		return 0
+	}
+	return a / sum
+}
+
+// over blends the premultiplied color top, whose alpha is a from 0 to 0xFF,
+// over bottom.
+func over(top xcolor.RGB, a float32, bottom xcolor.RGB) xcolor.RGB {
+	if a >= 0xFF {
+		return top
+	}
+	t := 1 - max(a, 0)/0xFF
+	return xcolor.RGB{
+		R: clampUint8(float32(top.R) + float32(bottom.R)*t),
+		G: clampUint8(float32(top.G) + float32(bottom.G)*t),
+		B: clampUint8(float32(top.B) + float32(bottom.B)*t),
+	}
+}
+
+// resample returns the weighted sum of the colors of the pixels in src.
+func resample(src *image.RGBA, pixels []ledPixel, width int, padded bool) xcolor.RGB {
+	var r, g, b float32
+	for _, pixel := range pixels {
+		p := pixOffset(src, pixel, width, padded)
+		r += float32(src.Pix[p+0]) * pixel.intensity
+		g += float32(src.Pix[p+1]) * pixel.intensity
+		b += float32(src.Pix[p+2]) * pixel.intensity
+	}
+	return xcolor.RGB{
+		R: clampUint8(r),
+		G: clampUint8(g),
+		B: clampUint8(b),
+	}
+}
+
+// clampUint8 rounds v to the nearest uint8. Kernels with negative weights can
+// overshoot the range.
+func clampUint8(v float32) uint8 {
+	return uint8(min(max(v+0.5, 0), 255))
+}
+
+// pixOffset returns the offset of the pixel into src.Pix. padded is true if
+// src has padding after each row, such as a SubImage, so that offsets don't
+// apply to it as they are.
+func pixOffset(src *image.RGBA, pixel ledPixel, width int, padded bool) int {
+	p := int(pixel.offset)
+	if padded {
+		ix := p / 4
+		p = (ix/width)*src.Stride + (ix%width)*4
 	}
+	return p
 }
 
-// allPixelsWithIntensity returns all pixels surrounding the given point that
-// has an intensity greater than minIntensity.
+// allPixelsWithIntensity returns all pixels surrounding the LED at pos, in
+// canvas pixels, that have an intensity greater than minIntensity. The
+// intensity of a pixel is measured from its center, except for the pixel that
+// the LED is in, which always has the full intensity.
 func allPixelsWithIntensity(
-	canvasRect, ledRect image.Rectangle,
-	led image.Point,
+	canvasRect image.Rectangle,
+	pos Vec2,
 	intensityFn IntensityFunc,
 	minIntensity float64,
 ) []pointIntensity {
-	// Calculate the scale between the LED and canvas.
-	canvasScale := float64(canvasRect.Dx()) / float64(ledRect.Dx())
-
-	// Calculate the pt nearest to the given LED point.
-	// This is our "current position".
-	pt := image.Point{
-		X: int(float64(led.X-ledRect.Min.X) * canvasScale),
-		Y: int(float64(led.Y-ledRect.Min.Y) * canvasScale),
+	// Start from the pixel that the LED is in. An LED on the right or bottom
+	// edge of the canvas is in the last pixel.
+	ledPt := image.Point{
+		X: min(int(math.Floor(pos.X)), canvasRect.Max.X-1),
+		Y: min(int(math.Floor(pos.Y)), canvasRect.Max.Y-1),
 	}
-
-	// Save this point so we can calculate the distance.
-	ledPt := pt
+	pt := ledPt
 
 	// Iterate from the center point and outwards in a spiral.
 	// See https://stackoverflow.com/a/3706260/5041327.
@@ -279,8 +624,12 @@ func allPixelsWithIntensity(
 	var duds int
 
 	for {
-		// Scale the point back to the LED canvas.
-		intensity := intensityFn(distance(pt, ledPt))
+		var dist float64
+		if pt != ledPt {
+			dist = math.Hypot(float64(pt.X)+0.5-pos.X, float64(pt.Y)+0.5-pos.Y)
+		}
+
+		intensity := intensityFn(dist)
 		if intensity > minIntensity {
 			points = append(points, pointIntensity{
 				Point:     pt,
@@ -294,7 +643,9 @@ func allPixelsWithIntensity(
 		segmentPassed++
 
 		if segmentPassed == segmentLength {
-			if duds == segmentLength {
+			// The neighbors of the LED's pixel may be duds while the pixels
+			// beyond them aren't, since the LED may be near their corner.
+			if duds == segmentLength && segmentLength > 2 {
 				// The entire segment is duds, so we're done.
 				// Going further out will not yield any more points.
 				break
@@ -313,14 +664,6 @@ func allPixelsWithIntensity(
 	return points
 }
 
-func distance(pt1, pt2 image.Point) float64 {
-	pt1x := float64(pt1.X)
-	pt1y := float64(pt1.Y)
-	pt2x := float64(pt2.X)
-	pt2y := float64(pt2.Y)
-	return math.Sqrt(math.Pow(pt1x-pt2x, 2) + math.Pow(pt1y-pt2y, 2))
-}
-
 // PtDistance is a pair of points and the distance between them.
 type PtDistance struct {
 	Pt1, Pt2 image.Point
@@ -328,28 +671,16 @@ type PtDistance struct {
 }
 
 // FindMinDistance returns the pair of points with the smallest distance
-// between them. It runs in O(n^2) time.
+// between them. It uses a ledpos.Index, so it runs in about O(n log n) time.
 func FindMinDistance(points []image.Point) PtDistance {
-	if len(points) < 2 {
+	i, j, d := ledpos.MinDistance(ledpos.Points(points))
+	if i == -1 {
 		return PtDistance{}
 	}
 
-	minDistance := math.MaxFloat64
-	var minPt1, minPt2 image.Point
-
-	for i, pt1 := range points {
-		for _, pt2 := range points[i+1:] {
-			if d := distance(pt1, pt2); d < minDistance {
-				minDistance = d
-				minPt1 = pt1
-				minPt2 = pt2
-			}
-		}
-	}
-
 	return PtDistance{
-		Pt1:      minPt1,
-		Pt2:      minPt2,
-		Distance: minDistance,
+		Pt1:      points[i],
+		Pt2:      points[j],
+		Distance: d,
 	}
 }
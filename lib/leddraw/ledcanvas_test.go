@@ -0,0 +1,295 @@
+package leddraw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/csvutil"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// datasets are the LED points in the data directory.
+var datasets = []string{"acmtree", "fake"}
+
+func loadDataset(tb testing.TB, name string) []image.Point {
+	pts, err := csvutil.UnmarshalFile[image.Point]("../../data/" + name + "/led-points.csv")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return pts
+}
+
+// randomImage returns an image with random colors. It is the same for the
+// same bounds.
+func randomImage(r image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(r)
+	rand.New(rand.NewSource(int64(r.Dx()))).Read(img.Pix)
+	return img
+}
+
+func TestLEDCanvasRenderStride(t *testing.T) {
+	c, err := NewLEDCanvas(loadDataset(t, "acmtree"), LEDCanvasOpts{PPI: 72})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := randomImage(c.CanvasBounds())
+	if err := c.Render(img); err != nil {
+		t.Fatal(err)
+	}
+	want := slices.Clone(c.LEDs())
+
+	// Render the same image with padding after each row.
+	bounds := c.CanvasBounds()
+	padded := image.NewRGBA(image.Rect(0, 0, bounds.Dx()+7, bounds.Dy()))
+	draw.Draw(padded, bounds, img, image.Point{}, draw.Src)
+
+	if err := c.Render(padded.SubImage(bounds).(*image.RGBA)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(want, c.LEDs()) {
+		t.Errorf("padded image rendered differently:\n got %v\nwant %v", c.LEDs(), want)
+	}
+}
+
+func TestLEDCanvasExactPositions(t *testing.T) {
+	// The LEDs are 10 units apart, so the canvas has 2 pixels per unit, and
+	// the middle LEDs are a pixel apart, so each one only has the pixel that
+	// it is in.
+	pts := []ledpos.Point{ledpos.Pt(0, 0), ledpos.Pt(10, 10), ledpos.Pt(4.25, 0), ledpos.Pt(4.75, 0)}
+	c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), c.CanvasBounds())
+	assert.Equal(t, pts, c.ExactLEDPositions())
+	assert.Equal(t, []image.Point{{0, 0}, {10, 10}, {4, 0}, {5, 0}}, c.LEDPositions())
+
+	// Paint column 9, which only the LED at 4.75 is in.
+	img := image.NewRGBA(c.CanvasBounds())
+	draw.Draw(img, image.Rect(9, 0, 10, 20), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+	assert.NoError(t, c.Render(img))
+	assert.Equal(t, xcolor.RGB{}, c.LEDs()[2])
+	assert.Equal(t, xcolor.RGB{R: 255}, c.LEDs()[3])
+
+	// Integer positions are the same as whole exact positions.
+	ints, err := NewLEDCanvas([]image.Point{{0, 0}, {10, 10}, {4, 0}, {6, 0}}, LEDCanvasOpts{PPI: 20})
+	assert.NoError(t, err)
+	exact, err := NewLEDCanvas([]ledpos.Point{ledpos.Pt(0, 0), ledpos.Pt(10, 10), ledpos.Pt(4, 0), ledpos.Pt(6, 0)}, LEDCanvasOpts{PPI: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, ints.ledPixels, exact.ledPixels)
+}
+
+func TestLEDCanvasBounds(t *testing.T) {
+	pts := []ledpos.Point{ledpos.Pt(100.5, 50), ledpos.Pt(110, 60), ledpos.Pt(105, 55)}
+	given := slices.Clone(pts)
+
+	c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, given, pts, "positions were modified")
+	assert.Equal(t, image.Rect(100, 50, 110, 60), c.LEDBounds())
+	assert.Equal(t, image.Rect(0, 0, 19, 20), c.CanvasBounds())
+	assert.Equal(t, []image.Point{{1, 0}, {10, 10}, {5, 5}}, c.LEDPositions())
+	assert.Equal(t, []ledpos.Point{{X: 0, Y: 0}, {X: 9.5, Y: 10}, {X: 4.5, Y: 5}}, c.ExactLEDPositions())
+	assert.Equal(t, ledpos.Pt(0, 0), c.Transform().Apply(pts[0]))
+	assert.Equal(t, ledpos.Pt(19, 20), c.Transform().Apply(pts[1]))
+
+	// PPI defaults to 128.
+	c, err = NewLEDCanvas(pts, LEDCanvasOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, 128, c.CanvasBounds().Dy())
+}
+
+func TestLEDCanvasLayout(t *testing.T) {
+	// The LEDs are 10 units wide and 20 tall, with one in the middle.
+	pts := []ledpos.Point{ledpos.Pt(0, 0), ledpos.Pt(10, 20), ledpos.Pt(5, 10)}
+
+	tests := []struct {
+		name string
+		opts LEDCanvasOpts
+		// bounds is the canvas, and want is where the LEDs are on it.
+		bounds image.Rectangle
+		want   []ledpos.Point
+	}{
+		{
+			name:   "padding",
+			opts:   LEDCanvasOpts{PPI: 40, Padding: 5},
+			bounds: image.Rect(0, 0, 30, 50),
+			want:   []ledpos.Point{{X: 5, Y: 5}, {X: 25, Y: 45}, {X: 15, Y: 25}},
+		},
+		{
+			name:   "size",
+			opts:   LEDCanvasOpts{Size: image.Pt(100, 100), Padding: 10},
+			bounds: image.Rect(0, 0, 100, 100),
+			want:   []ledpos.Point{{X: 30, Y: 10}, {X: 70, Y: 90}, {X: 50, Y: 50}},
+		},
+		{
+			name:   "rotated right",
+			opts:   LEDCanvasOpts{Size: image.Pt(100, 100), Padding: 10, Orientation: RotatedRight},
+			bounds: image.Rect(0, 0, 100, 100),
+			want:   []ledpos.Point{{X: 90, Y: 30}, {X: 10, Y: 70}, {X: 50, Y: 50}},
+		},
+		{
+			name:   "rotated left",
+			opts:   LEDCanvasOpts{PPI: 10, Orientation: RotatedLeft},
+			bounds: image.Rect(0, 0, 20, 10),
+			want:   []ledpos.Point{{X: 0, Y: 10}, {X: 20, Y: 0}, {X: 10, Y: 5}},
+		},
+		{
+			name:   "upside down",
+			opts:   LEDCanvasOpts{PPI: 20, Orientation: UpsideDown},
+			bounds: image.Rect(0, 0, 10, 20),
+			want:   []ledpos.Point{{X: 10, Y: 20}, {X: 0, Y: 0}, {X: 5, Y: 10}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := NewLEDCanvas(pts, test.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, test.bounds, c.CanvasBounds())
+
+			transform := c.Transform()
+			inverse := transform.Invert()
+			for i, pt := range pts {
+				got := transform.Apply(pt)
+				assert.True(t, got.Distance(test.want[i]) < 1e-9, "LED %d at %v, want %v", i, got, test.want[i])
+				assert.True(t, inverse.Apply(got).Distance(pt) < 1e-9, "LED %d came back at %v", i, inverse.Apply(got))
+			}
+
+			// The middle LED is lit by the pixel that it is on.
+			img := image.NewRGBA(c.CanvasBounds())
+			mid := test.want[2].ImagePoint()
+			img.Set(mid.X, mid.Y, color.RGBA{255, 0, 0, 255})
+			assert.NoError(t, c.Render(img))
+			assert.NotEqual(t, xcolor.RGB{}, c.LEDs()[2])
+		})
+	}
+
+	_, err := NewLEDCanvas(pts, LEDCanvasOpts{Orientation: 4})
+	assert.Error(t, err)
+	_, err = NewLEDCanvas(pts, LEDCanvasOpts{Size: image.Pt(100, 20), Padding: 10})
+	assert.Error(t, err)
+}
+
+func TestLEDCanvasRenderParallel(t *testing.T) {
+	for _, name := range datasets {
+		pts := loadDataset(t, name)
+
+		serial, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 72})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, workers := range []int{-1, 2, 3, 8, len(pts) + 1} {
+			c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 72, Workers: workers})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			// Render a few different images with the same canvas.
+			for i := 0; i < 3; i++ {
+				img := randomImage(c.CanvasBounds())
+				img.Pix[i] ^= 0xFF
+
+				assert.NoError(t, serial.Render(img))
+				assert.NoError(t, c.Render(img))
+				assert.Equal(t, serial.LEDs(), c.LEDs(), "%s with %d workers", name, workers)
+
+				below := slices.Clone(c.LEDs())
+				assert.NoError(t, serial.RenderOver(img, below))
+				assert.NoError(t, c.RenderOver(img, below))
+				assert.Equal(t, serial.LEDs(), c.LEDs(), "%s with %d workers over LEDs", name, workers)
+			}
+
+			// The canvas renders serially once closed.
+			c.Close()
+			assert.NoError(t, c.Render(randomImage(c.CanvasBounds())))
+		}
+	}
+}
+
+func TestSplitLEDs(t *testing.T) {
+	leds := make([]ledData, 10)
+	for i := range leds {
+		leds[i].pixels = make([]ledPixel, i)
+	}
+
+	for n := 1; n <= 12; n++ {
+		parts := splitLEDs(leds, n)
+		assert.True(t, len(parts) <= n, "%d parts for %d workers", len(parts), n)
+
+		// The parts cover every LED once, in order, and fit their pixels.
+		var end int
+		for _, part := range parts {
+			assert.Equal(t, end, part.start)
+			assert.True(t, part.end > part.start)
+			for _, led := range leds[part.start:part.end] {
+				assert.True(t, cap(part.averages) >= len(led.pixels))
+			}
+			end = part.end
+		}
+		assert.Equal(t, len(leds), end)
+	}
+
+	// 45 pixels in 3 parts, which close once they reach 15 and 30 pixels.
+	parts := splitLEDs(leds, 3)
+	var ranges [][2]int
+	for _, part := range parts {
+		ranges = append(ranges, [2]int{part.start, part.end})
+	}
+	assert.Equal(t, [][2]int{{0, 6}, {6, 9}, {9, 10}}, ranges)
+}
+
+func TestLEDCanvasRenderAllocs(t *testing.T) {
+	t.Run("serial", func(t *testing.T) { testLEDCanvasRenderAllocs(t, 0) })
+	t.Run("parallel", func(t *testing.T) { testLEDCanvasRenderAllocs(t, 4) })
+}
+
+func testLEDCanvasRenderAllocs(t *testing.T, workers int) {
+	c, err := NewLEDCanvas(loadDataset(t, "fake"), LEDCanvasOpts{PPI: 72, Workers: workers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	img := randomImage(c.CanvasBounds())
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := c.Render(img); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations per render, got %v", allocs)
+	}
+}
+
+func BenchmarkLEDCanvasRender(b *testing.B) {
+	for _, name := range datasets {
+		for _, workers := range []int{0, 2, 4} {
+			b.Run(fmt.Sprintf("%s/workers=%d", name, workers), func(b *testing.B) {
+				c, err := NewLEDCanvas(loadDataset(b, name), LEDCanvasOpts{PPI: 72, Workers: workers})
+				if err != nil {
+					b.Fatal(err)
+				}
+				defer c.Close()
+
+				img := randomImage(c.CanvasBounds())
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if err := c.Render(img); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
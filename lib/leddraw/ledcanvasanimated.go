@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"libdb.so/acm-christmas/internal/animation"
+	"libdb.so/acm-christmas/lib/ledpos"
 )
 
 // LEDCanvas wraps an LEDCanvas and provides animation capabilities.
@@ -17,20 +18,56 @@ type LEDCanvasAnimated struct {
 	player *animation.Player[LEDStrip]
 	canvas *LEDCanvas
 	adding sync.Mutex // lock
-	opts   LEDCanvasOpts
+	opts   LEDCanvasAnimatedOpts
+
+	// strips are the LED strips that frames are rendered into. They are
+	// reused in turn, which the player allows since it copies the frames that
+	// it shows. Guarded by adding.
+	strips  []LEDStrip
+	stripIx int
+}
+
+// LEDCanvasAnimatedOpts is a set of options for creating a new
+// LEDCanvasAnimated.
+type LEDCanvasAnimatedOpts struct {
+	LEDCanvasOpts
+	// Player is the set of options for the animation player. If
+	// Player.Interpolate is nil, InterpolateLEDStrip is used. Player.Copy is
+	// always CopyLEDStrip.
+	Player animation.PlayerOpts[LEDStrip]
 }
 
 // NewLEDCanvasAnimated creates a new LEDCanvasAnimated.
-func NewLEDCanvasAnimated(ledPositions []image.Point, opts LEDCanvasOpts) (*LEDCanvasAnimated, error) {
-	canvas, err := NewLEDCanvas(ledPositions, opts)
+func NewLEDCanvasAnimated[P ledpos.Position](ledPositions []P, opts LEDCanvasAnimatedOpts) (*LEDCanvasAnimated, error) {
+	canvas, err := NewLEDCanvas(ledPositions, opts.LEDCanvasOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.Player.Interpolate == nil {
+		opts.Player.Interpolate = InterpolateLEDStrip
+	}
+	if opts.Player.Dark == nil {
+		opts.Player.Dark = make(LEDStrip, len(ledPositions))
+	}
+	if opts.Player.MaxFrames == 0 {
+		opts.Player.MaxFrames = 100
+	}
+	opts.Player.Copy = CopyLEDStrip
+
+	// The player lets us reuse a strip once MaxFrames+1 more frames have
+	// been added after it.
+	strips := make([]LEDStrip, opts.Player.MaxFrames+2)
+	backing := make(LEDStrip, len(strips)*len(ledPositions))
+	for i := range strips {
+		strips[i] = backing[i*len(ledPositions) : (i+1)*len(ledPositions)]
+	}
+
 	c := &LEDCanvasAnimated{
-		player: animation.NewPlayer[LEDStrip](),
+		player: animation.NewPlayerWithOpts(opts.Player),
 		canvas: canvas,
 		opts:   opts,
+		strips: strips,
 	}
 	c.C = c.player.C
 	return c, nil
@@ -41,6 +78,86 @@ func (c *LEDCanvasAnimated) Run(ctx context.Context) error {
 	return c.player.Run(ctx)
 }
 
+// Status returns a snapshot of the state of the animation player.
+func (c *LEDCanvasAnimated) Status() animation.PlayerStatus {
+	return c.player.Status()
+}
+
+// Beats returns the clock that frames timed in beats follow. See
+// animation.Player.Beats.
+func (c *LEDCanvasAnimated) Beats() *animation.BeatClock {
+	return c.player.Beats()
+}
+
+// Pause pauses playback. See animation.Player.Pause.
+func (c *LEDCanvasAnimated) Pause(ctx context.Context) error {
+	return c.player.Pause(ctx)
+}
+
+// Resume resumes paused playback. See animation.Player.Resume.
+func (c *LEDCanvasAnimated) Resume(ctx context.Context) error {
+	return c.player.Resume(ctx)
+}
+
+// Seek shows the frame at the given index and continues playing from there.
+// See animation.Player.Seek.
+func (c *LEDCanvasAnimated) Seek(ctx context.Context, index int64) error {
+	return c.player.Seek(ctx, index)
+}
+
+// SetSpeed sets the playback rate. See animation.Player.SetSpeed.
+func (c *LEDCanvasAnimated) SetSpeed(ctx context.Context, speed float64) error {
+	return c.player.SetSpeed(ctx, speed)
+}
+
+// CanvasBounds returns the bounds of the images that frames must have. See
+// LEDCanvas.CanvasBounds.
+func (c *LEDCanvasAnimated) CanvasBounds() image.Rectangle {
+	return c.canvas.CanvasBounds()
+}
+
+// Transform returns the transform from the LED positions to the canvas. See
+// LEDCanvas.Transform.
+func (c *LEDCanvasAnimated) Transform() ledpos.Similarity {
+	return c.canvas.Transform()
+}
+
+// Close stops the render workers of the canvas. See LEDCanvas.Close.
+func (c *LEDCanvasAnimated) Close() {
+	c.adding.Lock()
+	defer c.adding.Unlock()
+
+	c.canvas.Close()
+}
+
+// LEDPositions returns the positions of the LEDs. See LEDCanvas.LEDPositions.
+func (c *LEDCanvasAnimated) LEDPositions() []image.Point {
+	return c.canvas.LEDPositions()
+}
+
+// ReplaceLEDFrames is like ReplaceFrames, but takes frames that are already
+// rendered to the LEDs, such as the ones from the effects package. Each frame
+// must have one color per LED.
+func (c *LEDCanvasAnimated) ReplaceLEDFrames(ctx context.Context, frames []animation.Frame[LEDStrip]) error {
+	if !c.adding.TryLock() {
+		return fmt.Errorf("cannot replace frames: already adding frames")
+	}
+	defer c.adding.Unlock()
+
+	for i, frame := range frames {
+		if len(frame.Image) != len(c.canvas.LEDs()) {
+			return fmt.Errorf(
+				"frame %d has %d LEDs, want %d",
+				i, len(frame.Image), len(c.canvas.LEDs()))
+		}
+	}
+
+	if err := c.player.ReplaceFrames(ctx, frames); err != nil {
+		return fmt.Errorf("cannot replace frames: %w", err)
+	}
+	return nil
+}
+
 // AddFrames adds frames to the animated canvas.
 func (c *LEDCanvasAnimated) AddFrames(ctx context.Context, images []animation.Frame[*image.RGBA]) error {
 	if !c.adding.TryLock() {
@@ -48,8 +165,38 @@ func (c *LEDCanvasAnimated) AddFrames(ctx context.Context, images []animation.Fr
 	}
 	defer c.adding.Unlock()
 
-	for i, frame := range images {
-		rendered, err := renderCanvas(c.canvas, frame)
+	return c.addFrames(ctx, images, 0)
+}
+
+// ReplaceFrames replaces all frames that have not been played yet with the
+// given frames. If the player is configured to crossfade, the new frames fade
+// in over the currently shown frame.
+func (c *LEDCanvasAnimated) ReplaceFrames(ctx context.Context, images []animation.Frame[*image.RGBA]) error {
+	if !c.adding.TryLock() {
+		return fmt.Errorf("cannot replace frames: already adding frames")
+	}
+	defer c.adding.Unlock()
+
+	if len(images) == 0 {
+		return c.player.ReplaceFrames(ctx, nil)
+	}
+
+	// Render the first frame before clearing so that the old frames keep
+	// playing while we render.
+	first, err := c.render(images[0])
+	if err != nil {
+		return fmt.Errorf("cannot render frame 0: %w", err)
+	}
+	if err := c.player.ReplaceFrames(ctx, []animation.Frame[LEDStrip]{first}); err != nil {
+		return fmt.Errorf("cannot replace frames: %w", err)
+	}
+
+	return c.addFrames(ctx, images, 1)
+}
+
+func (c *LEDCanvasAnimated) addFrames(ctx context.Context, images []animation.Frame[*image.RGBA], start int) error {
+	for i := start; i < len(images); i++ {
+		rendered, err := c.render(images[i])
 		if err != nil {
 			return fmt.Errorf("cannot render frame %d: %w", i, err)
 		}
@@ -61,14 +208,15 @@ func (c *LEDCanvasAnimated) AddFrames(ctx context.Context, images []animation.Fr
 	return nil
 }
 
-func renderCanvas(canvas *LEDCanvas, frame animation.Frame[*image.RGBA]) (animation.Frame[LEDStrip], error) {
-	if err := canvas.Render(frame.Image); err != nil {
+// render renders the frame into the next strip. The caller must hold adding.
+func (c *LEDCanvasAnimated) render(frame animation.Frame[*image.RGBA]) (animation.Frame[LEDStrip], error) {
+	if err := c.canvas.Render(frame.Image); err != nil {
 		return animation.Frame[LEDStrip]{}, err
 	}
 
-	return animation.Frame[LEDStrip]{
-		Image:          append(LEDStrip(nil), canvas.LEDs()...),
-		JumpBackAmount: frame.JumpBackAmount,
-		DurationMs:     frame.DurationMs,
-	}, nil
+	leds := c.strips[c.stripIx]
+	c.stripIx = (c.stripIx + 1) % len(c.strips)
+	copy(leds, c.canvas.LEDs())
+
+	return animation.ConvertFrame(frame, leds), nil
 }
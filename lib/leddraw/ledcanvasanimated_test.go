@@ -0,0 +1,70 @@
+package leddraw
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"libdb.so/acm-christmas/internal/animation"
+)
+
+func newTestCanvasAnimated(tb testing.TB, opts LEDCanvasAnimatedOpts) (*LEDCanvasAnimated, context.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	tb.Cleanup(cancel)
+
+	var ledPositions []image.Point
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 10; x++ {
+			ledPositions = append(ledPositions, image.Pt(x*10, y*10))
+		}
+	}
+
+	if opts.PPI == 0 {
+		opts.PPI = 64
+	}
+
+	c, err := NewLEDCanvasAnimated(ledPositions, opts)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	go c.Run(ctx)
+
+	return c, ctx
+}
+
+func TestLEDCanvasAnimatedAllocs(t *testing.T) {
+	c, ctx := newTestCanvasAnimated(t, LEDCanvasAnimatedOpts{})
+
+	frames := []animation.Frame[*image.RGBA]{
+		{Image: image.NewRGBA(c.canvas.CanvasBounds())},
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := c.AddFrames(ctx, frames); err != nil {
+			t.Fatal(err)
+		}
+		<-c.C
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations per frame, got %v", allocs)
+	}
+}
+
+func BenchmarkLEDCanvasAnimated(b *testing.B) {
+	c, ctx := newTestCanvasAnimated(b, LEDCanvasAnimatedOpts{})
+
+	frames := []animation.Frame[*image.RGBA]{
+		{Image: image.NewRGBA(c.canvas.CanvasBounds())},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := c.AddFrames(ctx, frames); err != nil {
+			b.Fatal(err)
+		}
+		<-c.C
+	}
+}
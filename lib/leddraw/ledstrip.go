@@ -30,8 +30,35 @@ func (s LEDStrip) Clear() {
 	}
 }
 
+// Fill sets all LEDs in the strip to the color c.
+func (s LEDStrip) Fill(c xcolor.RGB) {
+	for i := range s {
+		s[i] = c
+	}
+}
+
 // LEDStripDrawer describes an instance that can render a given LED strip.
 type LEDStripDrawer interface {
 	// DrawLEDStrip draws the given LED strip.
 	DrawLEDStrip(context.Context, LEDStrip) error
 }
+
+// InterpolateLEDStrip blends the LED strip a into the LED strip b. It is an
+// animation.InterpolateFunc, so it can be used to blend frames in an
+// animation.Player. Both strips must have the same length.
+func InterpolateLEDStrip(dst, a, b LEDStrip, t float64) LEDStrip {
+	if cap(dst) < len(a) {
+		dst = make(LEDStrip, len(a))
+	}
+	dst = dst[:len(a)]
+	for i := range dst {
+		dst[i] = xcolor.Lerp(a[i], b[i], t)
+	}
+	return dst
+}
+
+// CopyLEDStrip copies the LED strip src into dst, reusing dst if it is large
+// enough. It can be used as animation.PlayerOpts.Copy.
+func CopyLEDStrip(dst, src LEDStrip) LEDStrip {
+	return append(dst[:0], src...)
+}
@@ -0,0 +1,122 @@
+package leddraw
+
+import (
+	"fmt"
+	"image"
+)
+
+// Shape is an area of the tree, in the same coordinates as the LED positions.
+// vision.BoundaryImage is a Shape, so a region can be drawn as a mask image.
+type Shape interface {
+	// PtIn returns true if the given point is in the shape.
+	PtIn(pt image.Point) bool
+}
+
+// ShapeFunc is a function that implements Shape.
+type ShapeFunc func(pt image.Point) bool
+
+// PtIn implements Shape.
+func (f ShapeFunc) PtIn(pt image.Point) bool {
+	return f(pt)
+}
+
+// Rectangle is a rectangular Shape.
+type Rectangle image.Rectangle
+
+// PtIn implements Shape.
+func (r Rectangle) PtIn(pt image.Point) bool {
+	return pt.In(image.Rectangle(r))
+}
+
+// Polygon is a Shape bounded by the lines between its vertices. The last
+// vertex is connected back to the first. Points on the edges may be in or out
+// of the polygon. Self-intersecting polygons use the even-odd rule.
+type Polygon []image.Point
+
+// PtIn implements Shape.
+func (p Polygon) PtIn(pt image.Point) bool {
+	// Cast a ray to the right of pt and count how many edges it crosses.
+	var in bool
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		if (a.Y > pt.Y) == (b.Y > pt.Y) {
+			continue // the edge doesn't cross the ray's height
+		}
+		// X of the edge at the ray's height, compared without dividing.
+		lhs := (pt.X - a.X) * (b.Y - a.Y)
+		rhs := (b.X - a.X) * (pt.Y - a.Y)
+		if (b.Y > a.Y && lhs < rhs) || (b.Y < a.Y && lhs > rhs) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// Region is a named part of the tree, such as the star at the top. It is the
+// set of LEDs that lie within a Shape.
+type Region struct {
+	name string
+	leds []int // sorted
+	size int
+}
+
+// NewRegion creates a region of the LEDs at ledPositions that lie within
+// shape.
+func NewRegion(name string, ledPositions []image.Point, shape Shape) *Region {
+	r := &Region{
+		name: name,
+		size: len(ledPositions),
+	}
+	for i, pt := range ledPositions {
+		if shape.PtIn(pt) {
+			r.leds = append(r.leds, i)
+		}
+	}
+	return r
+}
+
+// NewRegionFromLEDs creates a region of the given LED indices on a strip of
+// size LEDs.
+func NewRegionFromLEDs(name string, size int, leds []int) (*Region, error) {
+	r := &Region{
+		name: name,
+		size: size,
+	}
+	mask := make([]bool, size)
+	for _, i := range leds {
+		if i < 0 || i >= size {
+			return nil, fmt.Errorf("LED %d is out of range [0, %d)", i, size)
+		}
+		mask[i] = true
+	}
+	for i, in := range mask {
+		if in {
+			r.leds = append(r.leds, i)
+		}
+	}
+	return r, nil
+}
+
+// Name returns the name of the region.
+func (r *Region) Name() string {
+	return r.name
+}
+
+// Size returns the number of LEDs on the strip that the region is part of.
+func (r *Region) Size() int {
+	return r.size
+}
+
+// LEDs returns the indices of the LEDs in the region in ascending order. The
+// returned slice must not be modified.
+func (r *Region) LEDs() []int {
+	return r.leds
+}
+
+// Mask copies the LEDs of src that are in the region into dst, leaving the
+// rest of dst untouched. Both strips must be as large as the region's strip.
+func (r *Region) Mask(dst, src LEDStrip) {
+	for _, i := range r.leds {
+		dst[i] = src[i]
+	}
+}
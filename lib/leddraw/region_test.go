@@ -0,0 +1,106 @@
+package leddraw
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/vision"
+)
+
+func TestPolygon(t *testing.T) {
+	// A triangle, like a tree.
+	tree := Polygon{{50, 0}, {100, 100}, {0, 100}}
+
+	tests := []struct {
+		pt image.Point
+		in bool
+	}{
+		{image.Pt(50, 50), true},
+		{image.Pt(50, 99), true},
+		{image.Pt(10, 95), true},
+		{image.Pt(10, 10), false},
+		{image.Pt(90, 10), false},
+		{image.Pt(50, 101), false},
+		{image.Pt(-10, 50), false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.in, tree.PtIn(test.pt), "point %v", test.pt)
+	}
+}
+
+func TestRegion(t *testing.T) {
+	ledPositions := []image.Point{
+		{50, 5},  // star
+		{40, 50}, // middle left
+		{60, 50}, // middle right
+		{10, 95}, // bottom left
+		{90, 95}, // bottom right
+	}
+
+	star := NewRegion("star", ledPositions, Rectangle(image.Rect(40, 0, 60, 20)))
+	assert.Equal(t, "star", star.Name())
+	assert.Equal(t, []int{0}, star.LEDs())
+
+	left := NewRegion("left", ledPositions, ShapeFunc(func(pt image.Point) bool {
+		return pt.X < 50
+	}))
+	assert.Equal(t, []int{1, 3}, left.LEDs())
+
+	bottom, err := NewRegionFromLEDs("bottom", len(ledPositions), []int{4, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, bottom.LEDs())
+
+	// Regions can also be drawn as mask images.
+	mask := image.NewGray(image.Rect(0, 0, 100, 100))
+	for y := 80; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			mask.SetGray(x, y, color.Gray{Y: 0xFF})
+		}
+	}
+	ring := NewRegion("ring", ledPositions, vision.NewBoundaryImage(mask, color.White))
+	assert.Equal(t, []int{3, 4}, ring.LEDs())
+
+	_, err = NewRegionFromLEDs("bad", len(ledPositions), []int{5})
+	assert.Error(t, err)
+
+	red := xcolor.RGB{R: 0xFF}
+	dst := make(LEDStrip, len(ledPositions))
+	left.Mask(dst, LEDStrip{red, red, red, red, red})
+	assert.Equal(t, LEDStrip{{}, red, {}, red, {}}, dst)
+}
+
+func TestCompositorRegions(t *testing.T) {
+	red := xcolor.RGB{R: 0xFF}
+	blue := xcolor.RGB{B: 0xFF}
+
+	left, err := NewRegionFromLEDs("left", 3, []int{0})
+	assert.NoError(t, err)
+	right, err := NewRegionFromLEDs("right", 3, []int{1, 2})
+	assert.NoError(t, err)
+
+	c := NewCompositor(3)
+	leftLayer, err := c.AddLayer("left", LayerOpts{Region: left})
+	assert.NoError(t, err)
+	rightLayer, err := c.AddLayer("right", LayerOpts{Region: right})
+	assert.NoError(t, err)
+
+	_, err = c.AddLayer("bad", LayerOpts{Region: NewRegion("bad", nil, Rectangle{})})
+	assert.EqualError(t, err, `region "bad" is for 0 LEDs, want 3`)
+
+	// Each layer paints the whole strip, but only its own LEDs show.
+	assert.NoError(t, leftLayer.Set(LEDStrip{red, red, red}))
+	assert.NoError(t, rightLayer.Set(LEDStrip{blue, blue, blue}))
+	assert.Equal(t, LEDStrip{red, blue, blue}, c.Composite(nil))
+
+	// Blended layers are limited to their region too.
+	middle, err := NewRegionFromLEDs("middle", 3, []int{1})
+	assert.NoError(t, err)
+	middleLayer, err := c.AddLayer("middle", LayerOpts{Blend: xcolor.BlendAdd, Region: middle})
+	assert.NoError(t, err)
+	assert.NoError(t, middleLayer.Set(LEDStrip{red, red, red}))
+	assert.Equal(t, LEDStrip{red, {R: 0xFF, B: 0xFF}, blue}, c.Composite(nil))
+}
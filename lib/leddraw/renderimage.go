@@ -0,0 +1,266 @@
+package leddraw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+)
+
+// Scaling is how RenderImage scales an image onto the canvas.
+type Scaling uint8
+
+const (
+	// ScaleFill scales the image to cover the whole canvas, cropping the
+	// parts of it that don't fit.
+	ScaleFill Scaling = iota
+	// ScaleFit scales the image to fit inside the canvas. The rest of the
+	// canvas is transparent.
+	ScaleFit
+	// ScaleStretch stretches the image to the size of the canvas, ignoring
+	// its aspect ratio.
+	ScaleStretch
+)
+
+// Alignment is where RenderImage places an image that doesn't have the aspect
+// ratio of the canvas.
+type Alignment uint8
+
+const (
+	AlignCenter Alignment = iota
+	AlignTopLeft
+	AlignTop
+	AlignTopRight
+	AlignLeft
+	AlignRight
+	AlignBottomLeft
+	AlignBottom
+	AlignBottomRight
+)
+
+// fractions returns where the image is placed along the X and Y axes, from 0
+// for the left or top to 1 for the right or bottom.
+func (a Alignment) fractions() (x, y float64) {
+	switch a {
+	case AlignTopLeft:
+		return 0, 0
+	case AlignTop:
+		return 0.5, 0
+	case AlignTopRight:
+		return 1, 0
+	case AlignLeft:
+		return 0, 0.5
+	case AlignRight:
+		return 1, 0.5
+	case AlignBottomLeft:
+		return 0, 1
+	case AlignBottom:
+		return 0.5, 1
+	case AlignBottomRight:
+		return 1, 1
+	default:
+		return 0.5, 0.5
+	}
+}
+
+// ImageOpts is a set of options for RenderImage.
+type ImageOpts struct {
+	// Scaling is how the image is scaled onto the canvas.
+	Scaling Scaling
+	// Align is where the image is placed on the canvas if it doesn't have the
+	// aspect ratio of the canvas. It doesn't apply to ScaleStretch.
+	Align Alignment
+	// Below, if not nil, is what the image is blended over, as in RenderOver.
+	// The parts of the canvas that the image doesn't cover, such as the bars
+	// around an image scaled with ScaleFit, show it as it is.
+	Below LEDStrip
+}
+
+// imageTransform maps canvas pixels to image pixels.
+type imageTransform struct {
+	src image.Rectangle
+	// scaleX and scaleY are the number of image pixels per canvas pixel.
+	scaleX, scaleY float64
+	// offsetX and offsetY are where the image starts on the canvas.
+	offsetX, offsetY float64
+}
+
+func newImageTransform(canvas, src image.Rectangle, opts ImageOpts) imageTransform {
+	cw, ch := float64(canvas.Dx()), float64(canvas.Dy())
+	sw, sh := float64(src.Dx()), float64(src.Dy())
+
+	t := imageTransform{src: src}
+	if opts.Scaling == ScaleStretch {
+		t.scaleX = sw / cw
+		t.scaleY = sh / ch
+		return t
+	}
+
+	var scale float64 // canvas pixels per image pixel
+	if opts.Scaling == ScaleFit {
+		scale = min(cw/sw, ch/sh)
+	} else {
+		scale = max(cw/sw, ch/sh)
+	}
+
+	alignX, alignY := opts.Align.fractions()
+	t.scaleX = 1 / scale
+	t.scaleY = 1 / scale
+	t.offsetX = (cw - sw*scale) * alignX
+	t.offsetY = (ch - sh*scale) * alignY
+	return t
+}
+
+// at returns the image pixel under the center of the canvas pixel (x, y). ok
+// is false if the image doesn't cover it.
+func (t imageTransform) at(x, y int) (p image.Point, ok bool) {
+	p.X = t.src.Min.X + int(math.Floor((float64(x)+0.5-t.offsetX)*t.scaleX))
+	p.Y = t.src.Min.Y + int(math.Floor((float64(y)+0.5-t.offsetY)*t.scaleY))
+	return p, p.In(t.src)
+}
+
+// RenderImage renders an image of any type and size to the LED canvas, scaling
+// it as opts says. Only the pixels that the LEDs use are read from the image,
+// and the common image types, such as *image.NRGBA from PNGs and
+// *image.YCbCr from JPEGs and videos, are read without converting them.
+func (c *LEDCanvas) RenderImage(src image.Image, opts ImageOpts) error {
+	if opts.Below != nil && len(opts.Below) != len(c.leds) {
+		return fmt.Errorf("cannot render over %d LEDs, want %d", len(opts.Below), len(c.leds))
+	}
+	if src.Bounds().Empty() {
+		if opts.Below != nil {
+			copy(c.leds, opts.Below)
+		} else {
+			c.Clear()
+		}
+		return nil
+	}
+
+	if c.scratch == nil {
+		c.scratch = image.NewRGBA(c.canvasRect)
+		c.usedPixels = usedPixels(c.ledPixels)
+	}
+
+	t := newImageTransform(c.canvasRect, src.Bounds(), opts)
+
+	switch src := src.(type) {
+	case *image.RGBA:
+		readPixels(c, t, rgbaReader{src})
+	case *image.NRGBA:
+		readPixels(c, t, nrgbaReader{src})
+	case *image.YCbCr:
+		readPixels(c, t, ycbcrReader{src})
+	case *image.Gray:
+		readPixels(c, t, grayReader{src})
+	case *image.Paletted:
+		// Convert the palette once rather than for every pixel.
+		c.palette = c.palette[:0]
+		for _, pc := range src.Palette {
+			c.palette = append(c.palette, color.RGBAModel.Convert(pc).(color.RGBA))
+		}
+		readPixels(c, t, palettedReader{src, c.palette})
+	default:
+		readPixels(c, t, imageReader{src})
+	}
+
+	return c.RenderOver(c.scratch, opts.Below)
+}
+
+// readPixels copies the pixels that the LEDs use from an image into the
+// scratch canvas.
+func readPixels[R pixelReader](c *LEDCanvas, t imageTransform, r R) {
+	width := c.canvasRect.Dx()
+
+	for _, offset := range c.usedPixels {
+		ix := int(offset) / 4
+		pix := c.scratch.Pix[offset : offset+4 : offset+4]
+
+		p, ok := t.at(ix%width, ix/width)
+		if !ok {
+			pix[0], pix[1], pix[2], pix[3] = 0, 0, 0, 0
+			continue
+		}
+
+		rgba := r.rgbaAt(p.X, p.Y)
+		pix[0], pix[1], pix[2], pix[3] = rgba.R, rgba.G, rgba.B, rgba.A
+	}
+}
+
+// usedPixels returns the offsets of the pixels that the LEDs use, in order and
+// without duplicates.
+func usedPixels(pixels []ledPixel) []int32 {
+	offsets := make([]int32, len(pixels))
+	for i, pixel := range pixels {
+		offsets[i] = pixel.offset
+	}
+	slices.Sort(offsets)
+	return slices.Clip(slices.Compact(offsets))
+}
+
+// pixelReader reads the pixels of an image as premultiplied colors. The
+// readers for the common image types read their pixel buffers directly.
+type pixelReader interface {
+	rgbaAt(x, y int) color.RGBA
+}
+
+type rgbaReader struct{ *image.RGBA }
+
+func (r rgbaReader) rgbaAt(x, y int) color.RGBA {
+	i := r.PixOffset(x, y)
+	s := r.Pix[i : i+4 : i+4]
+	return color.RGBA{s[0], s[1], s[2], s[3]}
+}
+
+type nrgbaReader struct{ *image.NRGBA }
+
+func (r nrgbaReader) rgbaAt(x, y int) color.RGBA {
+	i := r.PixOffset(x, y)
+	s := r.Pix[i : i+4 : i+4]
+	if s[3] == 0xFF {
+		return color.RGBA{s[0], s[1], s[2], 0xFF}
+	}
+	return premultiply(s[0], s[1], s[2], s[3])
+}
+
+type ycbcrReader struct{ *image.YCbCr }
+
+func (r ycbcrReader) rgbaAt(x, y int) color.RGBA {
+	yi := r.YOffset(x, y)
+	ci := r.COffset(x, y)
+	cr, cg, cb := color.YCbCrToRGB(r.Y[yi], r.Cb[ci], r.Cr[ci])
+	return color.RGBA{cr, cg, cb, 0xFF}
+}
+
+type grayReader struct{ *image.Gray }
+
+func (r grayReader) rgbaAt(x, y int) color.RGBA {
+	v := r.Pix[r.PixOffset(x, y)]
+	return color.RGBA{v, v, v, 0xFF}
+}
+
+type palettedReader struct {
+	*image.Paletted
+	palette []color.RGBA
+}
+
+func (r palettedReader) rgbaAt(x, y int) color.RGBA {
+	ix := r.Pix[r.PixOffset(x, y)]
+	if int(ix) >= len(r.palette) {
+		return color.RGBA{}
+	}
+	return r.palette[ix]
+}
+
+type imageReader struct{ image.Image }
+
+func (r imageReader) rgbaAt(x, y int) color.RGBA {
+	return color.RGBAModel.Convert(r.At(x, y)).(color.RGBA)
+}
+
+// premultiply premultiplies a straight alpha color, rounding like
+// color.NRGBA does.
+func premultiply(r, g, b, a uint8) color.RGBA {
+	pr, pg, pb, pa := color.NRGBA{r, g, b, a}.RGBA()
+	return color.RGBA{uint8(pr >> 8), uint8(pg >> 8), uint8(pb >> 8), uint8(pa >> 8)}
+}
@@ -0,0 +1,241 @@
+package leddraw
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+func TestRenderImageTypes(t *testing.T) {
+	c, err := NewLEDCanvas(loadDataset(t, "fake"), LEDCanvasOpts{PPI: 72})
+	assert.NoError(t, err)
+
+	bounds := c.CanvasBounds()
+	rgba := randomImage(bounds)
+
+	// Images of the size of the canvas are rendered as they are, just like
+	// Render does once they are converted to RGBA.
+	tests := []struct {
+		name string
+		img  draw.Image
+	}{
+		{"rgba", image.NewRGBA(bounds)},
+		{"nrgba", image.NewNRGBA(bounds)},
+		{"gray", image.NewGray(bounds)},
+		{"paletted", image.NewPaletted(bounds, palette.Plan9)},
+		{"rgba64", image.NewRGBA64(bounds)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			draw.Draw(test.img, bounds, rgba, image.Point{}, draw.Src)
+			testRenderImageAsRGBA(t, c, test.img)
+		})
+	}
+
+	t.Run("ycbcr", func(t *testing.T) {
+		img := image.NewYCbCr(bounds, image.YCbCrSubsampleRatio420)
+		for i := range img.Y {
+			img.Y[i] = uint8(i * 7)
+		}
+		for i := range img.Cb {
+			img.Cb[i] = uint8(i * 3)
+			img.Cr[i] = uint8(i * 5)
+		}
+		testRenderImageAsRGBA(t, c, img)
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		// Images that don't start at (0, 0) are rendered the same way.
+		img := image.NewRGBA(bounds.Add(image.Pt(100, -50)))
+		draw.Draw(img, img.Rect, rgba, image.Point{}, draw.Src)
+		testRenderImageAsRGBA(t, c, img)
+	})
+}
+
+func testRenderImageAsRGBA(t *testing.T, c *LEDCanvas, img image.Image) {
+	t.Helper()
+
+	rgba := image.NewRGBA(c.CanvasBounds())
+	draw.Draw(rgba, rgba.Rect, img, img.Bounds().Min, draw.Src)
+	assert.NoError(t, c.Render(rgba))
+	want := append(LEDStrip(nil), c.LEDs()...)
+
+	assert.NoError(t, c.RenderImage(img, ImageOpts{Scaling: ScaleStretch}))
+	assert.Equal(t, want, c.LEDs())
+}
+
+func TestRenderImageScaling(t *testing.T) {
+	// The canvas is 20x20, and the corner LEDs each sample the corner pixel
+	// of the canvas.
+	pts := []image.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}}
+	c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 20, Kernel: BilinearKernel})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), c.CanvasBounds())
+
+	// The image is twice as wide as it is tall, with a red left half and a
+	// blue right half.
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(img, image.Rect(0, 0, 20, 20), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(20, 0, 40, 20), image.NewUniform(color.RGBA{0, 0, 255, 255}), image.Point{}, draw.Src)
+
+	var (
+		red   = xcolor.RGB{R: 255}
+		blue  = xcolor.RGB{B: 255}
+		black = xcolor.RGB{}
+	)
+
+	tests := []struct {
+		name string
+		opts ImageOpts
+		want LEDStrip
+	}{
+		{"stretch", ImageOpts{Scaling: ScaleStretch}, LEDStrip{red, blue, red, blue}},
+		{"fill", ImageOpts{Scaling: ScaleFill}, LEDStrip{red, blue, red, blue}},
+		{"fill left", ImageOpts{Scaling: ScaleFill, Align: AlignLeft}, LEDStrip{red, red, red, red}},
+		{"fill right", ImageOpts{Scaling: ScaleFill, Align: AlignRight}, LEDStrip{blue, blue, blue, blue}},
+		{"fit", ImageOpts{Scaling: ScaleFit}, LEDStrip{black, black, black, black}},
+		{"fit top", ImageOpts{Scaling: ScaleFit, Align: AlignTop}, LEDStrip{red, blue, black, black}},
+		{"fit bottom", ImageOpts{Scaling: ScaleFit, Align: AlignBottom}, LEDStrip{black, black, red, blue}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NoError(t, c.RenderImage(img, test.opts))
+			assert.Equal(t, test.want, c.LEDs())
+		})
+	}
+}
+
+func TestRenderImageAllocs(t *testing.T) {
+	c, err := NewLEDCanvas(loadDataset(t, "fake"), LEDCanvasOpts{PPI: 72})
+	assert.NoError(t, err)
+
+	images := []image.Image{
+		randomImage(image.Rect(0, 0, 640, 480)),
+		image.NewNRGBA(image.Rect(0, 0, 640, 480)),
+		image.NewYCbCr(image.Rect(0, 0, 1920, 1080), image.YCbCrSubsampleRatio420),
+		image.NewPaletted(image.Rect(0, 0, 64, 64), palette.Plan9),
+	}
+
+	for _, img := range images {
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := c.RenderImage(img, ImageOpts{Scaling: ScaleFit}); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Equal(t, 0.0, allocs, "%T", img)
+	}
+}
+
+func BenchmarkRenderImage(b *testing.B) {
+	c, err := NewLEDCanvas(loadDataset(b, "fake"), LEDCanvasOpts{PPI: 72})
+	assert.NoError(b, err)
+
+	img := image.NewYCbCr(image.Rect(0, 0, 1920, 1080), image.YCbCrSubsampleRatio420)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := c.RenderImage(img, ImageOpts{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRenderOver(t *testing.T) {
+	for _, kernel := range []KernelFunc{nil, GaussianKernel} {
+		c, err := NewLEDCanvas(loadDataset(t, "acmtree"), LEDCanvasOpts{PPI: 72, Kernel: kernel})
+		assert.NoError(t, err)
+
+		bounds := c.CanvasBounds()
+		white := make(LEDStrip, len(c.LEDs()))
+		white.Fill(xcolor.RGB{R: 255, G: 255, B: 255})
+
+		uniform := func(img draw.Image, c color.Color) image.Image {
+			draw.Draw(img, bounds, image.NewUniform(c), image.Point{}, draw.Src)
+			return img
+		}
+
+		// assertLEDs asserts the color of every LED that has pixels to render.
+		assertLEDs := func(want xcolor.RGB, msg string) {
+			t.Helper()
+			for i, led := range c.LEDs() {
+				if len(c.ledData[i].pixels) > 0 {
+					assert.Equal(t, want, led, "%s: LED %d", msg, i)
+				}
+			}
+		}
+
+		// Half transparent red, premultiplied and straight, over white is
+		// pink either way.
+		pink := xcolor.RGB{R: 255, G: 127, B: 127}
+		premultiplied := uniform(image.NewRGBA(bounds), color.RGBA{128, 0, 0, 128}).(*image.RGBA)
+		straight := uniform(image.NewNRGBA(bounds), color.NRGBA{255, 0, 0, 128})
+
+		assert.NoError(t, c.RenderOver(premultiplied, white))
+		assertLEDs(pink, "premultiplied")
+
+		assert.NoError(t, c.RenderImage(straight, ImageOpts{Below: white}))
+		assertLEDs(pink, "straight")
+
+		// Without anything below, the image is blended over black, which
+		// is the premultiplied color.
+		assert.NoError(t, c.RenderImage(straight, ImageOpts{}))
+		assertLEDs(xcolor.RGB{R: 128}, "over black")
+
+		// An opaque image renders the same over anything, except for the
+		// LEDs without any pixels, which show what is below.
+		opaque := randomImage(bounds)
+		for i := 3; i < len(opaque.Pix); i += 4 {
+			opaque.Pix[i] = 0xFF
+		}
+		assert.NoError(t, c.Render(opaque))
+		want := append(LEDStrip(nil), c.LEDs()...)
+		for i := range want {
+			if len(c.ledData[i].pixels) == 0 {
+				want[i] = white[i]
+			}
+		}
+		assert.NoError(t, c.RenderOver(opaque, white))
+		assert.Equal(t, want, c.LEDs())
+
+		// A transparent image leaves what is below.
+		assert.NoError(t, c.RenderOver(image.NewRGBA(bounds), white))
+		assert.Equal(t, white, c.LEDs())
+	}
+}
+
+func TestRenderOverCurrent(t *testing.T) {
+	pts := []image.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}}
+	c, err := NewLEDCanvas(pts, LEDCanvasOpts{PPI: 20, Kernel: BilinearKernel})
+	assert.NoError(t, err)
+
+	background := image.NewRGBA(c.CanvasBounds())
+	draw.Draw(background, background.Rect, image.NewUniform(color.RGBA{0, 0, 255, 255}), image.Point{}, draw.Src)
+	assert.NoError(t, c.Render(background))
+
+	// Overlay an opaque red line along the top on the current LEDs.
+	overlay := image.NewRGBA(c.CanvasBounds())
+	draw.Draw(overlay, image.Rect(0, 0, 20, 2), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+	assert.NoError(t, c.RenderOver(overlay, c.LEDs()))
+
+	var (
+		red  = xcolor.RGB{R: 255}
+		blue = xcolor.RGB{B: 255}
+	)
+	assert.Equal(t, LEDStrip{red, red, blue, blue}, c.LEDs())
+
+	// The bars around a fitted image show what is below.
+	wide := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(wide, wide.Rect, image.NewUniform(color.RGBA{0, 255, 0, 255}), image.Point{}, draw.Src)
+	assert.NoError(t, c.RenderImage(wide, ImageOpts{Scaling: ScaleFit, Below: c.LEDs()}))
+	assert.Equal(t, LEDStrip{red, red, blue, blue}, c.LEDs())
+
+	assert.Error(t, c.RenderOver(overlay, make(LEDStrip, 3)))
+}
@@ -0,0 +1,106 @@
+package leddraw
+
+import (
+	"image"
+	"sync"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+// renderPool renders the LEDs of a canvas across a fixed set of goroutines.
+// The LEDs are split into contiguous parts with about as many pixels each, and
+// each part is always rendered by the same worker into its own range of the
+// LED strip, so workers never share any state.
+type renderPool struct {
+	canvas *LEDCanvas
+	parts  []renderPart
+	// work sends the job to each worker but the first. The first part is
+	// rendered by the goroutine that calls render.
+	work []chan renderJob
+	wg   sync.WaitGroup
+}
+
+type renderJob struct {
+	src   *image.RGBA
+	below LEDStrip
+}
+
+type renderPart struct {
+	start, end int
+	averages   []xcolor.AveragingPoint
+}
+
+func newRenderPool(c *LEDCanvas, workers int) *renderPool {
+	p := &renderPool{
+		canvas: c,
+		parts:  splitLEDs(c.ledData, workers),
+	}
+
+	p.work = make([]chan renderJob, len(p.parts)-1)
+	for i := range p.work {
+		p.work[i] = make(chan renderJob)
+		go p.worker(p.work[i], &p.parts[i+1])
+	}
+
+	return p
+}
+
+func (p *renderPool) worker(work <-chan renderJob, part *renderPart) {
+	for job := range work {
+		p.canvas.renderLEDs(job.src, job.below, part.start, part.end, part.averages)
+		p.wg.Done()
+	}
+}
+
+// render renders src over below and returns once all parts are done.
+func (p *renderPool) render(src *image.RGBA, below LEDStrip) {
+	p.wg.Add(len(p.work))
+	for _, work := range p.work {
+		work <- renderJob{src, below}
+	}
+
+	part := &p.parts[0]
+	p.canvas.renderLEDs(src, below, part.start, part.end, part.averages)
+
+	p.wg.Wait()
+}
+
+// stop stops the workers. render must not be called afterwards.
+func (p *renderPool) stop() {
+	for _, work := range p.work {
+		close(work)
+	}
+}
+
+// splitLEDs splits leds into up to n contiguous parts with about the same
+// number of pixels each. Every part has at least one LED.
+func splitLEDs(leds []ledData, n int) []renderPart {
+	var total int
+	for _, led := range leds {
+		total += len(led.pixels)
+	}
+
+	parts := make([]renderPart, 0, n)
+	var start, pixels, maxPixels int
+
+	for i, led := range leds {
+		pixels += len(led.pixels)
+		maxPixels = max(maxPixels, len(led.pixels))
+
+		// Close the part once it has its share of the pixels, as long as
+		// enough LEDs are left for the other parts.
+		last := len(parts) == n-1
+		full := pixels*n >= total*(len(parts)+1)
+		if i == len(leds)-1 || (!last && full && len(leds)-i-1 >= n-len(parts)-1) {
+			parts = append(parts, renderPart{
+				start:    start,
+				end:      i + 1,
+				averages: make([]xcolor.AveragingPoint, 0, maxPixels),
+			})
+			start = i + 1
+			maxPixels = 0
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,108 @@
+package leddraw
+
+import (
+	"image"
+	"runtime"
+	"sync"
+	"time"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// Vec2 is a point with floating-point coordinates.
+type Vec2 struct {
+	X, Y float64
+}
+
+// ShaderFunc gives the color of the LED at index i at time t. pos is the
+// position of the LED normalized to the LED bounds, so that X goes from 0 at
+// the leftmost LED to 1 at the rightmost one, and Y goes from 0 at the
+// topmost LED to 1 at the bottommost one, like in images.
+//
+// A ShaderFunc is called for many LEDs at once, so it must be safe to call
+// from multiple goroutines.
+type ShaderFunc func(i int, pos Vec2, t time.Duration) xcolor.RGB
+
+// NormalizePositions normalizes the positions of LEDs to the given bounds, as
+// described in ShaderFunc. If the bounds have no width or height, the
+// respective coordinate is 0.
+func NormalizePositions(ledPositions []image.Point, bounds image.Rectangle) []Vec2 {
+	norm := func(v, min, size int) float64 {
+		if size == 0 {
+			return 0
+		}
+		return float64(v-min) / float64(size)
+	}
+
+	out := make([]Vec2, len(ledPositions))
+	for i, pt := range ledPositions {
+		out[i] = Vec2{
+			X: norm(pt.X, bounds.Min.X, bounds.Dx()),
+			Y: norm(pt.Y, bounds.Min.Y, bounds.Dy()),
+		}
+	}
+	return out
+}
+
+// normalizeExact normalizes exact positions that start at (0, 0) to size, like
+// NormalizePositions.
+func normalizeExact(positions []ledpos.Point, size ledpos.Point) []Vec2 {
+	norm := func(v, size float64) float64 {
+		if size == 0 {
+			return 0
+		}
+		return v / size
+	}
+
+	out := make([]Vec2, len(positions))
+	for i, pt := range positions {
+		out[i] = Vec2{
+			X: norm(pt.X, size.X),
+			Y: norm(pt.Y, size.Y),
+		}
+	}
+	return out
+}
+
+// minShadeChunk is the smallest number of LEDs that Shade gives to each
+// goroutine. Fewer LEDs than this aren't worth the cost of a goroutine.
+const minShadeChunk = 64
+
+// Shade sets each LED in dst to the color that fn gives for it at time t.
+// positions are the normalized positions of the LEDs, one for each LED in
+// dst. The LEDs are split across up to GOMAXPROCS goroutines.
+func Shade(dst LEDStrip, positions []Vec2, t time.Duration, fn ShaderFunc) {
+	shadeAll(dst, positions, t, fn)
+}
+
+func shadeAll[V any, F ~func(int, V, time.Duration) xcolor.RGB](dst LEDStrip, positions []V, t time.Duration, fn F) {
+	if len(positions) != len(dst) {
+		panic("leddraw: Shade: positions and dst differ in length")
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), len(dst)/minShadeChunk)
+	if workers <= 1 {
+		shade(dst, positions, 0, t, fn)
+		return
+	}
+
+	chunk := (len(dst) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(dst); start += chunk {
+		end := min(start+chunk, len(dst))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			shade(dst[start:end], positions[start:end], start, t, fn)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+func shade[V any, F ~func(int, V, time.Duration) xcolor.RGB](dst LEDStrip, positions []V, offset int, t time.Duration, fn F) {
+	for i, pos := range positions {
+		dst[i] = fn(offset+i, pos, t)
+	}
+}
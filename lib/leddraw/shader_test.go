@@ -0,0 +1,79 @@
+package leddraw
+
+import (
+	"image"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+)
+
+func TestNormalizePositions(t *testing.T) {
+	positions := NormalizePositions(
+		[]image.Point{{10, 20}, {30, 20}, {20, 60}},
+		image.Rect(10, 20, 30, 60))
+	assert.Equal(t, []Vec2{{0, 0}, {1, 0}, {0.5, 1}}, positions)
+
+	// A row of LEDs has no height.
+	positions = NormalizePositions([]image.Point{{0, 5}, {10, 5}}, image.Rect(0, 5, 10, 5))
+	assert.Equal(t, []Vec2{{0, 0}, {1, 0}}, positions)
+}
+
+func TestLEDCanvasShade(t *testing.T) {
+	canvas, err := NewLEDCanvas(
+		[]image.Point{{0, 0}, {10, 10}, {20, 0}},
+		LEDCanvasOpts{PPI: 16})
+	assert.NoError(t, err)
+
+	leds := canvas.Shade(time.Second, func(i int, pos Vec2, t time.Duration) xcolor.RGB {
+		return xcolor.RGB{
+			R: uint8(pos.X * 100),
+			G: uint8(pos.Y * 100),
+			B: uint8(t / time.Second),
+		}
+	})
+	assert.Equal(t, LEDStrip{
+		{R: 0, G: 0, B: 1},
+		{R: 50, G: 100, B: 1},
+		{R: 100, G: 0, B: 1},
+	}, leds)
+	assert.Equal(t, canvas.LEDs(), leds)
+}
+
+func TestShadeParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	const n = 1000
+	positions := make([]Vec2, n)
+	for i := range positions {
+		positions[i] = Vec2{X: float64(i) / n}
+	}
+
+	strip := make(LEDStrip, n)
+	Shade(strip, positions, 0, func(i int, pos Vec2, t time.Duration) xcolor.RGB {
+		return xcolor.RGB{R: uint8(i), G: uint8(pos.X * 0xFF)}
+	})
+
+	for i, c := range strip {
+		assert.Equal(t, xcolor.RGB{R: uint8(i), G: uint8(positions[i].X * 0xFF)}, c, "LED %d", i)
+	}
+}
+
+func BenchmarkShade(b *testing.B) {
+	positions := make([]Vec2, 500)
+	for i := range positions {
+		positions[i] = Vec2{X: float64(i%25) / 25, Y: float64(i/25) / 20}
+	}
+	strip := make(LEDStrip, len(positions))
+
+	fn := func(i int, pos Vec2, t time.Duration) xcolor.RGB {
+		return xcolor.HSV(pos.X+pos.Y+t.Seconds(), 1, 1)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Shade(strip, positions, time.Duration(i)*time.Millisecond, fn)
+	}
+}
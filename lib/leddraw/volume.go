@@ -0,0 +1,119 @@
+package leddraw
+
+import (
+	"math"
+	"time"
+
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+// Vec3 is a point in 3D with floating-point coordinates.
+type Vec3 struct {
+	X, Y, Z float64
+}
+
+// ShaderFunc3 is like ShaderFunc, but for LEDs with 3D positions. pos is the
+// position of the LED normalized as described in NormalizePositions3.
+//
+// A ShaderFunc3 is called for many LEDs at once, so it must be safe to call
+// from multiple goroutines.
+type ShaderFunc3 func(i int, pos Vec3, t time.Duration) xcolor.RGB
+
+// NormalizePositions3 normalizes 3D LED positions so that the LEDs are 1 tall.
+// Y goes from 0 at the topmost LED to 1 at the bottommost one, like in
+// images, and X and Z go from -0.5 to 0.5 times the width and depth of the
+// LEDs over their height, so that the middle of the LEDs is at X = Z = 0 and
+// shapes aren't stretched. If the LEDs have no height, they are scaled by
+// their width or depth instead.
+func NormalizePositions3(ledPositions []ledpos.Point3) []Vec3 {
+	out := make([]Vec3, len(ledPositions))
+	if len(ledPositions) == 0 {
+		return out
+	}
+
+	min, max := ledPositions[0], ledPositions[0]
+	for _, pt := range ledPositions[1:] {
+		min.X = math.Min(min.X, pt.X)
+		min.Y = math.Min(min.Y, pt.Y)
+		min.Z = math.Min(min.Z, pt.Z)
+		max.X = math.Max(max.X, pt.X)
+		max.Y = math.Max(max.Y, pt.Y)
+		max.Z = math.Max(max.Z, pt.Z)
+	}
+
+	size := max.Sub(min)
+	scale := size.Y
+	if scale == 0 {
+		scale = math.Max(size.X, size.Z)
+	}
+	if scale == 0 {
+		scale = 1
+	}
+
+	center := min.Add(max).Mul(0.5)
+	for i, pt := range ledPositions {
+		out[i] = Vec3{
+			X: (pt.X - center.X) / scale,
+			Y: (pt.Y - min.Y) / scale,
+			Z: (pt.Z - center.Z) / scale,
+		}
+	}
+	return out
+}
+
+// Shade3 is like Shade, but for LEDs with 3D positions.
+func Shade3(dst LEDStrip, positions []Vec3, t time.Duration, fn ShaderFunc3) {
+	shadeAll(dst, positions, t, fn)
+}
+
+// LEDVolume is a canvas of LEDs with 3D positions, such as the ones from
+// ledpos.Reconstruct. Unlike an LEDCanvas, it has no pixels to render: shapes
+// are drawn by shading each LED from its position.
+type LEDVolume struct {
+	leds      LEDStrip
+	positions []ledpos.Point3
+	normPos   []Vec3
+}
+
+// NewLEDVolume creates a new LEDVolume from the given LED positions.
+func NewLEDVolume(ledPositions []ledpos.Point3) *LEDVolume {
+	positions := make([]ledpos.Point3, len(ledPositions))
+	copy(positions, ledPositions)
+
+	return &LEDVolume{
+		leds:      make(LEDStrip, len(ledPositions)),
+		positions: positions,
+		normPos:   NormalizePositions3(positions),
+	}
+}
+
+// LEDs returns the LEDs of the volume. The returned slice must not be
+// modified.
+func (v *LEDVolume) LEDs() LEDStrip {
+	return v.leds
+}
+
+// LEDPositions returns the positions of the LEDs. The returned slice must not
+// be modified.
+func (v *LEDVolume) LEDPositions() []ledpos.Point3 {
+	return v.positions
+}
+
+// NormalizedLEDPositions returns the positions of the LEDs normalized as
+// described in NormalizePositions3. The returned slice must not be modified.
+func (v *LEDVolume) NormalizedLEDPositions() []Vec3 {
+	return v.normPos
+}
+
+// Shade sets the LEDs of the volume to the colors that fn gives at time t,
+// and returns them. See Shade3 for details.
+func (v *LEDVolume) Shade(t time.Duration, fn ShaderFunc3) LEDStrip {
+	Shade3(v.leds, v.normPos, t, fn)
+	return v.leds
+}
+
+// Clear clears the LED volume.
+func (v *LEDVolume) Clear() {
+	v.leds.Clear()
+}
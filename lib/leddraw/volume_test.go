@@ -0,0 +1,52 @@
+package leddraw
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/internal/xcolor"
+	"libdb.so/acm-christmas/lib/ledpos"
+)
+
+func TestNormalizePositions3(t *testing.T) {
+	positions := NormalizePositions3([]ledpos.Point3{
+		{X: 10, Y: 0, Z: -5},
+		{X: 0, Y: 20, Z: 0},
+		{X: 20, Y: 20, Z: 5},
+	})
+	assert.Equal(t, []Vec3{
+		{X: 0, Y: 0, Z: -0.25},
+		{X: -0.5, Y: 1, Z: 0},
+		{X: 0.5, Y: 1, Z: 0.25},
+	}, positions)
+
+	// A ring of LEDs has no height, so it is scaled by its width.
+	positions = NormalizePositions3([]ledpos.Point3{{X: -4, Y: 3}, {X: 4, Y: 3}, {Y: 3, Z: 2}})
+	assert.Equal(t, []Vec3{{X: -0.5, Z: -0.125}, {X: 0.5, Z: -0.125}, {Z: 0.125}}, positions)
+}
+
+func TestLEDVolumeShade(t *testing.T) {
+	pts := []ledpos.Point3{
+		{X: 0, Y: 0, Z: 10},
+		{X: 0, Y: 10, Z: -10},
+		{X: 0, Y: 5, Z: 0},
+	}
+	volume := NewLEDVolume(pts)
+	pts[0].Z = 0 // the volume has its own copy
+	assert.Equal(t, 10.0, volume.LEDPositions()[0].Z)
+
+	// Light the LEDs in front of the trunk, as seen from the first camera.
+	leds := volume.Shade(time.Second, func(i int, pos Vec3, t time.Duration) xcolor.RGB {
+		if pos.Z > 0 {
+			return xcolor.RGB{R: uint8(math.Round(pos.Z * 100)), B: uint8(t / time.Second)}
+		}
+		return xcolor.RGB{}
+	})
+	assert.Equal(t, LEDStrip{{R: 100, B: 1}, {}, {}}, leds)
+	assert.Equal(t, volume.LEDs(), leds)
+
+	volume.Clear()
+	assert.Equal(t, make(LEDStrip, 3), volume.LEDs())
+}
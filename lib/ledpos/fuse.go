@@ -0,0 +1,308 @@
+package ledpos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"libdb.so/acm-christmas/internal/csvutil"
+)
+
+// Spot is an LED as big-spot found it in a capture, which is a row of
+// led-points.csv.
+type Spot struct {
+	X, Y float64
+	// Area is the number of pixels of the spot. It is 0 if the LED wasn't
+	// found, such as when it is hidden behind a branch.
+	Area int
+}
+
+// Found returns true if the LED was found in the capture.
+func (s Spot) Found() bool {
+	return s.Area > 0
+}
+
+// Point returns the position of the spot.
+func (s Spot) Point() Point {
+	return Point{s.X, s.Y}
+}
+
+// ReadSpotsCSVFile reads the spots of a capture from a led-points.csv file
+// from big-spot, which has X, Y and Area columns.
+func ReadSpotsCSVFile(path string) ([]Spot, error) {
+	return csvutil.UnmarshalFile[Spot](path)
+}
+
+// Similarity is a transform that scales, rotates and moves points without
+// changing their shape.
+type Similarity struct {
+	// A and B are the scale times the cosine and sine of the rotation.
+	A, B float64
+	// T is how far the points are moved after scaling and rotating them.
+	T Point
+}
+
+// IdentitySimilarity is the Similarity that doesn't change points.
+var IdentitySimilarity = Similarity{A: 1}
+
+// Apply transforms p.
+func (s Similarity) Apply(p Point) Point {
+	return Point{
+		X: s.A*p.X - s.B*p.Y + s.T.X,
+		Y: s.B*p.X + s.A*p.Y + s.T.Y,
+	}
+}
+
+// Scale returns how much s scales points by.
+func (s Similarity) Scale() float64 {
+	return math.Hypot(s.A, s.B)
+}
+
+// Rotation returns how far s rotates points, in radians.
+func (s Similarity) Rotation() float64 {
+	return math.Atan2(s.B, s.A)
+}
+
+// Invert returns the Similarity that undoes s. s must not scale points down to
+// nothing.
+func (s Similarity) Invert() Similarity {
+	norm := s.A*s.A + s.B*s.B
+	inv := Similarity{A: s.A / norm, B: -s.B / norm}
+	inv.T = inv.Apply(s.T).Mul(-1)
+	return inv
+}
+
+// FitSimilarity returns the Similarity that moves each point in src the
+// closest to the point at the same index in dst, in the least squares sense.
+// It needs at least two points that aren't all the same.
+func FitSimilarity(src, dst []Point) (Similarity, error) {
+	if len(src) != len(dst) {
+		return Similarity{}, fmt.Errorf("got %d points to move onto %d points", len(src), len(dst))
+	}
+	if len(src) < 2 {
+		return Similarity{}, errors.New("need at least two points to fit a similarity")
+	}
+
+	var srcMean, dstMean Point
+	for i := range src {
+		srcMean = srcMean.Add(src[i])
+		dstMean = dstMean.Add(dst[i])
+	}
+	srcMean = srcMean.Mul(1 / float64(len(src)))
+	dstMean = dstMean.Mul(1 / float64(len(dst)))
+
+	// Treating the points as complex numbers, A+Bi is the sum of conj(p)q
+	// over the sum of |p|², for p and q moved to their means.
+	var a, b, norm float64
+	for i := range src {
+		p := src[i].Sub(srcMean)
+		q := dst[i].Sub(dstMean)
+		a += p.X*q.X + p.Y*q.Y
+		b += p.X*q.Y - p.Y*q.X
+		norm += p.X*p.X + p.Y*p.Y
+	}
+	if norm == 0 {
+		return Similarity{}, errors.New("cannot fit a similarity to points that are all the same")
+	}
+
+	s := Similarity{A: a / norm, B: b / norm}
+	s.T = dstMean.Sub(s.Apply(srcMean))
+	return s, nil
+}
+
+// FusedPoint is the position of an LED fused from several captures.
+type FusedPoint struct {
+	Point
+	// Views is the number of captures that found the LED.
+	Views int
+	// Confidence is the fraction of the captures that found the LED, from 0
+	// to 1. An LED that no capture found has a confidence of 0, and its
+	// position is guessed from the LEDs next to it on the strip.
+	Confidence float64
+	// Residual is the root mean square distance of the LED in each capture
+	// that found it from its fused position, after aligning the captures. It
+	// is 0 if fewer than two captures found the LED.
+	Residual float64
+}
+
+// Fusion is the result of Fuse.
+type Fusion struct {
+	// Points are the fused positions of the LEDs, in the units of the first
+	// capture.
+	Points []FusedPoint
+	// Transforms align each capture to the first one.
+	Transforms []Similarity
+}
+
+// outlierFactor is how many times the median distance of the LEDs from their
+// aligned positions an LED has to be off by for it to be left out when the
+// captures are aligned.
+const outlierFactor = 3
+
+// Fuse fuses the captures of the same LEDs from different viewpoints into one
+// map. Each capture is aligned to the ones before it using a Similarity that
+// is fitted to the LEDs that are found in both, and the position of each LED
+// is the mean of its aligned positions. LEDs that are hidden in one capture
+// are filled in from the others.
+//
+// The captures should be taken from about the same direction, such as from
+// either side of a branch that hides some LEDs, since a Similarity can't undo
+// the change in perspective between two sides of the tree.
+func Fuse(captures [][]Spot) (*Fusion, error) {
+	if len(captures) == 0 {
+		return nil, errors.New("no captures to fuse")
+	}
+
+	n := len(captures[0])
+	for i, c := range captures[1:] {
+		if len(c) != n {
+			return nil, fmt.Errorf("capture %d has %d LEDs, but capture 0 has %d", i+1, len(c), n)
+		}
+	}
+
+	// sums and views are the sums and counts of the aligned positions of
+	// each LED so far.
+	sums := make([]Point, n)
+	views := make([]int, n)
+	transforms := make([]Similarity, len(captures))
+
+	var src, dst []Point
+	var common []int
+
+	for ci, capture := range captures {
+		transform := IdentitySimilarity
+
+		if ci > 0 {
+			common = common[:0]
+			src, dst = src[:0], dst[:0]
+			for i, spot := range capture {
+				if spot.Found() && views[i] > 0 {
+					common = append(common, i)
+					src = append(src, spot.Point())
+					dst = append(dst, sums[i].Mul(1/float64(views[i])))
+				}
+			}
+
+			var err error
+			transform, err = fitRobust(src, dst)
+			if err != nil {
+				return nil, fmt.Errorf("cannot align capture %d with %d LEDs in common: %w", ci, len(common), err)
+			}
+		}
+
+		transforms[ci] = transform
+		for i, spot := range capture {
+			if spot.Found() {
+				sums[i] = sums[i].Add(transform.Apply(spot.Point()))
+				views[i]++
+			}
+		}
+	}
+
+	points := make([]FusedPoint, n)
+	for i := range points {
+		if views[i] == 0 {
+			continue
+		}
+		points[i] = FusedPoint{
+			Point:      sums[i].Mul(1 / float64(views[i])),
+			Views:      views[i],
+			Confidence: float64(views[i]) / float64(len(captures)),
+		}
+	}
+
+	// Measure how far apart the captures of each LED are.
+	for ci, capture := range captures {
+		for i, spot := range capture {
+			if spot.Found() {
+				d := transforms[ci].Apply(spot.Point()).Distance(points[i].Point)
+				points[i].Residual += d * d
+			}
+		}
+	}
+	for i := range points {
+		if points[i].Views > 1 {
+			points[i].Residual = math.Sqrt(points[i].Residual / float64(points[i].Views))
+		} else {
+			points[i].Residual = 0
+		}
+	}
+
+	if err := fillMissing(points); err != nil {
+		return nil, err
+	}
+
+	return &Fusion{
+		Points:     points,
+		Transforms: transforms,
+	}, nil
+}
+
+// fitRobust fits a Similarity from src to dst, then fits it again without the
+// points that are outliers, such as spots that big-spot found on a reflection
+// instead of the LED.
+func fitRobust(src, dst []Point) (Similarity, error) {
+	s, err := FitSimilarity(src, dst)
+	if err != nil {
+		return s, err
+	}
+
+	dists := make([]float64, len(src))
+	for i := range src {
+		dists[i] = s.Apply(src[i]).Distance(dst[i])
+	}
+	sorted := slices.Clone(dists)
+	slices.Sort(sorted)
+	limit := sorted[len(sorted)/2] * outlierFactor
+	if limit == 0 {
+		return s, nil
+	}
+
+	var keptSrc, keptDst []Point
+	for i, d := range dists {
+		if d <= limit {
+			keptSrc = append(keptSrc, src[i])
+			keptDst = append(keptDst, dst[i])
+		}
+	}
+	if len(keptSrc) == len(src) || len(keptSrc) < 2 {
+		return s, nil
+	}
+
+	refit, err := FitSimilarity(keptSrc, keptDst)
+	if err != nil {
+		return s, nil
+	}
+	return refit, nil
+}
+
+// fillMissing guesses the positions of the LEDs that no capture found from the
+// closest found LEDs before and after them on the strip, since LEDs next to
+// each other on the strip are close on the tree.
+func fillMissing(points []FusedPoint) error {
+	prev := -1
+	for i := 0; i <= len(points); i++ {
+		if i < len(points) && points[i].Views == 0 {
+			continue
+		}
+
+		// The LEDs between prev and i are missing.
+		for j := prev + 1; j < i; j++ {
+			switch {
+			case prev == -1 && i == len(points):
+				return errors.New("no capture found any LEDs")
+			case prev == -1:
+				points[j].Point = points[i].Point
+			case i == len(points):
+				points[j].Point = points[prev].Point
+			default:
+				t := float64(j-prev) / float64(i-prev)
+				points[j].Point = points[prev].Point.Add(points[i].Point.Sub(points[prev].Point).Mul(t))
+			}
+		}
+
+		prev = i
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package ledpos
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFitSimilarity(t *testing.T) {
+	want := Similarity{A: 2 * math.Cos(0.3), B: 2 * math.Sin(0.3), T: Pt(10, -5)}
+
+	src := []Point{{0, 0}, {10, 0}, {3, 7}, {-4, 2}}
+	dst := make([]Point, len(src))
+	for i, p := range src {
+		dst[i] = want.Apply(p)
+	}
+
+	got, err := FitSimilarity(src, dst)
+	assert.NoError(t, err)
+	assert.True(t, math.Abs(got.Scale()-2) < 1e-9, "scale %v", got.Scale())
+	assert.True(t, math.Abs(got.Rotation()-0.3) < 1e-9, "rotation %v", got.Rotation())
+	assert.True(t, got.T.Distance(want.T) < 1e-9, "translation %v", got.T)
+
+	_, err = FitSimilarity(src[:1], dst[:1])
+	assert.Error(t, err)
+	_, err = FitSimilarity([]Point{{1, 1}, {1, 1}}, []Point{{0, 0}, {1, 1}})
+	assert.Error(t, err)
+}
+
+func TestSimilarityInvert(t *testing.T) {
+	s := Similarity{A: 2 * math.Cos(0.3), B: 2 * math.Sin(0.3), T: Pt(10, -5)}
+	inv := s.Invert()
+
+	for _, p := range []Point{{0, 0}, {3, 7}, {-4, 2}} {
+		assert.True(t, inv.Apply(s.Apply(p)).Distance(p) < 1e-9, "%v came back as %v", p, inv.Apply(s.Apply(p)))
+	}
+	assert.True(t, math.Abs(inv.Scale()-0.5) < 1e-9, "scale %v", inv.Scale())
+}
+
+func TestFuse(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	want := make([]Point, 40)
+	for i := range want {
+		want[i] = Pt(r.Float64()*100, r.Float64()*200)
+	}
+
+	// The second capture is zoomed in, turned and moved.
+	moved := Similarity{A: 1.5 * math.Cos(-0.1), B: 1.5 * math.Sin(-0.1), T: Pt(30, 12)}
+
+	front := make([]Spot, len(want))
+	side := make([]Spot, len(want))
+	for i, p := range want {
+		front[i] = Spot{X: p.X, Y: p.Y, Area: 10}
+		q := moved.Apply(p)
+		side[i] = Spot{X: q.X, Y: q.Y, Area: 10}
+	}
+
+	// LEDs 0 to 4 are hidden from the front and 5 to 9 from the side, and
+	// LED 20 is hidden from both.
+	for i := 0; i < 5; i++ {
+		front[i] = Spot{}
+		side[i+5] = Spot{}
+	}
+	front[20], side[20] = Spot{}, Spot{}
+
+	// The side capture found a reflection instead of LED 30.
+	side[30].X += 50
+
+	fusion, err := Fuse([][]Spot{front, side})
+	assert.NoError(t, err)
+	assert.Equal(t, IdentitySimilarity, fusion.Transforms[0])
+	assert.True(t, math.Abs(fusion.Transforms[1].Scale()-1/1.5) < 1e-9,
+		"reflection threw off the alignment: %+v", fusion.Transforms[1])
+
+	for i, p := range fusion.Points {
+		switch {
+		case i == 20:
+			// Guessed halfway between its neighbours.
+			assert.Equal(t, 0.0, p.Confidence)
+			assert.True(t, p.Distance(want[19].Add(want[21]).Mul(0.5)) < 1e-9, "LED 20 at %v", p.Point)
+		case i < 10:
+			assert.Equal(t, 1, p.Views, "LED %d", i)
+			assert.Equal(t, 0.5, p.Confidence, "LED %d", i)
+			assert.True(t, p.Distance(want[i]) < 1e-9, "LED %d at %v, want %v", i, p.Point, want[i])
+		case i == 30:
+			assert.Equal(t, 2, p.Views)
+			assert.True(t, p.Residual > 10, "LED 30 residual %v", p.Residual)
+		default:
+			assert.Equal(t, 1.0, p.Confidence, "LED %d", i)
+			assert.True(t, p.Residual < 1e-9, "LED %d residual %v", i, p.Residual)
+			assert.True(t, p.Distance(want[i]) < 1e-9, "LED %d at %v, want %v", i, p.Point, want[i])
+		}
+	}
+
+	t.Run("too few in common", func(t *testing.T) {
+		lonely := make([]Spot, len(want))
+		lonely[0] = side[0]
+		_, err := Fuse([][]Spot{front, lonely})
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		_, err := Fuse([][]Spot{make([]Spot, 3)})
+		assert.Error(t, err)
+	})
+}
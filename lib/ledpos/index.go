@@ -0,0 +1,154 @@
+package ledpos
+
+import (
+	"math"
+	"slices"
+)
+
+// Index is a k-d tree of LED positions, which finds the nearest LED to a
+// point in about O(log n) time instead of comparing the point to every LED.
+type Index struct {
+	pts []Point
+	// order holds the indices of pts as an implicit k-d tree: the middle of
+	// each range is the node that splits it, on X at even depths and on Y at
+	// odd depths, and the halves on either side are its subtrees.
+	order []int
+}
+
+// NewIndex creates an Index of pts. pts must not be modified while the Index
+// is in use.
+func NewIndex(pts []Point) *Index {
+	order := make([]int, len(pts))
+	for i := range order {
+		order[i] = i
+	}
+
+	ix := &Index{pts: pts, order: order}
+	ix.build(order, 0)
+	return ix
+}
+
+func (ix *Index) build(order []int, depth int) {
+	if len(order) < 2 {
+		return
+	}
+
+	slices.SortFunc(order, func(a, b int) int {
+		pa, pb := ix.pts[a], ix.pts[b]
+		if depth%2 == 0 {
+			return cmpFloat(pa.X, pb.X, a, b)
+		}
+		return cmpFloat(pa.Y, pb.Y, a, b)
+	})
+
+	mid := len(order) / 2
+	ix.build(order[:mid], depth+1)
+	ix.build(order[mid+1:], depth+1)
+}
+
+// cmpFloat compares a and b, and then i and j if they are the same.
+func cmpFloat(a, b float64, i, j int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return i - j
+	}
+}
+
+// Len returns the number of points in the Index.
+func (ix *Index) Len() int {
+	return len(ix.pts)
+}
+
+// Nearest returns the index of the point nearest to p and its distance from
+// p. If several points are the nearest, the one with the smallest index is
+// returned. It returns -1 if the Index is empty.
+func (ix *Index) Nearest(p Point) (int, float64) {
+	return ix.nearest(p, -1)
+}
+
+// nearest is like Nearest, but never returns the point at index skip.
+func (ix *Index) nearest(p Point, skip int) (int, float64) {
+	s := nearestSearch{
+		ix:   ix,
+		p:    p,
+		skip: skip,
+		best: -1,
+		dist: math.Inf(1),
+	}
+	s.search(ix.order, 0)
+	if s.best == -1 {
+		return -1, 0
+	}
+	return s.best, math.Sqrt(s.dist)
+}
+
+type nearestSearch struct {
+	ix   *Index
+	p    Point
+	skip int
+	best int
+	dist float64 // squared
+}
+
+func (s *nearestSearch) search(order []int, depth int) {
+	if len(order) == 0 {
+		return
+	}
+
+	mid := len(order) / 2
+	i := order[mid]
+	pt := s.ix.pts[i]
+
+	if i != s.skip {
+		dx, dy := pt.X-s.p.X, pt.Y-s.p.Y
+		d := dx*dx + dy*dy
+		if d < s.dist || (d == s.dist && i < s.best) {
+			s.best, s.dist = i, d
+		}
+	}
+
+	diff := s.p.X - pt.X
+	if depth%2 == 1 {
+		diff = s.p.Y - pt.Y
+	}
+
+	near, far := order[:mid], order[mid+1:]
+	if diff > 0 {
+		near, far = far, near
+	}
+
+	s.search(near, depth+1)
+	// Points on the far side are at least diff away, but may still be as
+	// close as the best one, which matters for the smallest index.
+	if diff*diff <= s.dist {
+		s.search(far, depth+1)
+	}
+}
+
+// MinDistance returns the indices of the two points that are the closest to
+// each other, with i < j, and the distance between them. If several pairs are
+// the closest, the one with the smallest i, then j, is returned. It returns
+// -1, -1 if there are fewer than two points. It runs in about O(n log n)
+// time.
+func MinDistance(pts []Point) (i, j int, dist float64) {
+	if len(pts) < 2 {
+		return -1, -1, 0
+	}
+
+	ix := NewIndex(pts)
+	i, j, dist = -1, -1, math.Inf(1)
+
+	for a, pt := range pts {
+		b, d := ix.nearest(pt, a)
+		lo, hi := min(a, b), max(a, b)
+		if d < dist || (d == dist && (lo < i || (lo == i && hi < j))) {
+			i, j, dist = lo, hi, d
+		}
+	}
+
+	return i, j, dist
+}
@@ -0,0 +1,115 @@
+package ledpos
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// bruteNearest is Index.Nearest without an index.
+func bruteNearest(pts []Point, p Point) (int, float64) {
+	best, dist := -1, math.Inf(1)
+	for i, pt := range pts {
+		dx, dy := pt.X-p.X, pt.Y-p.Y
+		if d := math.Sqrt(dx*dx + dy*dy); d < dist {
+			best, dist = i, d
+		}
+	}
+	return best, dist
+}
+
+// bruteMinDistance is MinDistance without an index.
+func bruteMinDistance(pts []Point) (int, int, float64) {
+	bi, bj, dist := -1, -1, math.Inf(1)
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			dx, dy := pts[i].X-pts[j].X, pts[i].Y-pts[j].Y
+			if d := math.Sqrt(dx*dx + dy*dy); d < dist {
+				bi, bj, dist = i, j, d
+			}
+		}
+	}
+	return bi, bj, dist
+}
+
+func testPointSets() map[string][]Point {
+	r := rand.New(rand.NewSource(1))
+
+	random := make([]Point, 500)
+	for i := range random {
+		random[i] = Pt(r.Float64()*1000, r.Float64()*1000)
+	}
+
+	// A grid has many points that are the same distance apart.
+	var grid []Point
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 12; x++ {
+			grid = append(grid, Pt(float64(x)*3, float64(y)*3))
+		}
+	}
+
+	return map[string][]Point{
+		"random":     random,
+		"grid":       grid,
+		"duplicates": {{5, 5}, {1, 1}, {5, 5}, {9, 0}, {1, 1}},
+		"pair":       {{0, 0}, {3, 4}},
+	}
+}
+
+func TestIndexNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	for name, pts := range testPointSets() {
+		t.Run(name, func(t *testing.T) {
+			ix := NewIndex(pts)
+			assert.Equal(t, len(pts), ix.Len())
+
+			queries := append([]Point(nil), pts...)
+			for i := 0; i < 100; i++ {
+				queries = append(queries, Pt(r.Float64()*1100-50, r.Float64()*1100-50))
+			}
+
+			for _, q := range queries {
+				wantI, wantD := bruteNearest(pts, q)
+				gotI, gotD := ix.Nearest(q)
+				assert.Equal(t, wantI, gotI, "nearest to %v", q)
+				assert.Equal(t, wantD, gotD, "nearest to %v", q)
+			}
+		})
+	}
+
+	i, _ := NewIndex(nil).Nearest(Pt(1, 1))
+	assert.Equal(t, -1, i)
+}
+
+func TestMinDistance(t *testing.T) {
+	for name, pts := range testPointSets() {
+		t.Run(name, func(t *testing.T) {
+			wantI, wantJ, wantD := bruteMinDistance(pts)
+			gotI, gotJ, gotD := MinDistance(pts)
+			assert.Equal(t, [3]any{wantI, wantJ, wantD}, [3]any{gotI, gotJ, gotD})
+		})
+	}
+
+	i, j, _ := MinDistance([]Point{{1, 1}})
+	assert.Equal(t, [2]int{-1, -1}, [2]int{i, j})
+}
+
+func BenchmarkMinDistance(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		r := rand.New(rand.NewSource(1))
+		pts := make([]Point, n)
+		for i := range pts {
+			pts[i] = Pt(r.Float64()*1000, r.Float64()*1000)
+		}
+
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MinDistance(pts)
+			}
+		})
+	}
+}
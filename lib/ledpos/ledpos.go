@@ -0,0 +1,107 @@
+// Package ledpos describes the positions of LEDs, such as the ones that
+// big-spot finds in the images of a camera and writes to led-points.csv.
+//
+// Positions are in the pixels of those images, but unlike image.Point, they
+// can be between pixels. A spot that covers a few pixels of a low resolution
+// capture still gives the exact position of its LED that way.
+package ledpos
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"libdb.so/acm-christmas/internal/csvutil"
+)
+
+// Point is the position of an LED.
+type Point struct {
+	X, Y float64
+}
+
+// Pt is shorthand for Point{X, Y}.
+func Pt(x, y float64) Point {
+	return Point{x, y}
+}
+
+// Position is the type of LED positions that functions taking either integer
+// or exact positions accept.
+type Position interface {
+	image.Point | Point
+}
+
+// Points converts positions of either type to Points.
+func Points[P Position](positions []P) []Point {
+	pts := make([]Point, len(positions))
+	switch positions := any(positions).(type) {
+	case []Point:
+		copy(pts, positions)
+	case []image.Point:
+		for i, p := range positions {
+			pts[i] = Point{float64(p.X), float64(p.Y)}
+		}
+	}
+	return pts
+}
+
+// ImagePoints rounds pts to the nearest pixels.
+func ImagePoints(pts []Point) []image.Point {
+	out := make([]image.Point, len(pts))
+	for i, p := range pts {
+		out[i] = p.ImagePoint()
+	}
+	return out
+}
+
+// ImagePoint rounds p to the nearest pixel.
+func (p Point) ImagePoint() image.Point {
+	return image.Pt(int(math.Round(p.X)), int(math.Round(p.Y)))
+}
+
+// Add returns p+q.
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub returns p-q.
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Mul returns p scaled by k.
+func (p Point) Mul(k float64) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Distance returns the distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// String returns a string like "(1.5,2)".
+func (p Point) String() string {
+	return fmt.Sprintf("(%g,%g)", p.X, p.Y)
+}
+
+// Bounds returns the smallest and largest X and Y of pts. Both are zero if
+// there are no points.
+func Bounds(pts []Point) (min, max Point) {
+	if len(pts) == 0 {
+		return Point{}, Point{}
+	}
+	min, max = pts[0], pts[0]
+	for _, p := range pts[1:] {
+		min.X = math.Min(min.X, p.X)
+		min.Y = math.Min(min.Y, p.Y)
+		max.X = math.Max(max.X, p.X)
+		max.Y = math.Max(max.Y, p.Y)
+	}
+	return min, max
+}
+
+// ReadCSVFile reads LED positions from a CSV file with X and Y columns, such
+// as led-points.csv. The columns may be integers or floats, and any columns
+// after them, such as the area from big-spot, are ignored.
+func ReadCSVFile(path string) ([]Point, error) {
+	return csvutil.UnmarshalFile[Point](path)
+}
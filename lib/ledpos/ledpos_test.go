@@ -0,0 +1,53 @@
+package ledpos
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestPoints(t *testing.T) {
+	ints := []image.Point{{1, 2}, {-3, 4}}
+	assert.Equal(t, []Point{{1, 2}, {-3, 4}}, Points(ints))
+
+	exact := []Point{{1.5, 2.25}}
+	converted := Points(exact)
+	assert.Equal(t, exact, converted)
+
+	// The points are copied.
+	converted[0].X = 0
+	assert.Equal(t, 1.5, exact[0].X)
+}
+
+func TestImagePoints(t *testing.T) {
+	pts := []Point{{1.4, 1.5}, {-0.6, 2}, {3, -2.5}}
+	assert.Equal(t, []image.Point{{1, 2}, {-1, 2}, {3, -3}}, ImagePoints(pts))
+}
+
+func TestBounds(t *testing.T) {
+	min, max := Bounds([]Point{{1, 5}, {-2.5, 3}, {4, 0.5}})
+	assert.Equal(t, Pt(-2.5, 0.5), min)
+	assert.Equal(t, Pt(4, 5), max)
+
+	min, max = Bounds(nil)
+	assert.Equal(t, Point{}, min)
+	assert.Equal(t, Point{}, max)
+}
+
+func TestReadCSVFile(t *testing.T) {
+	// Integer positions from before big-spot wrote centroids still read, and
+	// the area column is ignored.
+	path := filepath.Join(t.TempDir(), "led-points.csv")
+	err := os.WriteFile(path, []byte("1,2,10\n302.66,254.45,53\n"), 0644)
+	assert.NoError(t, err)
+
+	pts, err := ReadCSVFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Point{{1, 2}, {302.66, 254.45}}, pts)
+
+	_, err = ReadCSVFile(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package ledpos
+
+import (
+	"fmt"
+	"math"
+
+	"libdb.so/acm-christmas/internal/csvutil"
+)
+
+// Point3 is the position of an LED in 3D. X and Y are the position of the LED
+// in the images of the first camera, like a Point, and Z is its depth, in the
+// same units as X and Y. Z is 0 on the trunk of the tree and grows towards
+// the first camera.
+type Point3 struct {
+	X, Y, Z float64
+}
+
+// Pt3 is shorthand for Point3{X, Y, Z}.
+func Pt3(x, y, z float64) Point3 {
+	return Point3{x, y, z}
+}
+
+// XY returns the position of p in the images of the first camera.
+func (p Point3) XY() Point {
+	return Point{p.X, p.Y}
+}
+
+// Add returns p+q.
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+// Sub returns p-q.
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+// Mul returns p scaled by k.
+func (p Point3) Mul(k float64) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Distance returns the distance between p and q.
+func (p Point3) Distance(q Point3) float64 {
+	d := p.Sub(q)
+	return math.Sqrt(d.X*d.X + d.Y*d.Y + d.Z*d.Z)
+}
+
+// String returns a string like "(1.5,2,-3)".
+func (p Point3) String() string {
+	return fmt.Sprintf("(%g,%g,%g)", p.X, p.Y, p.Z)
+}
+
+// XYs returns the positions of pts in the images of the first camera.
+func XYs(pts []Point3) []Point {
+	out := make([]Point, len(pts))
+	for i, p := range pts {
+		out[i] = p.XY()
+	}
+	return out
+}
+
+// ReadCSVFile3 reads 3D LED positions from a CSV file with X, Y and Z
+// columns, such as the one that WriteCSVFile3 writes. Unlike ReadCSVFile, the
+// third column is always Z, so it must not be used on led-points.csv files
+// from big-spot, whose third column is the area of the spot.
+func ReadCSVFile3(path string) ([]Point3, error) {
+	return csvutil.UnmarshalFile[Point3](path)
+}
+
+// WriteCSVFile3 writes 3D LED positions to a CSV file with X, Y and Z
+// columns. Since X and Y are the positions in the images of the first camera,
+// ReadCSVFile reads the file as the 2D positions from that camera.
+func WriteCSVFile3(path string, pts []Point3) error {
+	return csvutil.MarshalFile(path, pts)
+}
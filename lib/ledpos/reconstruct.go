@@ -0,0 +1,132 @@
+package ledpos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// View is a capture of the tree from one angle, such as a led-points.csv from
+// big-spot.
+//
+// The views of a tree are assumed to be taken from the same height and
+// distance, around its trunk, which is upright in the images. The camera is
+// far enough from the tree that the LEDs don't get smaller with distance.
+type View struct {
+	// Points are the positions of the LEDs in the images of the view. Every
+	// view of a tree has the same LEDs in the same order.
+	Points []Point
+	// Angle is how far the camera moved around the tree from the first view,
+	// in radians. It moves right as seen from the first camera, so a view
+	// with an angle of π/2 sees the right side of the tree.
+	Angle float64
+	// Axis is the X position of the trunk in the images of the view. Zero
+	// uses the middle of the LEDs, which is the trunk of a symmetric tree.
+	Axis float64
+}
+
+// axis returns the X position of the trunk.
+func (v View) axis() float64 {
+	if v.Axis != 0 {
+		return v.Axis
+	}
+	min, max := Bounds(v.Points)
+	return (min.X + max.X) / 2
+}
+
+// Reconstruct reconstructs the 3D positions of the LEDs from views of the tree
+// at two or more angles. The views are scaled to the height of the first view,
+// and the positions are in the units of its images, as described in Point3.
+//
+// An LED at X and Z from the trunk appears at X cos θ - Z sin θ from the trunk
+// in the view at angle θ, so two views that aren't at the same or opposite
+// angles give its position. With more views, it is the position that is the
+// closest to all of them in the least squares sense.
+func Reconstruct(views []View) ([]Point3, error) {
+	if len(views) < 2 {
+		return nil, errors.New("need at least two views to reconstruct depth")
+	}
+
+	n := len(views[0].Points)
+	for i, v := range views[1:] {
+		if len(v.Points) != n {
+			return nil, fmt.Errorf("view %d has %d LEDs, but view 0 has %d", i+1, len(v.Points), n)
+		}
+	}
+	if n == 0 {
+		return []Point3{}, nil
+	}
+
+	type viewTransform struct {
+		top, axis, scale float64
+		cos, sin         float64
+	}
+
+	transforms := make([]viewTransform, len(views))
+	var height float64
+
+	// ata is the matrix AᵀA of the least squares problem, which is the same
+	// for all LEDs.
+	var ata [2][2]float64
+
+	for i, v := range views {
+		min, max := Bounds(v.Points)
+		if max.Y == min.Y {
+			return nil, fmt.Errorf("view %d has no height", i)
+		}
+		if i == 0 {
+			height = max.Y - min.Y
+		}
+
+		sin, cos := math.Sincos(v.Angle)
+		transforms[i] = viewTransform{
+			top:   min.Y,
+			axis:  v.axis(),
+			scale: height / (max.Y - min.Y),
+			cos:   cos,
+			sin:   -sin,
+		}
+
+		ata[0][0] += cos * cos
+		ata[0][1] -= cos * sin
+		ata[1][1] += sin * sin
+	}
+	ata[1][0] = ata[0][1]
+
+	det := ata[0][0]*ata[1][1] - ata[0][1]*ata[1][0]
+	if det < 1e-9 {
+		return nil, errors.New("views must not all be at the same or opposite angles")
+	}
+
+	first := transforms[0]
+	pts := make([]Point3, n)
+
+	for led := range pts {
+		// atb is Aᵀb, where b is the distance of the LED from the trunk in
+		// each view.
+		var atb [2]float64
+		var y float64
+
+		for i, v := range views {
+			t := transforms[i]
+			pt := v.Points[led]
+
+			u := (pt.X - t.axis) * t.scale
+			atb[0] += t.cos * u
+			atb[1] += t.sin * u
+
+			y += (pt.Y - t.top) * t.scale
+		}
+
+		x := (ata[1][1]*atb[0] - ata[0][1]*atb[1]) / det
+		z := (ata[0][0]*atb[1] - ata[1][0]*atb[0]) / det
+
+		pts[led] = Point3{
+			X: x + first.axis,
+			Y: y/float64(len(views)) + first.top,
+			Z: z,
+		}
+	}
+
+	return pts, nil
+}
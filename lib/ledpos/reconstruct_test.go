@@ -0,0 +1,119 @@
+package ledpos
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// project captures pts from angle, as described in Reconstruct, with the trunk
+// at axis and the image scaled by scale and moved down by top.
+func project(pts []Point3, angle, axis, scale, top float64) View {
+	sin, cos := math.Sincos(angle)
+	v := View{Points: make([]Point, len(pts)), Angle: angle, Axis: axis}
+	for i, p := range pts {
+		v.Points[i] = Point{
+			X: axis + (p.X*cos-p.Z*sin)*scale,
+			Y: top + p.Y*scale,
+		}
+	}
+	return v
+}
+
+func TestReconstruct(t *testing.T) {
+	// LEDs on a cone, with the trunk at X = 0.
+	r := rand.New(rand.NewSource(1))
+	want := make([]Point3, 50)
+	for i := range want {
+		y := r.Float64() * 100
+		angle := r.Float64() * 2 * math.Pi
+		radius := y / 2
+		want[i] = Point3{X: radius * math.Cos(angle), Y: y, Z: radius * math.Sin(angle)}
+	}
+	// Make sure that the top and bottom of the tree are in every view.
+	want[0] = Point3{0, 0, 0}
+	want[1] = Point3{0, 100, 0}
+
+	assertPoints := func(t *testing.T, want, got []Point3) {
+		t.Helper()
+		assert.Equal(t, len(want), len(got))
+		for i := range want {
+			if d := want[i].Distance(got[i]); d > 1e-6 {
+				t.Errorf("LED %d: got %v, want %v", i, got[i], want[i])
+			}
+		}
+	}
+
+	t.Run("front and side", func(t *testing.T) {
+		got, err := Reconstruct([]View{
+			project(want, 0, 100, 1, 0),
+			project(want, math.Pi/2, 100, 1, 0),
+		})
+		assert.NoError(t, err)
+
+		moved := make([]Point3, len(want))
+		for i, p := range want {
+			moved[i] = p.Add(Point3{X: 100})
+		}
+		assertPoints(t, moved, got)
+	})
+
+	t.Run("middle axis", func(t *testing.T) {
+		// Without an axis, the trunk is in the middle of the LEDs, which is
+		// true for a symmetric tree.
+		sym := []Point3{{0, 0, 0}, {-10, 50, 0}, {10, 50, 0}, {0, 50, -10}, {0, 50, 10}}
+		front := project(sym, 0, 20, 1, 0)
+		side := project(sym, math.Pi/2, 30, 1, 0)
+		front.Axis, side.Axis = 0, 0
+
+		got, err := Reconstruct([]View{front, side})
+		assert.NoError(t, err)
+
+		moved := make([]Point3, len(sym))
+		for i, p := range sym {
+			moved[i] = p.Add(Point3{X: 20})
+		}
+		assertPoints(t, moved, got)
+	})
+
+	t.Run("scaled views", func(t *testing.T) {
+		// The positions are in the units of the first view, whose trunk is
+		// at X = 300.
+		views := []View{
+			project(want, 0, 300, 2, 50),
+			project(want, 2*math.Pi/3, 120, 0.5, 10),
+			project(want, 4*math.Pi/3, 400, 1.5, 0),
+		}
+		got, err := Reconstruct(views)
+		assert.NoError(t, err)
+
+		scaled := make([]Point3, len(want))
+		for i, p := range want {
+			scaled[i] = Point3{X: 300 + p.X*2, Y: 50 + p.Y*2, Z: p.Z * 2}
+		}
+		assertPoints(t, scaled, got)
+	})
+
+	t.Run("opposite views", func(t *testing.T) {
+		_, err := Reconstruct([]View{
+			project(want, 0, 0, 1, 0),
+			project(want, math.Pi, 0, 1, 0),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("mismatched views", func(t *testing.T) {
+		_, err := Reconstruct([]View{
+			project(want, 0, 0, 1, 0),
+			project(want[1:], math.Pi/2, 0, 1, 0),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("single view", func(t *testing.T) {
+		_, err := Reconstruct([]View{project(want, 0, 0, 1, 0)})
+		assert.Error(t, err)
+	})
+}
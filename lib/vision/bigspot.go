@@ -7,13 +7,18 @@ import (
 	"image/color"
 
 	"github.com/pierrre/imageutil"
+	"libdb.so/acm-christmas/lib/ledpos"
 )
 
 // BigSpot is a monochromic spot with the largest area in the image.
 type BigSpot struct {
 	Filled *image.Paletted
+	// Center is the center of the bounding box of the spot.
 	Center image.Point
-	Area   int
+	// Centroid is the mean position of the pixels of the spot, which lies
+	// between pixels if the spot is lopsided or has an even width or height.
+	Centroid ledpos.Point
+	Area     int
 }
 
 // ErrNoSpots is returned when there are no spots in the image.
@@ -152,6 +157,8 @@ func (b *SpotFinder) findBiggestSpot(matchingColor color.Color) (fillResult, err
 type fillResult struct {
 	Area   int
 	Bounds image.Rectangle
+	// SumX and SumY are the sums of the coordinates of the filled pixels.
+	SumX, SumY int
 }
 
 func (b *SpotFinder) toBigSpot(r fillResult) BigSpot {
@@ -183,7 +190,11 @@ func (b *SpotFinder) toBigSpot(r fillResult) BigSpot {
 	return BigSpot{
 		Filled: filledImage,
 		Center: center,
-		Area:   r.Area,
+		Centroid: ledpos.Point{
+			X: float64(r.SumX) / float64(r.Area),
+			Y: float64(r.SumY) / float64(r.Area),
+		},
+		Area: r.Area,
 	}
 
 }
@@ -197,7 +208,7 @@ func (b *SpotFinder) fill(x, y int, color color.Color) fillResult {
 
 	replaceFillMarks(b.filled, markFilling, markNotFilled)
 
-	var area int
+	var area, sumX, sumY int
 	bounds := image.Rectangle{
 		Min: image.Point{x, y},
 		Max: image.Point{x + 1, y + 1},
@@ -232,6 +243,8 @@ func (b *SpotFinder) fill(x, y int, color color.Color) fillResult {
 		})
 
 		area++
+		sumX += p.X
+		sumY += p.Y
 		queue = append(queue, image.Point{p.X - 1, p.Y})
 		queue = append(queue, image.Point{p.X + 1, p.Y})
 		queue = append(queue, image.Point{p.X, p.Y - 1})
@@ -241,6 +254,8 @@ func (b *SpotFinder) fill(x, y int, color color.Color) fillResult {
 	return fillResult{
 		Area:   area,
 		Bounds: bounds,
+		SumX:   sumX,
+		SumY:   sumY,
 	}
 }
 
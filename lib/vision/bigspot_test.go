@@ -11,6 +11,7 @@ import (
 	_ "embed"
 
 	"github.com/alecthomas/assert/v2"
+	"libdb.so/acm-christmas/lib/ledpos"
 )
 
 //go:embed dots.png
@@ -47,10 +48,25 @@ func TestFindBiggestSpot(t *testing.T) {
 
 		blob.Filled = nil // don't compare the image
 		assert.Equal(t, BigSpot{
-			Center: image.Point{X: 303, Y: 255},
-			Area:   53,
+			Center:   image.Point{X: 303, Y: 255},
+			Centroid: ledpos.Point{X: 302.66037735849056, Y: 254.45283018867926},
+			Area:     53,
 		}, blob)
 	})
+
+	t.Run("centroid", func(t *testing.T) {
+		// A 2x2 spot has its centroid between its pixels, and a smaller spot
+		// doesn't count.
+		img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+		for _, pt := range []image.Point{{2, 3}, {3, 3}, {2, 4}, {3, 4}, {6, 6}} {
+			img.Set(pt.X, pt.Y, color.White)
+		}
+
+		blob, err := FindBiggestSpot(img, color.White)
+		assert.NoError(t, err)
+		assert.Equal(t, ledpos.Pt(2.5, 3.5), blob.Centroid)
+		assert.Equal(t, 4, blob.Area)
+	})
 }
 
 func BenchmarkFindBiggestSpot(b *testing.B) {